@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"spy-cat-agency/internal/config"
+)
+
+func runCreateAdmin(cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s create-admin NAME", os.Args[0])
+	}
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+
+	admin, token, err := a.adminService().CreateAdmin(args[0])
+	if err != nil {
+		return err
+	}
+	a.audit("create-admin", admin.Name)
+
+	fmt.Printf("Created admin %q (id %d)\n", admin.Name, admin.ID)
+	fmt.Printf("API token: %s\n", token)
+	fmt.Println("The token is not stored and cannot be shown again.")
+	return nil
+}
+
+func runRotateKeys(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rotate-keys", flag.ExitOnError)
+	all := flags.Bool("all", false, "rotate the tokens of every admin")
+	flags.Parse(args)
+
+	if (*all && flags.NArg() != 0) || (!*all && flags.NArg() != 1) {
+		return fmt.Errorf("usage: %s rotate-keys [-all | NAME]", os.Args[0])
+	}
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+	adminService := a.adminService()
+
+	names := flags.Args()
+	if *all {
+		admins, err := adminService.ListAdmins()
+		if err != nil {
+			return fmt.Errorf("failed to list admins: %w", err)
+		}
+		names = names[:0]
+		for _, admin := range admins {
+			names = append(names, admin.Name)
+		}
+		if len(names) == 0 {
+			fmt.Println("No admins to rotate")
+			return nil
+		}
+	}
+
+	for _, name := range names {
+		admin, token, err := adminService.RotateKey(name)
+		if err != nil {
+			return err
+		}
+		a.audit("rotate-keys", admin.Name)
+		fmt.Printf("%s: %s\n", admin.Name, token)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/services"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// app is what the commands share: the configuration, the database and the repository layer
+type app struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	repos *repository.Repositories
+	rates *money.Rates
+}
+
+// newApp connects to the database; commands other than serve only log slow queries and errors
+func newApp(cfg *config.Config, quiet bool) (*app, error) {
+	db, err := database.Initialize(cfg.DatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if quiet {
+		db.Logger = logger.Default.LogMode(logger.Warn)
+	}
+
+	rates := money.NewRates(cfg.PayrollCurrency)
+	if cfg.ExchangeRatesFile != "" {
+		rates, err = money.LoadRates(cfg.ExchangeRatesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load exchange rates: %w", err)
+		}
+	}
+
+	return &app{
+		cfg:   cfg,
+		db:    db,
+		repos: repository.NewRepositories(db),
+		rates: rates,
+	}, nil
+}
+
+// Commands that write data refuse to run against a schema that is behind the binary
+func (a *app) requireMigrated() error {
+	status, err := database.GetMigrationStatus(a.db)
+	if err != nil {
+		return fmt.Errorf("failed to read migration status: %w", err)
+	}
+	for _, entry := range status {
+		if entry.AppliedAt == nil {
+			return fmt.Errorf("migration %04d_%s is pending; run `migrate up` first", entry.Version, entry.Name)
+		}
+	}
+	return nil
+}
+
+func (a *app) missionService() services.MissionService {
+	return services.NewMissionService(a.repos.Missions, a.repos.Targets, a.repos.Cats, a.repos.Availability, a.repos.Skills)
+}
+
+func (a *app) catService() services.CatService {
+	return services.NewCatService(a.repos.Cats, a.repos.Payroll, a.repos.Missions, a.missionService(), a.rates, a.cfg.PayrollCurrency)
+}
+
+func (a *app) importService() services.ImportService {
+	return services.NewImportService(a.repos, a.rates, a.cfg.PayrollCurrency)
+}
+
+func (a *app) exportService() services.ExportService {
+	return services.NewExportService(a.repos.Cats, a.repos.Missions, a.repos.Targets)
+}
+
+func (a *app) adminService() services.AdminService {
+	return services.NewAdminService(a.repos.Admins)
+}
+
+func (a *app) trashService(retention time.Duration) services.TrashService {
+	return services.NewTrashService(a.repos.Cats, a.repos.Missions, a.repos.Targets, retention)
+}
+
+// audit records a command in the audit log under the operating system user who ran it
+func (a *app) audit(action, detail string) {
+	actor := "cli"
+	if user := os.Getenv("USER"); user != "" {
+		actor = "cli:" + user
+	}
+
+	entry := &models.AuditEntry{Actor: actor, Action: action, Detail: detail}
+	if err := a.adminService().RecordAudit(entry); err != nil {
+		log.Printf("Failed to audit %s: %v", action, err)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"spy-cat-agency/internal/backup"
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/database"
+)
+
+func runBackup(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := flags.String("o", "", "write to this file (default: spycat-backup-TIMESTAMP.tar.gz)")
+	schema := flags.String("schema", "", "back up this tenant schema instead of the default one")
+	flags.Parse(args)
+
+	if *schema != "" {
+		tenantCfg, err := tenantConfig(cfg, *schema)
+		if err != nil {
+			return err
+		}
+		cfg = tenantCfg
+	}
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+	version, err := database.SchemaVersion(a.db)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if *output == "" {
+		*output = fmt.Sprintf("spycat-backup-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	manifest, err := backup.Create(a.db, file, version)
+	if err != nil {
+		os.Remove(*output)
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	a.audit("backup", *output)
+
+	for _, entry := range manifest.Files {
+		fmt.Printf("%-16s %d row(s)\n", entry.Table, entry.Rows)
+	}
+	fmt.Printf("Wrote %s (schema version %d)\n", *output, manifest.SchemaVersion)
+	return nil
+}
+
+// runRestore loads a backup into an empty database, or with -schema into a tenant schema that is
+// created and migrated first. The archive is verified completely before anything is written.
+func runRestore(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	schema := flags.String("schema", "", "restore into this tenant schema, creating it if needed")
+	verifyOnly := flags.Bool("verify", false, "only check the archive")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: %s restore [-schema NAME] [-verify] FILE", os.Args[0])
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	manifest, err := backup.Verify(file)
+	if err != nil {
+		return fmt.Errorf("invalid backup: %w", err)
+	}
+	fmt.Printf("Backup from %s, schema version %d\n", manifest.CreatedAt.Format(time.RFC3339), manifest.SchemaVersion)
+	if *verifyOnly {
+		for _, entry := range manifest.Files {
+			fmt.Printf("%-16s %d row(s)\n", entry.Table, entry.Rows)
+		}
+		return nil
+	}
+
+	if *schema != "" {
+		tenantCfg, err := tenantConfig(cfg, *schema)
+		if err != nil {
+			return err
+		}
+		db, err := database.Initialize(cfg.DatabaseURL)
+		if err != nil {
+			return err
+		}
+		if err := database.CreateSchema(db, *schema); err != nil {
+			return fmt.Errorf("failed to create schema %s: %w", *schema, err)
+		}
+		cfg = tenantCfg
+	}
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if *schema != "" {
+		if err := database.Migrate(a.db); err != nil {
+			return err
+		}
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+	version, err := database.SchemaVersion(a.db)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := backup.Restore(a.db, file, version); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+	a.audit("restore", flags.Arg(0))
+
+	for _, entry := range manifest.Files {
+		fmt.Printf("%-16s %d row(s)\n", entry.Table, entry.Rows)
+	}
+	if *schema != "" {
+		fmt.Printf("Restored into schema %s; serve it with search_path=%s,public in DATABASE_URL\n", *schema, *schema)
+	} else {
+		fmt.Println("Restore complete")
+	}
+	return nil
+}
+
+func tenantConfig(cfg *config.Config, schema string) (*config.Config, error) {
+	databaseURL, err := database.TenantURL(cfg.DatabaseURL, schema)
+	if err != nil {
+		return nil, err
+	}
+	tenantCfg := *cfg
+	tenantCfg.DatabaseURL = databaseURL
+	return &tenantCfg, nil
+}
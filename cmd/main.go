@@ -1,63 +1,70 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"text/tabwriter"
 
 	"spy-cat-agency/internal/config"
-	"spy-cat-agency/internal/database"
-	"spy-cat-agency/internal/handlers"
-	"spy-cat-agency/internal/middleware"
-	"spy-cat-agency/internal/repository"
-	"spy-cat-agency/internal/routes"
-	"spy-cat-agency/internal/services"
 
 	_ "spy-cat-agency/docs"
-
-	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(cfg *config.Config, args []string) error
+}
+
+var commands = []command{
+	{"serve", "serve", "Start the HTTP API (the default)", runServe},
+	{"migrate", "migrate up [N] | down [N] | status | create NAME", "Apply, roll back, list or create schema migrations", runMigrate},
+	{"seed", "seed [-fixture NAME | -list] [-cats N] [-missions N] [-seed S] [-force]", "Generate a reproducible dataset", runSeed},
+	{"export", "export [-o FILE] [-type cats|missions|targets [-format csv|ndjson|xlsx]]", "Write all cats and missions as JSON, or one table", runExport},
+	{"import", "import [-dry-run] [-type cats|missions] [-format F] [-mode M] FILE", "Create cats and missions from an export, CSV or NDJSON file", runImport},
+	{"backup", "backup [-schema NAME] [-o FILE]", "Write a checksummed archive of cats, missions, targets, notes and the audit log", runBackup},
+	{"restore", "restore [-schema NAME] [-verify] FILE", "Restore a backup into an empty database or a new tenant schema", runRestore},
+	{"create-admin", "create-admin NAME", "Create an admin and print their API token", runCreateAdmin},
+	{"rotate-keys", "rotate-keys [-all | NAME]", "Issue new API tokens, revoking the old ones", runRotateKeys},
+	{"purge-trash", "purge-trash [-older-than DURATION]", "Permanently remove records deleted before the retention period", runPurgeTrash},
+}
+
 func main() {
 	cfg := config.Load()
 
-	db, err := database.Initialize(cfg.DatabaseURL)
-	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
 	}
 
-	if err := database.Migrate(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage(os.Stdout)
+		return
 	}
 
-	catRepo := repository.NewCatRepository(db)
-	missionRepo := repository.NewMissionRepository(db)
-	targetRepo := repository.NewTargetRepository(db)
-
-	catService := services.NewCatService(catRepo)
-	missionService := services.NewMissionService(missionRepo, targetRepo, catRepo)
-
-	catHandler := handlers.NewCatHandler(catService)
-	missionHandler := handlers.NewMissionHandler(missionService)
-
-	router := gin.Default()
-
-	router.Use(middleware.LoggingMiddleware())
-
-	router.Use(middleware.CORSMiddleware())
-
-	routes.SetupRoutes(router, catHandler, missionHandler)
-
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(cfg, args); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+	printUsage(os.Stderr)
+	os.Exit(2)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s COMMAND [ARGS]\n\nCommands:\n", os.Args[0])
+	table := tabwriter.NewWriter(w, 0, 4, 3, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(table, "  %s\t%s\n", cmd.usage, cmd.summary)
 	}
+	table.Flush()
+	fmt.Fprintln(w, "\nConfiguration is read from the environment, see README.md.")
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/database"
+)
+
+// New migrations are written into the source tree and embedded at build time
+const migrationsDir = "internal/database/migrations"
+
+// runMigrate handles `migrate up [N]`, `migrate down [N]`, `migrate status` and `migrate create NAME`
+func runMigrate(cfg *config.Config, args []string) error {
+	usage := fmt.Errorf("usage: %s migrate up [N] | down [N] | status | create NAME", os.Args[0])
+	if len(args) == 0 || len(args) > 2 {
+		return usage
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return usage
+		}
+		paths, err := database.CreateMigration(migrationsDir, args[1])
+		if err != nil {
+			return fmt.Errorf("failed to create migration: %w", err)
+		}
+		for _, path := range paths {
+			fmt.Println("Created", path)
+		}
+		return nil
+	}
+
+	steps := 0
+	if args[0] == "down" {
+		steps = 1
+	}
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 || args[0] == "status" {
+			return usage
+		}
+		steps = n
+	}
+
+	db, err := database.Initialize(cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := database.MigrateUp(db, steps)
+		for _, migration := range applied {
+			fmt.Println("Applied", migration)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	case "down":
+		rolledBack, err := database.MigrateDown(db, steps)
+		for _, migration := range rolledBack {
+			fmt.Println("Rolled back", migration)
+		}
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No applied migrations")
+		}
+	case "status":
+		status, err := database.GetMigrationStatus(db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, entry := range status {
+			appliedAt := "pending"
+			if entry.AppliedAt != nil {
+				appliedAt = entry.AppliedAt.Format(time.RFC3339)
+			}
+			if entry.Missing {
+				appliedAt += " (no migration file)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", entry.Version, entry.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return usage
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/seed"
+)
+
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	fixtureName := flags.String("fixture", "demo", "named dataset to load, see -list")
+	list := flags.Bool("list", false, "list the fixtures and exit")
+	cats := flags.Int("cats", 0, "number of cats, overrides the fixture")
+	missions := flags.Int("missions", 0, "number of missions, overrides the fixture")
+	seedValue := flags.Int64("seed", 0, "random seed, overrides the fixture")
+	force := flags.Bool("force", false, "seed even if the database already has cats")
+	flags.Parse(args)
+
+	if *list {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSEED\tCATS\tMISSIONS\tDESCRIPTION")
+		for _, fixture := range seed.Fixtures() {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", fixture.Name, fixture.Options.Seed, fixture.Options.Cats, fixture.Options.Missions, fixture.Description)
+		}
+		return w.Flush()
+	}
+
+	fixture, ok := seed.GetFixture(*fixtureName)
+	if !ok {
+		return fmt.Errorf("unknown fixture %q", *fixtureName)
+	}
+	opts := fixture.Options
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "cats":
+			opts.Cats = *cats
+		case "missions":
+			opts.Missions = *missions
+		case "seed":
+			opts.Seed = *seedValue
+		}
+	})
+	opts.Currency = cfg.PayrollCurrency
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+
+	existing, err := a.repos.Cats.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to list cats: %w", err)
+	}
+	if len(existing) > 0 && !*force {
+		return fmt.Errorf("the database already has %d cat(s); use -force to seed anyway", len(existing))
+	}
+
+	result, err := seed.NewGenerator(a.repos, a.missionService()).Generate(opts)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for _, count := range result.Missions {
+		total += count
+	}
+	a.audit("seed", fmt.Sprintf("fixture %s, seed %d: %d cat(s), %d mission(s)", fixture.Name, opts.Seed, result.Cats, total))
+	fmt.Printf("Seeded %d cat(s), %d mission(s) and %d note(s) with seed %d\n", result.Cats, total, result.Notes, opts.Seed)
+	for _, state := range []string{models.MissionStateActive, models.MissionStateOverdue, models.MissionStateCompleted, models.MissionStateUnassigned} {
+		fmt.Printf("  %-11s %d\n", state, result.Missions[state])
+	}
+	return nil
+}
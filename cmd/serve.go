@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/middleware"
+	"spy-cat-agency/internal/routes"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func runServe(cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: %s serve", os.Args[0])
+	}
+
+	a, err := newApp(cfg, false)
+	if err != nil {
+		return err
+	}
+
+	if err := database.Migrate(a.db); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	repos := a.repos
+	if repos.Geo.UsesPostGIS() {
+		log.Println("Proximity search uses PostGIS")
+	} else {
+		log.Println("PostGIS not available; proximity search uses the Haversine fallback")
+	}
+
+	trashService := a.trashService(time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour)
+	retentionService := services.NewRetentionService(repos.Retention, cfg.PseudonymizationKey)
+	adminService := a.adminService()
+
+	overdueChecker := services.NewOverdueChecker(repos.Missions, cfg.OverdueCheckInterval)
+	overdueChecker.Start(context.Background())
+
+	certificationChecker := services.NewCertificationChecker(repos.Skills, cfg.CertificationCheckInterval, cfg.CertificationWarningWindow)
+	certificationChecker.Start(context.Background())
+
+	if cfg.TrashRetentionDays > 0 {
+		trashPurger := services.NewTrashPurger(trashService, cfg.TrashPurgeInterval)
+		trashPurger.Start(context.Background())
+	}
+
+	if cfg.PseudonymizationKey == "" {
+		log.Println("WARNING: PSEUDONYMIZATION_KEY is not set; pseudonyms can be reversed by guessing names")
+	}
+	retentionEnforcer := services.NewRetentionEnforcer(retentionService, cfg.RetentionCheckInterval)
+	retentionEnforcer.Start(context.Background())
+
+	if !cfg.AuthRequired {
+		log.Println("WARNING: AUTH_REQUIRED is not set; the API accepts requests without a token")
+	}
+
+	h := routes.NewHandlers(repos, cfg, a.rates)
+
+	router := gin.Default()
+
+	router.Use(middleware.LoggingMiddleware())
+
+	router.Use(middleware.CORSMiddleware())
+
+	routes.SetupRoutes(router, h,
+		middleware.AuthMiddleware(adminService, cfg.AuthRequired),
+		middleware.AuditMiddleware(adminService))
+
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	log.Printf("Server starting on port %s", port)
+	return router.Run(":" + port)
+}
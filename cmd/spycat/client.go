@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// session carries the flags of one invocation; the profile is only resolved when it is needed,
+// so the config commands work even while the current profile is broken
+type session struct {
+	flags    *globalFlags
+	out      io.Writer
+	settings *profile
+}
+
+func newSession(flags *globalFlags) (*session, error) {
+	if flags.output != "" && !validOutput(flags.output) {
+		return nil, fmt.Errorf("unknown output format %q, use table, json or yaml", flags.output)
+	}
+	return &session{flags: flags, out: os.Stdout}, nil
+}
+
+func (s *session) resolve() (*profile, error) {
+	if s.settings == nil {
+		cfg, err := loadConfig()
+		if err != nil {
+			return nil, err
+		}
+		settings, err := cfg.resolve(s.flags)
+		if err != nil {
+			return nil, err
+		}
+		s.settings = &settings
+	}
+	return s.settings, nil
+}
+
+// apiError is a non-2xx response; the API reports problems as {"error": "..."}
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.Status)
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// call sends body as JSON to /api/v1 + path and decodes the response into out, which may be nil
+func (s *session) call(method, path string, body, out interface{}) error {
+	settings, err := s.resolve()
+	if err != nil {
+		return err
+	}
+
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, apiURL(settings.URL)+path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if settings.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+settings.Token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var errorBody struct {
+			Error string `json:"error"`
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(data, &errorBody) != nil || errorBody.Error == "" {
+			errorBody.Error = strings.TrimSpace(string(data))
+		}
+		if errorBody.Error == "" {
+			errorBody.Error = http.StatusText(resp.StatusCode)
+		}
+		return &apiError{Status: resp.StatusCode, Message: errorBody.Error}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Profiles may hold either the server address or the full API prefix
+func apiURL(base string) string {
+	base = strings.TrimRight(base, "/")
+	if strings.HasSuffix(base, "/api/v1") {
+		return base
+	}
+	return base + "/api/v1"
+}
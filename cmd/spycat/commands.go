@@ -0,0 +1,482 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+)
+
+var apiCommands = []command{
+	{"cats list", "", "List spy cats", defineCatsList},
+	{"cats get", "CAT", "Show a spy cat", defineCatsGet},
+	{"cats create", "--name NAME --breed BREED --years N [--salary AMOUNT]", "Hire a spy cat", defineCatsCreate},
+	{"cats update", "CAT --salary AMOUNT", "Change a cat's salary", defineCatsUpdate},
+	{"missions list", "", "List missions", defineMissionsList},
+	{"missions get", "MISSION", "Show a mission with its targets", defineMissionsGet},
+	{"missions create", "--target NAME:COUNTRY... [--cat CAT]", "Create a mission with one to three targets", defineMissionsCreate},
+	{"missions assign", "MISSION CAT", "Assign a cat to a mission", defineMissionsAssign},
+	{"missions complete", "MISSION", "Complete a mission", defineMissionsComplete},
+	{"targets list", "MISSION", "List the targets of a mission", defineTargetsList},
+	{"targets complete", "MISSION TARGET [--cat CAT]", "Mark a target as completed", defineTargetsComplete},
+	{"notes list", "MISSION TARGET", "List the field notes of a target", defineNotesList},
+	{"notes add", "MISSION TARGET TEXT [--lat LAT --lng LNG]", "Add a field note to a target", defineNotesAdd},
+}
+
+func defineCatsList(fs *flag.FlagSet) runner {
+	status := fs.String("status", "", "only cats with this status: active, suspended, retired or kia")
+	skill := fs.String("skill", "", "only cats with this skill")
+	minLevel := fs.Int("min-level", 0, "minimum level of --skill")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+		query := url.Values{}
+		setQuery(query, "status", *status)
+		setQuery(query, "skill", *skill)
+		if *minLevel > 0 {
+			query.Set("min_level", strconv.Itoa(*minLevel))
+		}
+
+		var cats []models.SpyCat
+		if err := s.call("GET", "/cats"+encodeQuery(query), nil, &cats); err != nil {
+			return err
+		}
+		return s.print(cats, catTable(cats...))
+	}
+}
+
+func defineCatsGet(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "CAT"); err != nil {
+			return err
+		}
+		id, err := parseID("cat", args[0])
+		if err != nil {
+			return err
+		}
+		var cat models.SpyCat
+		if err := s.call("GET", fmt.Sprintf("/cats/%d", id), nil, &cat); err != nil {
+			return err
+		}
+		return s.print(cat, catTable(cat))
+	}
+}
+
+func defineCatsCreate(fs *flag.FlagSet) runner {
+	name := fs.String("name", "", "name of the cat (required)")
+	breed := fs.String("breed", "", "breed, checked against TheCatAPI (required)")
+	years := fs.Int("years", 0, "years of experience (required)")
+	salary := fs.String("salary", "", "salary, e.g. 1250.50")
+	currency := fs.String("currency", "", "salary currency (default: the server's payroll currency)")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+		if *name == "" || *breed == "" {
+			return fmt.Errorf("--name and --breed are required")
+		}
+		req := models.CreateCatRequest{Name: *name, Breed: *breed, YearsExperience: *years}
+		if *salary != "" {
+			amount, err := parseMoney(*salary, *currency)
+			if err != nil {
+				return err
+			}
+			req.Salary = amount
+		}
+
+		var cat models.SpyCat
+		if err := s.call("POST", "/cats", req, &cat); err != nil {
+			return err
+		}
+		return s.print(cat, catTable(cat))
+	}
+}
+
+func defineCatsUpdate(fs *flag.FlagSet) runner {
+	salary := fs.String("salary", "", "new salary, e.g. 1400 (required)")
+	currency := fs.String("currency", "", "salary currency (default: the cat's current currency)")
+	reason := fs.String("reason", "", "reason recorded in the salary history")
+	effective := fs.String("effective-from", "", "date the salary applies from, YYYY-MM-DD or RFC 3339")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "CAT"); err != nil {
+			return err
+		}
+		id, err := parseID("cat", args[0])
+		if err != nil {
+			return err
+		}
+		if *salary == "" {
+			return fmt.Errorf("--salary is required")
+		}
+		amount, err := parseMoney(*salary, *currency)
+		if err != nil {
+			return err
+		}
+		req := models.UpdateCatRequest{Salary: amount, Reason: *reason}
+		if req.EffectiveFrom, err = parseOptionalTime("effective-from", *effective); err != nil {
+			return err
+		}
+
+		var cat models.SpyCat
+		if err := s.call("PUT", fmt.Sprintf("/cats/%d", id), req, &cat); err != nil {
+			return err
+		}
+		return s.print(cat, catTable(cat))
+	}
+}
+
+func defineMissionsList(fs *flag.FlagSet) runner {
+	state := fs.String("state", "", "only missions in this state: unassigned, active, overdue or completed")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+		query := url.Values{}
+		setQuery(query, "state", *state)
+
+		var missions []models.Mission
+		if err := s.call("GET", "/missions"+encodeQuery(query), nil, &missions); err != nil {
+			return err
+		}
+		return s.print(missions, missionTable(missions...))
+	}
+}
+
+func defineMissionsGet(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION"); err != nil {
+			return err
+		}
+		id, err := parseID("mission", args[0])
+		if err != nil {
+			return err
+		}
+		return showMission(s, id)
+	}
+}
+
+func defineMissionsCreate(fs *flag.FlagSet) runner {
+	var targets stringList
+	fs.Var(&targets, "target", "target as \"NAME:COUNTRY\", e.g. \"John Doe:US\"; repeat for up to three targets")
+	cat := fs.Uint("cat", 0, "assign this cat right away")
+	deadline := fs.String("deadline", "", "mission deadline, YYYY-MM-DD or RFC 3339")
+	classification := fs.String("classification", "", "classification of every target: public, confidential, secret or top_secret")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("at least one --target is required")
+		}
+
+		req := models.CreateMissionRequest{}
+		if *cat != 0 {
+			catID := *cat
+			req.CatID = &catID
+		}
+		var err error
+		if req.DeadlineAt, err = parseOptionalTime("deadline", *deadline); err != nil {
+			return err
+		}
+		for _, target := range targets {
+			// The country follows the last colon, so names may contain colons themselves
+			i := strings.LastIndex(target, ":")
+			if i <= 0 || i == len(target)-1 {
+				return fmt.Errorf("invalid target %q, expected NAME:COUNTRY", target)
+			}
+			req.Targets = append(req.Targets, models.CreateTargetRequest{
+				Name:           strings.TrimSpace(target[:i]),
+				Country:        strings.TrimSpace(target[i+1:]),
+				Classification: *classification,
+			})
+		}
+
+		var mission models.Mission
+		if err := s.call("POST", "/missions", req, &mission); err != nil {
+			return err
+		}
+		return s.print(mission, missionTable(mission))
+	}
+}
+
+func defineMissionsAssign(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION", "CAT"); err != nil {
+			return err
+		}
+		missionID, err := parseID("mission", args[0])
+		if err != nil {
+			return err
+		}
+		catID, err := parseID("cat", args[1])
+		if err != nil {
+			return err
+		}
+
+		body := map[string]uint{"cat_id": catID}
+		if err := s.call("PUT", fmt.Sprintf("/missions/%d/assign", missionID), body, nil); err != nil {
+			return err
+		}
+		return showMission(s, missionID)
+	}
+}
+
+func defineMissionsComplete(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION"); err != nil {
+			return err
+		}
+		id, err := parseID("mission", args[0])
+		if err != nil {
+			return err
+		}
+		if err := s.call("PUT", fmt.Sprintf("/missions/%d/complete", id), nil, nil); err != nil {
+			return err
+		}
+		return showMission(s, id)
+	}
+}
+
+func defineTargetsList(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION"); err != nil {
+			return err
+		}
+		id, err := parseID("mission", args[0])
+		if err != nil {
+			return err
+		}
+		var targets []models.Target
+		if err := s.call("GET", fmt.Sprintf("/missions/%d/targets", id), nil, &targets); err != nil {
+			return err
+		}
+		return s.print(targets, targetTable(targets...))
+	}
+}
+
+func defineTargetsComplete(fs *flag.FlagSet) runner {
+	cat := fs.Uint("cat", 0, "the cat completing the target, when several work on the mission")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION", "TARGET"); err != nil {
+			return err
+		}
+		missionID, targetID, err := parseTargetArgs(args)
+		if err != nil {
+			return err
+		}
+
+		var body interface{}
+		if *cat != 0 {
+			catID := *cat
+			body = models.CompleteTargetRequest{CatID: &catID}
+		}
+		if err := s.call("PUT", fmt.Sprintf("/missions/%d/targets/%d/complete", missionID, targetID), body, nil); err != nil {
+			return err
+		}
+
+		var targets []models.Target
+		if err := s.call("GET", fmt.Sprintf("/missions/%d/targets", missionID), nil, &targets); err != nil {
+			return err
+		}
+		for _, target := range targets {
+			if target.ID == targetID {
+				return s.print(target, targetTable(target))
+			}
+		}
+		return nil
+	}
+}
+
+func defineNotesList(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION", "TARGET"); err != nil {
+			return err
+		}
+		missionID, targetID, err := parseTargetArgs(args)
+		if err != nil {
+			return err
+		}
+		var notes []models.TargetNote
+		if err := s.call("GET", fmt.Sprintf("/missions/%d/targets/%d/notes", missionID, targetID), nil, &notes); err != nil {
+			return err
+		}
+		return s.print(notes, noteTable(notes...))
+	}
+}
+
+func defineNotesAdd(fs *flag.FlagSet) runner {
+	cat := fs.Uint("cat", 0, "the cat writing the note, when several work on the mission")
+	lat := fs.String("lat", "", "latitude where the target was seen")
+	lng := fs.String("lng", "", "longitude where the target was seen")
+	seenAt := fs.String("seen-at", "", "when the target was seen, RFC 3339 (default: now)")
+
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "MISSION", "TARGET", "TEXT"); err != nil {
+			return err
+		}
+		missionID, targetID, err := parseTargetArgs(args)
+		if err != nil {
+			return err
+		}
+
+		req := models.AddTargetNoteRequest{Notes: args[2]}
+		if *cat != 0 {
+			catID := *cat
+			req.CatID = &catID
+		}
+		if (*lat == "") != (*lng == "") {
+			return fmt.Errorf("--lat and --lng must be given together")
+		}
+		if *lat != "" {
+			latitude, err := strconv.ParseFloat(*lat, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --lat %q", *lat)
+			}
+			longitude, err := strconv.ParseFloat(*lng, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --lng %q", *lng)
+			}
+			req.Latitude, req.Longitude = &latitude, &longitude
+		}
+		if req.SeenAt, err = parseOptionalTime("seen-at", *seenAt); err != nil {
+			return err
+		}
+
+		var note models.TargetNote
+		if err := s.call("POST", fmt.Sprintf("/missions/%d/targets/%d/notes", missionID, targetID), req, &note); err != nil {
+			return err
+		}
+		return s.print(note, noteTable(note))
+	}
+}
+
+func showMission(s *session, id uint) error {
+	var mission models.Mission
+	if err := s.call("GET", fmt.Sprintf("/missions/%d", id), nil, &mission); err != nil {
+		return err
+	}
+	if err := s.print(mission, missionTable(mission)); err != nil {
+		return err
+	}
+	// JSON and YAML already include the targets
+	if format, _ := s.format(); format == "table" && len(mission.Targets) > 0 {
+		fmt.Fprintln(s.out)
+		return s.print(mission.Targets, targetTable(mission.Targets...))
+	}
+	return nil
+}
+
+func catTable(cats ...models.SpyCat) table {
+	t := table{headers: []string{"ID", "NAME", "BREED", "YEARS", "SALARY", "STATUS", "AVAILABLE"}}
+	for _, cat := range cats {
+		t.rows = append(t.rows, []string{
+			fmt.Sprint(cat.ID), cat.Name, cat.Breed, fmt.Sprint(cat.YearsExperience),
+			cat.Salary.String(), cat.Status, formatBool(cat.IsAvailable),
+		})
+	}
+	return t
+}
+
+func missionTable(missions ...models.Mission) table {
+	t := table{headers: []string{"ID", "STATE", "CAT", "DEADLINE", "TARGETS"}}
+	for _, mission := range missions {
+		completed := 0
+		for _, target := range mission.Targets {
+			if target.IsCompleted {
+				completed++
+			}
+		}
+		t.rows = append(t.rows, []string{
+			fmt.Sprint(mission.ID), models.MissionState(&mission), formatID(mission.CatID),
+			formatTime(mission.DeadlineAt), fmt.Sprintf("%d/%d", completed, len(mission.Targets)),
+		})
+	}
+	return t
+}
+
+func targetTable(targets ...models.Target) table {
+	t := table{headers: []string{"ID", "NAME", "COUNTRY", "CLASSIFICATION", "CAT", "COMPLETED", "LAST SEEN"}}
+	for _, target := range targets {
+		t.rows = append(t.rows, []string{
+			fmt.Sprint(target.ID), target.Name, target.Country, target.Classification,
+			formatID(target.CatID), formatBool(target.IsCompleted), formatTime(target.LastSeenAt),
+		})
+	}
+	return t
+}
+
+func noteTable(notes ...models.TargetNote) table {
+	t := table{headers: []string{"ID", "SEEN AT", "CAT", "LOCATION", "NOTES"}}
+	for _, note := range notes {
+		t.rows = append(t.rows, []string{
+			fmt.Sprint(note.ID), formatTime(&note.SeenAt), formatID(note.CatID),
+			formatCoordinates(note.Latitude, note.Longitude), truncate(note.Notes, 60),
+		})
+	}
+	return t
+}
+
+func parseID(kind, value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid %s ID %q", kind, value)
+	}
+	return uint(id), nil
+}
+
+func parseTargetArgs(args []string) (uint, uint, error) {
+	missionID, err := parseID("mission", args[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	targetID, err := parseID("target", args[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return missionID, targetID, nil
+}
+
+// parseMoney leaves the currency empty when none is given so the server applies its default
+func parseMoney(amount, currency string) (money.Money, error) {
+	if currency == "" {
+		return money.ParseBare(amount)
+	}
+	return money.Parse(amount, currency)
+}
+
+func parseOptionalTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid --%s %q, expected YYYY-MM-DD or RFC 3339", name, value)
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func encodeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
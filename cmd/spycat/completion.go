@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+func defineCompletion(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "SHELL"); err != nil {
+			return err
+		}
+		switch args[0] {
+		case "bash":
+			writeBashCompletion(s.out)
+		case "zsh":
+			// zsh runs the bash script through its bash compatibility layer
+			fmt.Fprintln(s.out, "autoload -U +X bashcompinit && bashcompinit")
+			writeBashCompletion(s.out)
+		default:
+			return fmt.Errorf("unsupported shell %q, use bash or zsh", args[0])
+		}
+		return nil
+	}
+}
+
+// writeBashCompletion generates the script from the command table, so new commands and flags complete without extra work
+func writeBashCompletion(w io.Writer) {
+	var top []string
+	verbs := map[string][]string{}
+	for _, cmd := range commands {
+		resource, verb, nested := strings.Cut(cmd.name, " ")
+		if _, seen := verbs[resource]; !seen {
+			top = append(top, resource)
+			verbs[resource] = nil
+		}
+		if nested {
+			verbs[resource] = append(verbs[resource], verb)
+		}
+	}
+
+	fmt.Fprintln(w, "# spycat bash completion, load with: source <(spycat completion bash)")
+	fmt.Fprintln(w, "_spycat() {")
+	fmt.Fprintln(w, `    local cur="${COMP_WORDS[COMP_CWORD]}"`)
+	fmt.Fprintln(w, "    if [[ $COMP_CWORD -eq 1 ]]; then")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(append(top, "help"), " "))
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "    if [[ $COMP_CWORD -eq 2 ]]; then")
+	fmt.Fprintln(w, `        case "${COMP_WORDS[1]}" in`)
+	for _, resource := range top {
+		if len(verbs[resource]) > 0 {
+			fmt.Fprintf(w, "            %s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", resource, strings.Join(verbs[resource], " "))
+		}
+	}
+	fmt.Fprintln(w, `            completion) COMPREPLY=($(compgen -W "bash zsh" -- "$cur")); return ;;`)
+	fmt.Fprintln(w, "        esac")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, `    if [[ "${COMP_WORDS[COMP_CWORD-1]}" == "-o" ]]; then`)
+	fmt.Fprintln(w, `        COMPREPLY=($(compgen -W "table json yaml" -- "$cur"))`)
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, `    if [[ "$cur" == -* ]]; then`)
+	fmt.Fprintln(w, `        case "${COMP_WORDS[1]} ${COMP_WORDS[2]}" in`)
+	for _, cmd := range commands {
+		pattern := fmt.Sprintf("%q", cmd.name)
+		if !strings.Contains(cmd.name, " ") {
+			pattern = fmt.Sprintf("\"%s \"*", cmd.name)
+		}
+		fmt.Fprintf(w, "            %s) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", pattern, strings.Join(commandFlags(cmd), " "))
+	}
+	fmt.Fprintln(w, "        esac")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -o default -F _spycat spycat")
+}
+
+// commandFlags defines the command on a scratch flag set to learn its flag names
+func commandFlags(cmd command) []string {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	defineGlobalFlags(fs)
+	cmd.define(fs)
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		if len(f.Name) == 1 {
+			names = append(names, "-"+f.Name)
+		} else {
+			names = append(names, "--"+f.Name)
+		}
+	})
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultURL = "http://localhost:8080"
+
+type profile struct {
+	URL    string `yaml:"url"`
+	Token  string `yaml:"token,omitempty"`
+	Output string `yaml:"output,omitempty"`
+}
+
+// cliConfig is the profiles file, spycat/config.yaml in the user config directory unless SPYCAT_CONFIG says otherwise
+type cliConfig struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+type globalFlags struct {
+	profile string
+	url     string
+	token   string
+	output  string
+}
+
+func defineGlobalFlags(fs *flag.FlagSet) *globalFlags {
+	opts := &globalFlags{}
+	fs.StringVar(&opts.profile, "profile", "", "config profile to use (default: the current profile, or $SPYCAT_PROFILE)")
+	fs.StringVar(&opts.url, "url", "", "API base URL, overrides the profile (or $SPYCAT_URL)")
+	fs.StringVar(&opts.token, "token", "", "API token, overrides the profile (or $SPYCAT_TOKEN)")
+	fs.StringVar(&opts.output, "o", "", "output format: table, json or yaml")
+	return opts
+}
+
+func configPath() (string, error) {
+	if path := os.Getenv("SPYCAT_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "spycat", "config.yaml"), nil
+}
+
+// loadConfig returns an empty configuration when the file does not exist yet
+func loadConfig() (*cliConfig, error) {
+	cfg := &cliConfig{Profiles: map[string]profile{}}
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]profile{}
+	}
+	return cfg, nil
+}
+
+// The file holds API tokens, so it is only readable by its owner
+func (c *cliConfig) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// resolve picks each setting from the flags, then the environment, then the profile
+func (c *cliConfig) resolve(opts *globalFlags) (profile, error) {
+	name := firstNonEmpty(opts.profile, os.Getenv("SPYCAT_PROFILE"), c.Current)
+	selected := profile{}
+	if name != "" {
+		var ok bool
+		if selected, ok = c.Profiles[name]; !ok {
+			return profile{}, fmt.Errorf("profile %q does not exist, see \"spycat config list\"", name)
+		}
+	}
+
+	return profile{
+		URL:    firstNonEmpty(opts.url, os.Getenv("SPYCAT_URL"), selected.URL, defaultURL),
+		Token:  firstNonEmpty(opts.token, os.Getenv("SPYCAT_TOKEN"), selected.Token),
+		Output: firstNonEmpty(opts.output, selected.Output, "table"),
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var configCommands = []command{
+	{"config set", "NAME [--url URL] [--token TOKEN] [-o FORMAT]", "Create or update a profile", defineConfigSet},
+	{"config use", "NAME", "Make a profile the default", defineConfigUse},
+	{"config list", "", "List profiles", defineConfigList},
+}
+
+// config set takes the values from the global --url, --token and -o flags
+func defineConfigSet(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "NAME"); err != nil {
+			return err
+		}
+		url, token, output := s.flags.url, s.flags.token, s.flags.output
+		if output != "" && !validOutput(output) {
+			return fmt.Errorf("unknown output format %q", output)
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		entry := cfg.Profiles[name]
+		entry.URL = firstNonEmpty(url, entry.URL)
+		entry.Token = firstNonEmpty(token, entry.Token)
+		entry.Output = firstNonEmpty(output, entry.Output)
+		cfg.Profiles[name] = entry
+		if cfg.Current == "" {
+			cfg.Current = name
+		}
+		if err := cfg.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "Profile %q saved\n", name)
+		return nil
+	}
+}
+
+func defineConfigUse(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		if err := requireArgs(args, "NAME"); err != nil {
+			return err
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if _, ok := cfg.Profiles[args[0]]; !ok {
+			return fmt.Errorf("profile %q does not exist", args[0])
+		}
+		cfg.Current = args[0]
+		if err := cfg.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "Now using profile %q\n", args[0])
+		return nil
+	}
+}
+
+func defineConfigList(fs *flag.FlagSet) runner {
+	return func(s *session, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		rows := make([][]string, 0, len(names))
+		listed := make([]map[string]interface{}, 0, len(names))
+		for _, name := range names {
+			entry := cfg.Profiles[name]
+			current := ""
+			if name == cfg.Current {
+				current = "*"
+			}
+			rows = append(rows, []string{current, name, entry.URL, maskToken(entry.Token), entry.Output})
+			listed = append(listed, map[string]interface{}{
+				"name": name, "current": name == cfg.Current, "url": entry.URL, "token": maskToken(entry.Token), "output": entry.Output,
+			})
+		}
+		return s.print(listed, table{[]string{"CURRENT", "NAME", "URL", "TOKEN", "OUTPUT"}, rows})
+	}
+}
+
+// Only the first characters of a token are shown so a listing can be shared safely
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:8] + "…"
+}
@@ -0,0 +1,136 @@
+// Command spycat talks to the Spy Cat Agency API from the terminal
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+type runner func(s *session, args []string) error
+
+// A command registers its flags on the flag set and returns the function that runs it
+type command struct {
+	name    string
+	args    string
+	summary string
+	define  func(fs *flag.FlagSet) runner
+}
+
+var commands []command
+
+func init() {
+	commands = append(commands, apiCommands...)
+	commands = append(commands, configCommands...)
+	commands = append(commands, command{"completion", "bash|zsh", "Print a shell completion script", defineCompletion})
+}
+
+func main() {
+	args := os.Args[1:]
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(os.Stdout)
+		return
+	}
+
+	cmd, rest, ok := findCommand(args)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", strings.Join(args[:min(len(args), 2)], " "))
+		printUsage(os.Stderr)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("spycat "+cmd.name, flag.ContinueOnError)
+	opts := defineGlobalFlags(fs)
+	run := cmd.define(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: spycat %s [FLAGS] %s\n\n%s\n\nFlags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterleaved(fs, rest)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	s, err := newSession(opts)
+	if err == nil {
+		err = run(s, positional)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// findCommand matches "resource verb" first and then single-word commands
+func findCommand(args []string) (command, []string, bool) {
+	if len(args) > 1 {
+		for _, cmd := range commands {
+			if cmd.name == args[0]+" "+args[1] {
+				return cmd, args[2:], true
+			}
+		}
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd, args[1:], true
+		}
+	}
+	return command{}, nil, false
+}
+
+// parseInterleaved lets flags follow positional arguments, e.g. "notes add 1 2 'text' --cat 3"
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: spycat COMMAND [FLAGS] [ARGS]\n\nCommands:\n")
+	table := tabwriter.NewWriter(w, 0, 4, 3, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(table, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.summary)
+	}
+	table.Flush()
+	fmt.Fprintln(w, "\nEvery command accepts -o table|json|yaml, --profile, --url and --token.")
+	fmt.Fprintln(w, "Run \"spycat COMMAND -h\" for the flags of a command.")
+}
+
+// stringList collects a flag that may be given several times
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func requireArgs(args []string, names ...string) error {
+	if len(args) != len(names) {
+		return fmt.Errorf("expected %d argument(s): %s", len(names), strings.Join(names, " "))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func validOutput(format string) bool {
+	return format == "table" || format == "json" || format == "yaml"
+}
+
+func (s *session) format() (string, error) {
+	if s.flags.output != "" {
+		return s.flags.output, nil
+	}
+	settings, err := s.resolve()
+	if err != nil {
+		return "", err
+	}
+	return settings.Output, nil
+}
+
+// print writes data as JSON or YAML, or the table when the table format is selected.
+// YAML goes through JSON first so the field names match the API.
+func (s *session) print(data interface{}, t table) error {
+	format, err := s.format()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		encoded, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(s.out, string(encoded))
+		return err
+	case "yaml":
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(encoded, &generic); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = s.out.Write(out)
+		return err
+	default:
+		w := tabwriter.NewWriter(s.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.headers, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+func formatID(id *uint) string {
+	if id == nil {
+		return "-"
+	}
+	return fmt.Sprint(*id)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatCoordinates(lat, lng *float64) string {
+	if lat == nil || lng == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.5f,%.5f", *lat, *lng)
+}
+
+// truncate keeps table rows on one line
+func truncate(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if len([]rune(text)) <= max {
+		return text
+	}
+	return string([]rune(text)[:max-1]) + "…"
+}
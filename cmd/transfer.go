@@ -0,0 +1,445 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/country"
+	"spy-cat-agency/internal/export"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/services"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// agencyExport is the file format of export and import. Files written before salary history and
+// availability were exported still import, without them.
+type agencyExport struct {
+	ExportedAt    time.Time                  `json:"exported_at"`
+	Cats          []models.SpyCat            `json:"cats"`
+	Missions      []models.Mission           `json:"missions"`
+	SalaryHistory []models.SalaryRecord      `json:"salary_history,omitempty"`
+	Availability  []models.AvailabilityEntry `json:"availability,omitempty"`
+}
+
+// errDryRun rolls back the transaction of a dry-run import
+var errDryRun = errors.New("dry run")
+
+func runExport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	output := flags.String("o", "", "write to this file instead of standard output")
+	exportType := flags.String("type", "", "export cats, missions or targets as a table instead of the agency as JSON")
+	format := flags.String("format", export.FormatCSV, "csv, ndjson or xlsx, with -type")
+	status := flags.String("status", "", "only cats with this status, with -type cats")
+	state := flags.String("state", "", "only missions in this state, with -type missions")
+	missionID := flags.Uint("mission", 0, "only the targets of this mission, with -type targets")
+	flags.Parse(args)
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+
+	if *exportType != "" {
+		var write func(w io.Writer) error
+		switch *exportType {
+		case "cats":
+			write = func(w io.Writer) error {
+				return a.exportService().ExportCats(w, *format, models.CatFilter{Status: *status})
+			}
+		case "missions":
+			write = func(w io.Writer) error {
+				return a.exportService().ExportMissions(w, *format, models.MissionFilter{State: *state})
+			}
+		case "targets":
+			write = func(w io.Writer) error {
+				return a.exportService().ExportTargets(w, *format, models.TargetFilter{MissionID: *missionID})
+			}
+		default:
+			return fmt.Errorf("invalid type %q, expected cats, missions or targets", *exportType)
+		}
+		return writeExport(*output, write)
+	}
+
+	cats, err := a.repos.Cats.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to list cats: %w", err)
+	}
+	missions, err := a.repos.Missions.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to list missions: %w", err)
+	}
+	for i := range missions {
+		for j := range missions[i].Members {
+			missions[i].Members[j].Cat = nil
+		}
+	}
+
+	data := agencyExport{ExportedAt: time.Now().UTC(), Cats: cats, Missions: missions}
+	for _, cat := range cats {
+		history, err := a.repos.Payroll.GetSalaryHistory(cat.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load salary history: %w", err)
+		}
+		entries, err := a.repos.Availability.GetByCatID(cat.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load availability: %w", err)
+		}
+		data.SalaryHistory = append(data.SalaryHistory, history...)
+		data.Availability = append(data.Availability, entries...)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d cat(s) and %d mission(s) to %s\n", len(cats), len(missions), *output)
+	}
+	return nil
+}
+
+// writeExport streams a table export to the file, or to standard output without one
+func writeExport(output string, write func(w io.Writer) error) error {
+	if output == "" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		os.Remove(output)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported to %s\n", output)
+	return nil
+}
+
+// runImport recreates an export as it was, with new IDs: cats keep their status, availability and
+// salary history, missions their cats, schedule and progress, past deadlines included. Everything is
+// written in one transaction; a dry run writes it too and rolls back, so it fails wherever the import would.
+func runImport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "validate the file without writing anything")
+	importType := flags.String("type", "", "import a CSV or NDJSON file of cats or missions instead of an export")
+	format := flags.String("format", "", "csv or ndjson, by default taken from the file extension")
+	mode := flags.String("mode", models.ImportModeAllOrNothing, "all_or_nothing or best_effort")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: %s import [-dry-run] [-type cats|missions [-format csv|ndjson] [-mode all_or_nothing|best_effort]] FILE", os.Args[0])
+	}
+	if *importType != "" {
+		return runBulkImport(cfg, flags.Arg(0), models.ImportOptions{Type: *importType, Format: *format, Mode: *mode, DryRun: *dryRun})
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var data agencyExport
+	if err := json.NewDecoder(file).Decode(&data); err != nil {
+		return fmt.Errorf("failed to read %s: %w", flags.Arg(0), err)
+	}
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+	if err := validateAgency(a.catService(), &data); err != nil {
+		return err
+	}
+
+	err = a.repos.Transaction(func(tx *repository.Repositories) error {
+		if err := importAgency(tx, &data); err != nil {
+			return err
+		}
+		if *dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if *dryRun && errors.Is(err, errDryRun) {
+		fmt.Printf("%d cat(s) and %d mission(s) are valid\n", len(data.Cats), len(data.Missions))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	a.audit("import", fmt.Sprintf("%s: %d cat(s), %d mission(s)", flags.Arg(0), len(data.Cats), len(data.Missions)))
+	fmt.Printf("Imported %d cat(s) and %d mission(s)\n", len(data.Cats), len(data.Missions))
+	return nil
+}
+
+// validateAgency checks the records against the rules the API applies to their fields. Rules that
+// depend on the moment, such as deadlines in the future, do not apply to records that already exist.
+func validateAgency(catService services.CatService, data *agencyExport) error {
+	validate := validator.New()
+
+	for _, cat := range data.Cats {
+		req := models.CreateCatRequest{Name: cat.Name, YearsExperience: cat.YearsExperience, Breed: cat.Breed, Salary: cat.Salary}
+		if err := validate.Struct(req); err != nil {
+			return fmt.Errorf("cat %d (%s): %w", cat.ID, cat.Name, err)
+		}
+		if err := catService.ValidateBreed(cat.Breed); err != nil {
+			return fmt.Errorf("cat %d (%s): invalid breed: %w", cat.ID, cat.Name, err)
+		}
+		if _, err := catService.ValidateSalary(cat.Salary); err != nil {
+			return fmt.Errorf("cat %d (%s): %w", cat.ID, cat.Name, err)
+		}
+		switch cat.Status {
+		case models.CatStatusActive, models.CatStatusSuspended, models.CatStatusRetired, models.CatStatusKIA:
+		default:
+			return fmt.Errorf("cat %d (%s): invalid status %q", cat.ID, cat.Name, cat.Status)
+		}
+	}
+
+	for _, mission := range data.Missions {
+		if err := validate.Struct(missionRequest(&mission)); err != nil {
+			return fmt.Errorf("mission %d: %w", mission.ID, err)
+		}
+		for _, target := range mission.Targets {
+			if _, _, err := country.Normalize(target.Country); err != nil {
+				return fmt.Errorf("mission %d: target %d: %w", mission.ID, target.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// importAgency writes the records through the repositories, mapping the IDs in the file to the new ones.
+// Missions keep their cats when those cats are part of the file.
+func importAgency(tx *repository.Repositories, data *agencyExport) error {
+	catIDs := make(map[uint]uint, len(data.Cats))
+	for _, cat := range data.Cats {
+		created := models.SpyCat{
+			Name:            cat.Name,
+			YearsExperience: cat.YearsExperience,
+			Breed:           cat.Breed,
+			Salary:          cat.Salary,
+			Status:          cat.Status,
+			StatusReason:    cat.StatusReason,
+			StatusChangedAt: cat.StatusChangedAt,
+			CreatedAt:       cat.CreatedAt,
+			UpdatedAt:       cat.UpdatedAt,
+		}
+		if err := tx.Cats.Create(&created); err != nil {
+			return fmt.Errorf("cat %d (%s): %w", cat.ID, cat.Name, err)
+		}
+		// New cats take the column default, so a busy cat is marked afterwards
+		if !cat.IsAvailable {
+			if err := tx.Cats.SetAvailability(created.ID, false); err != nil {
+				return fmt.Errorf("cat %d (%s): %w", cat.ID, cat.Name, err)
+			}
+		}
+		catIDs[cat.ID] = created.ID
+	}
+	catID := func(id *uint) *uint {
+		if id == nil {
+			return nil
+		}
+		if mapped, ok := catIDs[*id]; ok {
+			return &mapped
+		}
+		return nil
+	}
+
+	for _, record := range data.SalaryHistory {
+		id := catID(&record.CatID)
+		if id == nil {
+			return fmt.Errorf("salary record %d: cat %d is not in the file", record.ID, record.CatID)
+		}
+		created := models.SalaryRecord{
+			CatID:         *id,
+			Salary:        record.Salary,
+			EffectiveFrom: record.EffectiveFrom,
+			Reason:        record.Reason,
+			CreatedAt:     record.CreatedAt,
+		}
+		if err := tx.Payroll.CreateSalaryRecord(&created); err != nil {
+			return fmt.Errorf("salary record %d: %w", record.ID, err)
+		}
+	}
+
+	missionIDs := make(map[uint]uint, len(data.Missions))
+	for _, mission := range data.Missions {
+		created := models.Mission{
+			CatID:          catID(mission.CatID),
+			IsCompleted:    mission.IsCompleted,
+			PlannedStartAt: mission.PlannedStartAt,
+			DeadlineAt:     mission.DeadlineAt,
+			StartedAt:      mission.StartedAt,
+			EndedAt:        mission.EndedAt,
+			IsOverdue:      mission.IsOverdue,
+			Budget:         mission.Budget,
+			CreatedAt:      mission.CreatedAt,
+			UpdatedAt:      mission.UpdatedAt,
+		}
+		for _, target := range mission.Targets {
+			code, name, err := country.Normalize(target.Country)
+			if err != nil {
+				return fmt.Errorf("mission %d: target %d: %w", mission.ID, target.ID, err)
+			}
+			created.Targets = append(created.Targets, models.Target{
+				Name:            target.Name,
+				Country:         code,
+				CountryName:     name,
+				Notes:           target.Notes,
+				Classification:  target.Classification,
+				NotesPurgedAt:   target.NotesPurgedAt,
+				PseudonymizedAt: target.PseudonymizedAt,
+				CatID:           catID(target.CatID),
+				Latitude:        target.Latitude,
+				Longitude:       target.Longitude,
+				LastSeenLat:     target.LastSeenLat,
+				LastSeenLng:     target.LastSeenLng,
+				LastSeenAt:      target.LastSeenAt,
+				DeadlineAt:      target.DeadlineAt,
+				IsCompleted:     target.IsCompleted,
+				CreatedAt:       target.CreatedAt,
+				UpdatedAt:       target.UpdatedAt,
+			})
+		}
+		if err := tx.Missions.Create(&created); err != nil {
+			return fmt.Errorf("mission %d: %w", mission.ID, err)
+		}
+		for _, member := range mission.Members {
+			if id := catID(&member.CatID); id != nil {
+				if err := tx.Missions.AddMember(created.ID, *id); err != nil {
+					return fmt.Errorf("mission %d: %w", mission.ID, err)
+				}
+			}
+		}
+		missionIDs[mission.ID] = created.ID
+	}
+
+	// Bookings of missions that are not in the file are left out
+	for _, entry := range data.Availability {
+		id := catID(&entry.CatID)
+		if id == nil {
+			return fmt.Errorf("availability entry %d: cat %d is not in the file", entry.ID, entry.CatID)
+		}
+		created := models.AvailabilityEntry{
+			CatID:     *id,
+			Kind:      entry.Kind,
+			StartsAt:  entry.StartsAt,
+			EndsAt:    entry.EndsAt,
+			Note:      entry.Note,
+			CreatedAt: entry.CreatedAt,
+			UpdatedAt: entry.UpdatedAt,
+		}
+		if entry.MissionID != nil {
+			missionID, ok := missionIDs[*entry.MissionID]
+			if !ok {
+				continue
+			}
+			created.MissionID = &missionID
+		}
+		if err := tx.Availability.Create(&created); err != nil {
+			return fmt.Errorf("availability entry %d: %w", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+// runBulkImport imports rows through the same service as POST /api/v1/import
+func runBulkImport(cfg *config.Config, path string, opts models.ImportOptions) error {
+	if opts.Format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			opts.Format = models.ImportFormatCSV
+		case ".ndjson", ".jsonl":
+			opts.Format = models.ImportFormatNDJSON
+		default:
+			return fmt.Errorf("cannot tell the format of %s; use -format csv or -format ndjson", path)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+
+	report, err := a.importService().Import(file, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range report.Errors {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", path, rowErr.Line, rowErr.Error)
+	}
+	switch {
+	case report.DryRun:
+		fmt.Printf("%d of %d %s are valid\n", report.Valid, report.Rows, opts.Type)
+	case report.Committed:
+		a.audit("import", fmt.Sprintf("%s: %d of %d %s, %s", path, report.Valid, report.Rows, opts.Type, report.Mode))
+		fmt.Printf("Imported %d of %d %s\n", report.Valid, report.Rows, opts.Type)
+	default:
+		return fmt.Errorf("%d of %d %s failed; nothing was imported (use -mode best_effort to import the valid ones)", report.Failed, report.Rows, opts.Type)
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d row(s) failed", report.Failed)
+	}
+	return nil
+}
+
+func missionRequest(mission *models.Mission) models.CreateMissionRequest {
+	req := models.CreateMissionRequest{
+		CatID:          mission.CatID,
+		PlannedStartAt: mission.PlannedStartAt,
+		DeadlineAt:     mission.DeadlineAt,
+	}
+	for _, target := range mission.Targets {
+		req.Targets = append(req.Targets, models.CreateTargetRequest{
+			Name:           target.Name,
+			Country:        target.Country,
+			Classification: target.Classification,
+			DeadlineAt:     target.DeadlineAt,
+			Latitude:       target.Latitude,
+			Longitude:      target.Longitude,
+		})
+	}
+	return req
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"spy-cat-agency/internal/config"
+)
+
+func runPurgeTrash(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("purge-trash", flag.ExitOnError)
+	olderThan := flags.Duration("older-than", time.Duration(cfg.TrashRetentionDays)*24*time.Hour, "purge records deleted longer ago than this (default: TRASH_RETENTION_DAYS)")
+	flags.Parse(args)
+
+	a, err := newApp(cfg, true)
+	if err != nil {
+		return err
+	}
+	if err := a.requireMigrated(); err != nil {
+		return err
+	}
+
+	result, err := a.trashService(*olderThan).Purge()
+	if err != nil {
+		return err
+	}
+	a.audit("purge-trash", fmt.Sprintf("older than %s: %d cat(s), %d mission(s), %d target(s)", *olderThan, result.Cats, result.Missions, result.Targets))
+
+	fmt.Printf("Purged %d cat(s), %d mission(s) and %d target(s)\n", result.Cats, result.Missions, result.Targets)
+	return nil
+}
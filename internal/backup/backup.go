@@ -0,0 +1,225 @@
+// Package backup writes the agency's data into a self-contained archive and restores it.
+//
+// An archive is a gzipped tar with one NDJSON file per table, each line a row as column/value pairs,
+// followed by manifest.json with the format and schema versions and the row count and SHA-256 of every file.
+// Rows keep their IDs, soft-deleted rows included, so a restore reproduces the database as it was.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FormatVersion changes when the layout of the archive changes; the schema version of the data is separate
+const FormatVersion = 3
+
+const manifestName = "manifest.json"
+
+type Manifest struct {
+	FormatVersion int         `json:"format_version"`
+	SchemaVersion int64       `json:"schema_version"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Files         []FileEntry `json:"files"`
+}
+
+type FileEntry struct {
+	Name   string `json:"name"`
+	Table  string `json:"table"`
+	Rows   int64  `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+type reference struct {
+	column string
+	table  string
+}
+
+type table struct {
+	name       string
+	references []reference
+	// since is the format version that added the table; older archives do not have it
+	since int
+}
+
+// Tables in restore order, each after the tables it refers to. Audit entries also carry admin IDs;
+// admins and their tokens are not part of the backup and those IDs are not checked.
+var tables = []table{
+	{name: "spy_cats"},
+	{name: "skills", since: 3},
+	{name: "dossiers", since: 3},
+	{name: "dossier_aliases", references: []reference{{"dossier_id", "dossiers"}}, since: 3},
+	{name: "dossier_countries", references: []reference{{"dossier_id", "dossiers"}}, since: 3},
+	{name: "dossier_photos", references: []reference{{"dossier_id", "dossiers"}}, since: 3},
+	{name: "missions", references: []reference{{"cat_id", "spy_cats"}}},
+	{name: "mission_cats", references: []reference{{"mission_id", "missions"}, {"cat_id", "spy_cats"}}, since: 2},
+	{name: "targets", references: []reference{{"mission_id", "missions"}, {"cat_id", "spy_cats"}, {"dossier_id", "dossiers"}}},
+	{name: "target_notes", references: []reference{{"target_id", "targets"}, {"mission_id", "missions"}, {"cat_id", "spy_cats"}}},
+	{name: "cat_skills", references: []reference{{"cat_id", "spy_cats"}, {"skill_id", "skills"}}, since: 3},
+	{name: "mission_skill_requirements", references: []reference{{"mission_id", "missions"}, {"skill_id", "skills"}}, since: 3},
+	{name: "salary_records", references: []reference{{"cat_id", "spy_cats"}}, since: 3},
+	{name: "availability_entries", references: []reference{{"cat_id", "spy_cats"}, {"mission_id", "missions"}}, since: 3},
+	{name: "hazard_pay_rates", since: 3},
+	{name: "payroll_runs", since: 3},
+	{name: "payroll_entries", references: []reference{{"run_id", "payroll_runs"}, {"cat_id", "spy_cats"}}, since: 3},
+	{name: "expenses", references: []reference{{"mission_id", "missions"}, {"cat_id", "spy_cats"}}, since: 3},
+	{name: "audit_entries"},
+}
+
+// tablesIn lists the tables in an archive of the given format version
+func tablesIn(formatVersion int) []table {
+	var in []table
+	for _, t := range tables {
+		if t.since <= formatVersion {
+			in = append(in, t)
+		}
+	}
+	return in
+}
+
+// missingReferences lists, per table, the columns that refer to a table an archive of the given format
+// version does not have, such as dossier links on targets before dossiers were backed up
+func missingReferences(formatVersion int) map[string][]string {
+	in := make(map[string]bool)
+	for _, t := range tablesIn(formatVersion) {
+		in[t.name] = true
+	}
+	missing := make(map[string][]string)
+	for _, t := range tables {
+		for _, ref := range t.references {
+			if in[t.name] && !in[ref.table] {
+				missing[t.name] = append(missing[t.name], ref.column)
+			}
+		}
+	}
+	return missing
+}
+
+func fileName(table string) string {
+	return table + ".ndjson"
+}
+
+// Create writes an archive of the database. All tables are read in one transaction, so the archive is a
+// consistent snapshot even while the API keeps writing.
+func Create(db *gorm.DB, w io.Writer, schemaVersion int64) (*Manifest, error) {
+	manifest := &Manifest{FormatVersion: FormatVersion, SchemaVersion: schemaVersion, CreatedAt: time.Now().UTC()}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	var options *sql.TxOptions
+	if db.Dialector.Name() == "postgres" {
+		options = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range tables {
+			entry, err := writeTable(tx, archive, t.name, manifest.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to back up %s: %w", t.name, err)
+			}
+			manifest.Files = append(manifest.Files, *entry)
+		}
+		return nil
+	}, options)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeEntry(archive, manifestName, manifest.CreatedAt, int64(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// A tar header needs the size up front, so each table is spooled to a temporary file first
+func writeTable(tx *gorm.DB, archive *tar.Writer, name string, modTime time.Time) (*FileEntry, error) {
+	spool, err := os.CreateTemp("", "spycat-backup-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	encoder := json.NewEncoder(io.MultiWriter(spool, hash))
+
+	rows, err := tx.Table(name).Order("id").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	entry := &FileEntry{Name: fileName(name), Table: name}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		if err := encoder.Encode(row); err != nil {
+			return nil, err
+		}
+		entry.Rows++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := writeEntry(archive, entry.Name, modTime, size, spool); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func writeEntry(archive *tar.Writer, name string, modTime time.Time, size int64, content io.Reader) error {
+	header := &tar.Header{Name: name, Mode: 0o600, Size: size, ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(archive, content)
+	return err
+}
@@ -0,0 +1,286 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const restoreBatchSize = 500
+
+// Verify reads the whole archive and checks it against its manifest: the format version, that every
+// table is present in restore order with the recorded row count and checksum, that IDs are unique and
+// that rows only refer to rows in the archive.
+func Verify(r io.Reader) (*Manifest, error) {
+	var manifest *Manifest
+	ids := make(map[string]map[uint64]bool)
+	var entries []FileEntry
+	// The first dangling reference to each table; references to tables the archive predates are
+	// only known to be allowed once the manifest is read
+	dangling := make(map[string]error)
+	var danglingOrder []string
+	// Files are checked against every table in order; which of them the archive has to hold
+	// depends on its format version, which is only known once the manifest at the end is read
+	next := 0
+
+	err := readArchive(r, func(name string, content io.Reader) error {
+		if name == manifestName {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(content).Decode(manifest); err != nil {
+				return fmt.Errorf("invalid manifest: %w", err)
+			}
+			return nil
+		}
+		if manifest != nil {
+			return fmt.Errorf("unexpected file %s after the manifest", name)
+		}
+		for next < len(tables) && fileName(tables[next].name) != name {
+			next++
+		}
+		if next == len(tables) {
+			return fmt.Errorf("unexpected file %s", name)
+		}
+		t := tables[next]
+		next++
+		seen := make(map[uint64]bool)
+		ids[t.name] = seen
+
+		hash := sha256.New()
+		entry := FileEntry{Name: name, Table: t.name}
+		err := decodeRows(io.TeeReader(content, hash), func(row map[string]interface{}) error {
+			entry.Rows++
+			id, ok := rowID(row["id"])
+			if !ok {
+				return fmt.Errorf("row %d has no valid id", entry.Rows)
+			}
+			if seen[id] {
+				return fmt.Errorf("duplicate id %d", id)
+			}
+			seen[id] = true
+
+			for _, ref := range t.references {
+				value, present := row[ref.column]
+				if !present || value == nil {
+					continue
+				}
+				refID, ok := rowID(value)
+				if !ok || !ids[ref.table][refID] {
+					if _, found := dangling[ref.table]; !found {
+						dangling[ref.table] = fmt.Errorf("%s: row %d refers to %s %v, which is not in the archive", name, id, ref.table, value)
+						danglingOrder = append(danglingOrder, ref.table)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		// Drain what the decoder did not read, so the checksum covers the whole file
+		if _, err := io.Copy(hash, content); err != nil {
+			return err
+		}
+		entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return nil, errors.New("archive has no manifest")
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d, expected 1 to %d", manifest.FormatVersion, FormatVersion)
+	}
+	expected := tablesIn(manifest.FormatVersion)
+	for i, t := range expected {
+		if i >= len(entries) || entries[i].Table != t.name {
+			return nil, fmt.Errorf("archive is missing %s", fileName(t.name))
+		}
+	}
+	if len(entries) != len(expected) {
+		return nil, fmt.Errorf("unexpected file %s in a format version %d archive", entries[len(expected)].Name, manifest.FormatVersion)
+	}
+	if len(manifest.Files) != len(entries) {
+		return nil, fmt.Errorf("manifest lists %d files, archive has %d", len(manifest.Files), len(entries))
+	}
+	for i, entry := range entries {
+		recorded := manifest.Files[i]
+		if recorded.Name != entry.Name || recorded.Table != entry.Table {
+			return nil, fmt.Errorf("manifest lists %s where the archive has %s", recorded.Name, entry.Name)
+		}
+		if recorded.SHA256 != entry.SHA256 {
+			return nil, fmt.Errorf("checksum mismatch for %s", entry.Name)
+		}
+		if recorded.Rows != entry.Rows {
+			return nil, fmt.Errorf("%s has %d rows, manifest lists %d", entry.Name, entry.Rows, recorded.Rows)
+		}
+	}
+	archived := make(map[string]bool)
+	for _, t := range expected {
+		archived[t.name] = true
+	}
+	for _, table := range danglingOrder {
+		if archived[table] {
+			return nil, dangling[table]
+		}
+	}
+	return manifest, nil
+}
+
+// Restore verifies the archive and loads it into db in one transaction. The database must be migrated
+// to at least the archive's schema version and must not hold any of the backed-up records yet; restoring
+// into a new tenant schema meets both. References to tables an older archive does not have are cleared,
+// so restored targets do not point at dossiers created later.
+func Restore(db *gorm.DB, r io.ReadSeeker, schemaVersion int64) (*Manifest, error) {
+	manifest, err := Verify(r)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.SchemaVersion > schemaVersion {
+		return nil, fmt.Errorf("archive has schema version %d but the database is at %d, migrate it first", manifest.SchemaVersion, schemaVersion)
+	}
+	for _, t := range tables {
+		var count int64
+		if err := db.Table(t.name).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", t.name, err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("database is not empty: %s has %d rows", t.name, count)
+		}
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	missing := missingReferences(manifest.FormatVersion)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return readArchive(r, func(name string, content io.Reader) error {
+			if name == manifestName {
+				return nil
+			}
+			table := strings.TrimSuffix(name, ".ndjson")
+			if err := restoreTable(tx, table, content, missing[table]); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table, err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func restoreTable(tx *gorm.DB, table string, content io.Reader, cleared []string) error {
+	batch := make([]map[string]interface{}, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := tx.Table(table).Create(&batch).Error
+		batch = batch[:0]
+		return err
+	}
+	err := decodeRows(content, func(row map[string]interface{}) error {
+		for column, value := range row {
+			if n, ok := value.(json.Number); ok {
+				row[column] = number(n)
+			}
+		}
+		for _, column := range cleared {
+			row[column] = nil
+		}
+		batch = append(batch, row)
+		if len(batch) == restoreBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// Rows keep their IDs, so Postgres sequences have to be moved past them
+	if tx.Dialector.Name() == "postgres" {
+		return tx.Exec("SELECT setval(pg_get_serial_sequence(?, 'id'), COALESCE(MAX(id), 0) + 1, false) FROM "+table, table).Error
+	}
+	return nil
+}
+
+func readArchive(r io.Reader, fn func(name string, content io.Reader) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("corrupt backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(header.Name, archive); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeRows(r io.Reader, fn func(row map[string]interface{}) error) error {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	decoder.UseNumber()
+	for {
+		var row map[string]interface{}
+		err := decoder.Decode(&row)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+func rowID(v interface{}) (uint64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	id, err := n.Int64()
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return uint64(id), true
+}
+
+func number(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
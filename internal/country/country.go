@@ -0,0 +1,117 @@
+package country
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//go:embed iso3166.csv
+var isoData string
+
+//go:embed aliases.csv
+var aliasData string
+
+// Country is an ISO 3166-1 entry; Name is the short display name
+type Country struct {
+	Code   string `json:"code"`
+	Alpha3 string `json:"alpha3"`
+	Name   string `json:"name"`
+}
+
+var (
+	countries []Country
+	byCode    = make(map[string]Country)
+	byKey     = make(map[string]Country)
+)
+
+func init() {
+	rows, err := csv.NewReader(strings.NewReader(isoData)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("country: invalid iso3166.csv: %v", err))
+	}
+	for _, row := range rows[1:] {
+		c := Country{Code: row[0], Alpha3: row[1], Name: row[2]}
+		countries = append(countries, c)
+		byCode[c.Code] = c
+		for _, name := range row {
+			if name != "" {
+				byKey[key(name)] = c
+			}
+		}
+	}
+
+	rows, err = csv.NewReader(strings.NewReader(aliasData)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("country: invalid aliases.csv: %v", err))
+	}
+	for _, row := range rows[1:] {
+		c, ok := byCode[row[1]]
+		if !ok {
+			panic(fmt.Sprintf("country: alias %q points to unknown code %q", row[0], row[1]))
+		}
+		byKey[key(row[0])] = c
+	}
+}
+
+// Lookup accepts alpha-2 and alpha-3 codes, ISO names and common aliases, ignoring case, accents and punctuation
+func Lookup(input string) (Country, bool) {
+	c, ok := byKey[key(input)]
+	return c, ok
+}
+
+// Normalize returns the alpha-2 code and display name for input
+func Normalize(input string) (string, string, error) {
+	c, ok := Lookup(input)
+	if !ok {
+		return "", "", fmt.Errorf("unknown country %q", strings.TrimSpace(input))
+	}
+	return c.Code, c.Name, nil
+}
+
+func Name(code string) string {
+	return byCode[strings.ToUpper(code)].Name
+}
+
+// Search lists countries whose code or name contains query; an empty query lists them all
+func Search(query string) []Country {
+	q := key(query)
+	result := make([]Country, 0)
+	for _, c := range countries {
+		if q == "" || strings.EqualFold(c.Code, q) || strings.EqualFold(c.Alpha3, q) || strings.Contains(key(c.Name), q) {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+var accents = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y",
+)
+
+// "U.S.", "us" and "The U.S." reduce to the same key
+func key(s string) string {
+	s = accents.Replace(strings.ToLower(s))
+	s = strings.ReplaceAll(s, ".", "")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	words := strings.Fields(s)
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
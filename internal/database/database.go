@@ -3,36 +3,187 @@ package database
 import (
 	"fmt"
 	"log"
+	"strings"
 
+	"spy-cat-agency/internal/country"
 	"spy-cat-agency/internal/models"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+const sqliteScheme = "sqlite://"
+
+// Initialize opens Postgres, or SQLite when the URL starts with sqlite://, e.g. sqlite://spycat.db,
+// sqlite:///var/lib/spycat.db or sqlite://:memory:
 func Initialize(databaseURL string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+	dialector := postgres.Open(databaseURL)
+	if strings.HasPrefix(databaseURL, sqliteScheme) {
+		dialector = sqlite.Open(sqliteDSN(strings.TrimPrefix(databaseURL, sqliteScheme)))
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// SQLite allows one writer at a time, and every connection to :memory: is a database of its own
+	if db.Dialector.Name() == "sqlite" {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
 	log.Println("Database connection established")
 	return db, nil
 }
 
+// Foreign keys are off in SQLite unless enabled on every connection
+func sqliteDSN(path string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+// Migrate applies pending versioned migrations, then maps legacy country values and creates the
+// spatial index, which depend on code and on the PostGIS extension respectively
 func Migrate(db *gorm.DB) error {
-	err := db.AutoMigrate(
-		&models.SpyCat{},
-		&models.Mission{},
-		&models.Target{},
-	)
+	applied, err := MigrateUp(db, 0)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
+	for _, migration := range applied {
+		log.Printf("Applied migration %s", migration)
+	}
+
+	if err := migrateCountries(db); err != nil {
+		return fmt.Errorf("failed to migrate countries: %w", err)
+	}
+
+	if HasPostGIS(db) {
+		if err := createSpatialIndex(db); err != nil {
+			return fmt.Errorf("failed to create spatial index: %w", err)
+		}
+	}
 
 	log.Println("Database migrations completed")
 	return nil
 }
+
+// HasPostGIS reports whether proximity queries can run in the database
+func HasPostGIS(db *gorm.DB) bool {
+	if db.Dialector.Name() != "postgres" {
+		return false
+	}
+
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM pg_extension WHERE extname = 'postgis'").Scan(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// Targets are indexed on their last known location, falling back to their recorded coordinates
+func createSpatialIndex(db *gorm.DB) error {
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_targets_location ON targets USING GIST (" + TargetLocationSQL + ")").Error
+}
+
+const TargetLocationSQL = "(ST_SetSRID(ST_MakePoint(COALESCE(last_seen_lng, longitude), COALESCE(last_seen_lat, latitude)), 4326)::geography)"
+
+// Countries used to be free-form; rows without a display name have not been mapped to an ISO code yet.
+// Values that cannot be mapped are left as they are and reported, see GET /api/v1/countries/unmapped.
+func migrateCountries(db *gorm.DB) error {
+	var unmapped []models.UnmappedCountry
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var values []models.UnmappedCountry
+		err := tx.Unscoped().Model(&models.Target{}).
+			Select("country AS value, COUNT(*) AS row_count").
+			Where("country_name = '' OR country_name IS NULL").
+			Group("country").Scan(&values).Error
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			c, ok := country.Lookup(value.Value)
+			if !ok {
+				value.Table = "targets"
+				unmapped = append(unmapped, value)
+				continue
+			}
+			err := tx.Unscoped().Model(&models.Target{}).
+				Where("country = ? AND (country_name = '' OR country_name IS NULL)", value.Value).
+				Updates(map[string]interface{}{"country": c.Code, "country_name": c.Name}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Two legacy spellings of the same country cannot share a hazard rate; the second one is reported
+		var rates []models.HazardPayRate
+		if err := tx.Where("country_name = '' OR country_name IS NULL").Order("id").Find(&rates).Error; err != nil {
+			return err
+		}
+		for _, rate := range rates {
+			c, ok := country.Lookup(rate.Country)
+			var taken int64
+			if ok {
+				if err := tx.Model(&models.HazardPayRate{}).Where("country = ? AND id <> ?", c.Code, rate.ID).Count(&taken).Error; err != nil {
+					return err
+				}
+			}
+			if !ok || taken > 0 {
+				unmapped = append(unmapped, models.UnmappedCountry{Table: "hazard_pay_rates", Value: rate.Country, Rows: 1})
+				continue
+			}
+			err := tx.Model(&models.HazardPayRate{}).Where("id = ?", rate.ID).
+				Updates(map[string]interface{}{"country": c.Code, "country_name": c.Name}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var dossierCountries []models.DossierCountry
+		if err := tx.Where("name = '' OR name IS NULL").Order("id").Find(&dossierCountries).Error; err != nil {
+			return err
+		}
+		for _, dc := range dossierCountries {
+			c, ok := country.Lookup(dc.Country)
+			if !ok {
+				unmapped = append(unmapped, models.UnmappedCountry{Table: "dossier_countries", Value: dc.Country, Rows: 1})
+				continue
+			}
+			var taken int64
+			if err := tx.Model(&models.DossierCountry{}).Where("dossier_id = ? AND country = ? AND id <> ?", dc.DossierID, c.Code, dc.ID).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken > 0 {
+				err = tx.Delete(&models.DossierCountry{}, dc.ID).Error
+			} else {
+				err = tx.Model(&models.DossierCountry{}).Where("id = ?", dc.ID).
+					Updates(map[string]interface{}{"country": c.Code, "name": c.Name}).Error
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, value := range unmapped {
+		log.Printf("Could not map country %q in %s (%d rows)", value.Value, value.Table, value.Rows)
+	}
+	return nil
+}
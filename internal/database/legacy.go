@@ -0,0 +1,68 @@
+package database
+
+import (
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+
+	"gorm.io/gorm"
+)
+
+// baselineModels are the models 0001_baseline was generated from. Their columns must stay as the
+// baseline has them: a later migration that changes one of these tables cannot rely on the
+// adoption below leaving it out.
+var baselineModels = []interface{}{
+	&models.SpyCat{},
+	&models.Mission{},
+	&models.Target{},
+	&models.AvailabilityEntry{},
+	&models.Skill{},
+	&models.CatSkill{},
+	&models.MissionSkillRequirement{},
+	&models.SalaryRecord{},
+	&models.HazardPayRate{},
+	&models.PayrollRun{},
+	&models.PayrollEntry{},
+	&models.Expense{},
+	&models.RetentionPolicy{},
+	&models.ErasureRequest{},
+	&models.ErasureRecord{},
+	&models.Dossier{},
+	&models.DossierAlias{},
+	&models.DossierCountry{},
+	&models.DossierPhoto{},
+	&models.TargetNote{},
+}
+
+// Databases created by AutoMigrate before versioned migrations may be from any earlier release and
+// lack columns the baseline indexes, such as spy_cats.status. Before the baseline is applied to one,
+// AutoMigrate brings its tables up to the baseline and salaries kept in the old float column are
+// converted to amount and currency.
+func adoptLegacySchema(db *gorm.DB) error {
+	// SQLite adds constraints and drops columns by copying tables, which fails while foreign keys are
+	// enforced; the pragma is per connection, and SQLite has only one
+	if db.Dialector.Name() == "sqlite" {
+		if err := db.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+			return err
+		}
+		defer db.Exec("PRAGMA foreign_keys = ON")
+	}
+
+	if err := db.AutoMigrate(baselineModels...); err != nil {
+		return err
+	}
+	return migrateLegacySalaries(db)
+}
+
+func migrateLegacySalaries(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.SpyCat{}, "salary") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("UPDATE spy_cats SET salary_amount = ROUND(salary * 100), salary_currency = ?", money.DefaultCurrency).Error; err != nil {
+			return err
+		}
+		// The SQLite migrator of GORM leaves columns in place that were declared without quotes
+		return tx.Exec("ALTER TABLE spy_cats DROP COLUMN salary").Error
+	})
+}
@@ -0,0 +1,316 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// baselineVersion is 0001_baseline, which databases created by AutoMigrate adopt
+const baselineVersion int64 = 1
+
+// Replicas starting at the same time wait on this advisory lock; the value is arbitrary but must not change
+const migrationLockID int64 = 7310451339
+
+const createSchemaMigrationsSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// A script may have a variant for one database, e.g. 0001_baseline.sqlite.up.sql, used there instead of the default
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)(?:\.(postgres|sqlite))?\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Missing is set for versions recorded in the database that this binary has no files for
+	Missing bool
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Migrations lists the embedded migrations in version order, with the scripts for the given database
+func Migrations(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] != "" && match[3] != dialect {
+			continue
+		}
+		if match[4] == "up" && (match[3] != "" || migration.Up == "") {
+			migration.Up = string(content)
+		} else if match[4] == "down" && (match[3] != "" || migration.Down == "") {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %s has no up script", migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// MigrateUp applies pending migrations in order, at most steps of them when steps is positive.
+// Each migration runs in its own transaction together with its schema_migrations row.
+func MigrateUp(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	err = withMigrationLock(db, func() error {
+		applied, err := appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := applied[baselineVersion]; !ok && db.Migrator().HasTable("spy_cats") {
+			if err := adoptLegacySchema(db); err != nil {
+				return fmt.Errorf("failed to adopt legacy database: %w", err)
+			}
+		}
+
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if steps > 0 && len(done) == steps {
+				break
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+					migration.Version, migration.Name, time.Now().UTC()).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s failed: %w", migration, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// MigrateDown rolls back the most recently applied migrations, newest first
+func MigrateDown(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	var done []Migration
+	err = withMigrationLock(db, func() error {
+		applied, err := appliedMigrations(db)
+		if err != nil {
+			return err
+		}
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for _, version := range versions {
+			if len(done) == steps {
+				break
+			}
+			migration, ok := known[version]
+			if !ok {
+				return fmt.Errorf("migration %04d_%s is applied but not known to this binary", version, applied[version].Name)
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("migration %s has no down script", migration)
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("rollback of %s failed: %w", migration, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+func GetMigrationStatus(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		entry := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			entry.AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+		status = append(status, entry)
+	}
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		status = append(status, MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Version < status[j].Version
+	})
+	return status, nil
+}
+
+// CreateMigration writes an empty up/down pair numbered after the newest migration in dir
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(name)), "_")
+	if !regexp.MustCompile(`^[a-z0-9_]+$`).MatchString(name) {
+		return nil, fmt.Errorf("migration name may only contain letters, digits, spaces, dashes and underscores")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var latest int64
+	for _, entry := range entries {
+		if match := migrationFileName.FindStringSubmatch(entry.Name()); match != nil {
+			if version, _ := strconv.ParseInt(match[1], 10, 64); version > latest {
+				latest = version
+			}
+		}
+	}
+
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", latest+1, name, direction))
+		content := fmt.Sprintf("-- %s: %s\n", strings.ReplaceAll(name, "_", " "), direction)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int64]appliedMigration, error) {
+	var records []appliedMigration
+	if !db.Migrator().HasTable("schema_migrations") {
+		return map[int64]appliedMigration{}, nil
+	}
+	if err := db.Raw("SELECT version, name, applied_at FROM schema_migrations").Scan(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// The lock is session-level, so it is taken on a dedicated connection that stays open until the work is done
+func withMigrationLock(db *gorm.DB, fn func() error) error {
+	if db.Dialector.Name() != "postgres" {
+		return createSchemaMigrations(db, fn)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	return createSchemaMigrations(db, fn)
+}
+
+func createSchemaMigrations(db *gorm.DB, fn func() error) error {
+	if err := db.Exec(createSchemaMigrationsSQL).Error; err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return fn()
+}
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS target_notes;
+DROP TABLE IF EXISTS dossier_photos;
+DROP TABLE IF EXISTS dossier_countries;
+DROP TABLE IF EXISTS dossier_aliases;
+DROP TABLE IF EXISTS dossiers;
+DROP TABLE IF EXISTS erasure_records;
+DROP TABLE IF EXISTS erasure_requests;
+DROP TABLE IF EXISTS retention_policies;
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS payroll_entries;
+DROP TABLE IF EXISTS payroll_runs;
+DROP TABLE IF EXISTS hazard_pay_rates;
+DROP TABLE IF EXISTS salary_records;
+DROP TABLE IF EXISTS mission_skill_requirements;
+DROP TABLE IF EXISTS cat_skills;
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS availability_entries;
+DROP TABLE IF EXISTS targets;
+DROP TABLE IF EXISTS missions;
+DROP TABLE IF EXISTS spy_cats;
@@ -0,0 +1,292 @@
+-- Baseline for SQLite: the schema of 0001_baseline.up.sql with SQLite column types. IDs use
+-- AUTOINCREMENT so that, as with Postgres sequences, IDs of deleted rows are never handed out again.
+
+CREATE TABLE IF NOT EXISTS spy_cats (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    years_experience integer NOT NULL,
+    breed text NOT NULL,
+    salary_amount integer NOT NULL DEFAULT 0,
+    salary_currency varchar(3) NOT NULL DEFAULT 'USD',
+    is_available boolean DEFAULT true,
+    status text NOT NULL DEFAULT 'active',
+    status_reason text,
+    status_changed_at datetime,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_spy_cats_deleted_at ON spy_cats (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_spy_cats_status ON spy_cats (status);
+
+CREATE TABLE IF NOT EXISTS missions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    cat_id integer,
+    is_completed boolean DEFAULT false,
+    planned_start_at datetime,
+    deadline_at datetime,
+    started_at datetime,
+    ended_at datetime,
+    is_overdue boolean DEFAULT false,
+    budget_amount integer NOT NULL DEFAULT 0,
+    budget_currency varchar(3) NOT NULL DEFAULT 'USD',
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    CONSTRAINT fk_missions_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE INDEX IF NOT EXISTS idx_missions_deleted_at ON missions (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_missions_is_overdue ON missions (is_overdue);
+CREATE INDEX IF NOT EXISTS idx_missions_deadline_at ON missions (deadline_at);
+CREATE INDEX IF NOT EXISTS idx_missions_cat_id ON missions (cat_id);
+
+CREATE TABLE IF NOT EXISTS targets (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    mission_id integer NOT NULL,
+    name text NOT NULL,
+    country text NOT NULL,
+    country_name text,
+    notes text,
+    classification text NOT NULL DEFAULT 'confidential',
+    notes_purged_at datetime,
+    pseudonymized_at datetime,
+    cat_id integer,
+    dossier_id integer,
+    latitude real,
+    longitude real,
+    last_seen_lat real,
+    last_seen_lng real,
+    last_seen_at datetime,
+    deadline_at datetime,
+    is_completed boolean DEFAULT false,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    CONSTRAINT fk_targets_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id),
+    CONSTRAINT fk_missions_targets FOREIGN KEY (mission_id) REFERENCES missions (id)
+);
+CREATE INDEX IF NOT EXISTS idx_targets_deleted_at ON targets (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_targets_dossier_id ON targets (dossier_id);
+CREATE INDEX IF NOT EXISTS idx_targets_cat_id ON targets (cat_id);
+CREATE INDEX IF NOT EXISTS idx_targets_classification ON targets (classification);
+CREATE INDEX IF NOT EXISTS idx_targets_country ON targets (country);
+CREATE INDEX IF NOT EXISTS idx_targets_mission_id ON targets (mission_id);
+
+CREATE TABLE IF NOT EXISTS availability_entries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    cat_id integer NOT NULL,
+    kind text NOT NULL,
+    starts_at datetime NOT NULL,
+    ends_at datetime,
+    mission_id integer,
+    note text,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_deleted_at ON availability_entries (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_mission_id ON availability_entries (mission_id);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_ends_at ON availability_entries (ends_at);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_starts_at ON availability_entries (starts_at);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_cat_id ON availability_entries (cat_id);
+
+CREATE TABLE IF NOT EXISTS skills (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    category text,
+    description text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_name ON skills (name);
+
+CREATE TABLE IF NOT EXISTS cat_skills (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    cat_id integer NOT NULL,
+    skill_id integer NOT NULL,
+    level integer NOT NULL,
+    certified_at datetime,
+    expires_at datetime,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_cat_skills_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id),
+    CONSTRAINT fk_cat_skills_skill FOREIGN KEY (skill_id) REFERENCES skills (id)
+);
+CREATE INDEX IF NOT EXISTS idx_cat_skills_expires_at ON cat_skills (expires_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cat_skill ON cat_skills (cat_id,skill_id);
+
+CREATE TABLE IF NOT EXISTS mission_skill_requirements (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    mission_id integer NOT NULL,
+    skill_id integer NOT NULL,
+    min_level integer NOT NULL,
+    CONSTRAINT fk_mission_skill_requirements_skill FOREIGN KEY (skill_id) REFERENCES skills (id),
+    CONSTRAINT fk_missions_required_skills FOREIGN KEY (mission_id) REFERENCES missions (id)
+);
+CREATE INDEX IF NOT EXISTS idx_mission_skill_requirements_mission_id ON mission_skill_requirements (mission_id);
+
+CREATE TABLE IF NOT EXISTS salary_records (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    cat_id integer NOT NULL,
+    salary_amount integer NOT NULL DEFAULT 0,
+    salary_currency varchar(3) NOT NULL DEFAULT 'USD',
+    effective_from datetime NOT NULL,
+    reason text,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_salary_records_effective_from ON salary_records (effective_from);
+CREATE INDEX IF NOT EXISTS idx_salary_records_cat_id ON salary_records (cat_id);
+
+CREATE TABLE IF NOT EXISTS hazard_pay_rates (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    country text NOT NULL,
+    country_name text,
+    rate_amount integer NOT NULL DEFAULT 0,
+    rate_currency varchar(3) NOT NULL DEFAULT 'USD',
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hazard_pay_rates_country ON hazard_pay_rates (country);
+
+CREATE TABLE IF NOT EXISTS payroll_runs (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    period text NOT NULL,
+    period_start datetime NOT NULL,
+    period_end datetime NOT NULL,
+    total_amount integer NOT NULL DEFAULT 0,
+    total_currency varchar(3) NOT NULL DEFAULT 'USD',
+    created_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payroll_runs_period ON payroll_runs (period);
+
+CREATE TABLE IF NOT EXISTS payroll_entries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    run_id integer NOT NULL,
+    cat_id integer NOT NULL,
+    base_amount integer NOT NULL DEFAULT 0,
+    base_currency varchar(3) NOT NULL DEFAULT 'USD',
+    bonus_amount integer NOT NULL DEFAULT 0,
+    bonus_currency varchar(3) NOT NULL DEFAULT 'USD',
+    hazard_amount integer NOT NULL DEFAULT 0,
+    hazard_currency varchar(3) NOT NULL DEFAULT 'USD',
+    total_amount integer NOT NULL DEFAULT 0,
+    total_currency varchar(3) NOT NULL DEFAULT 'USD',
+    missions_completed integer,
+    CONSTRAINT fk_payroll_runs_entries FOREIGN KEY (run_id) REFERENCES payroll_runs (id),
+    CONSTRAINT fk_payroll_entries_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE INDEX IF NOT EXISTS idx_payroll_entries_cat_id ON payroll_entries (cat_id);
+CREATE INDEX IF NOT EXISTS idx_payroll_entries_run_id ON payroll_entries (run_id);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    mission_id integer NOT NULL,
+    cat_id integer NOT NULL,
+    category text NOT NULL,
+    amount_amount integer NOT NULL DEFAULT 0,
+    amount_currency varchar(3) NOT NULL DEFAULT 'USD',
+    description text,
+    receipt_ref text,
+    status text NOT NULL DEFAULT 'pending',
+    review_note text,
+    reviewed_at datetime,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    CONSTRAINT fk_expenses_mission FOREIGN KEY (mission_id) REFERENCES missions (id),
+    CONSTRAINT fk_expenses_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE INDEX IF NOT EXISTS idx_expenses_deleted_at ON expenses (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_expenses_status ON expenses (status);
+CREATE INDEX IF NOT EXISTS idx_expenses_cat_id ON expenses (cat_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_mission_id ON expenses (mission_id);
+
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    classification text NOT NULL,
+    purge_notes_after_days integer,
+    pseudonymize_after_days integer,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_classification ON retention_policies (classification);
+
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    subject_name text NOT NULL,
+    subject_token text NOT NULL,
+    country text,
+    reason text,
+    status text NOT NULL DEFAULT 'pending',
+    completed_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_status ON erasure_requests (status);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_subject_token ON erasure_requests (subject_token);
+
+CREATE TABLE IF NOT EXISTS erasure_records (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    request_id integer NOT NULL,
+    target_id integer NOT NULL,
+    mission_id integer NOT NULL,
+    fields text,
+    erased_at datetime,
+    CONSTRAINT fk_erasure_requests_records FOREIGN KEY (request_id) REFERENCES erasure_requests (id)
+);
+CREATE INDEX IF NOT EXISTS idx_erasure_records_target_id ON erasure_records (target_id);
+CREATE INDEX IF NOT EXISTS idx_erasure_records_request_id ON erasure_records (request_id);
+
+CREATE TABLE IF NOT EXISTS dossiers (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    summary text,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_dossiers_deleted_at ON dossiers (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_dossiers_name ON dossiers (name);
+
+CREATE TABLE IF NOT EXISTS dossier_aliases (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    dossier_id integer NOT NULL,
+    alias text NOT NULL,
+    CONSTRAINT fk_dossiers_aliases FOREIGN KEY (dossier_id) REFERENCES dossiers (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dossier_alias ON dossier_aliases (dossier_id,alias);
+
+CREATE TABLE IF NOT EXISTS dossier_countries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    dossier_id integer NOT NULL,
+    country text NOT NULL,
+    name text,
+    CONSTRAINT fk_dossiers_countries FOREIGN KEY (dossier_id) REFERENCES dossiers (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dossier_country ON dossier_countries (dossier_id,country);
+
+CREATE TABLE IF NOT EXISTS dossier_photos (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    dossier_id integer NOT NULL,
+    url text NOT NULL,
+    caption text,
+    taken_at datetime,
+    created_at datetime,
+    CONSTRAINT fk_dossiers_photos FOREIGN KEY (dossier_id) REFERENCES dossiers (id)
+);
+CREATE INDEX IF NOT EXISTS idx_dossier_photos_dossier_id ON dossier_photos (dossier_id);
+
+CREATE TABLE IF NOT EXISTS target_notes (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    target_id integer NOT NULL,
+    mission_id integer NOT NULL,
+    cat_id integer,
+    notes text,
+    latitude real,
+    longitude real,
+    seen_at datetime NOT NULL,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_target_notes_seen_at ON target_notes (seen_at);
+CREATE INDEX IF NOT EXISTS idx_target_notes_mission_id ON target_notes (mission_id);
+CREATE INDEX IF NOT EXISTS idx_target_notes_target_id ON target_notes (target_id);
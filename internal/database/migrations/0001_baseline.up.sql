@@ -0,0 +1,313 @@
+-- Baseline: the schema as last created by GORM AutoMigrate. Statements are idempotent so a
+-- database created by AutoMigrate adopts versioned migrations as it is; one from an older release
+-- is first brought up to this schema by adoptLegacySchema in legacy.go, which runs before the baseline.
+
+CREATE TABLE IF NOT EXISTS spy_cats (
+    id bigserial,
+    name text NOT NULL,
+    years_experience bigint NOT NULL,
+    breed text NOT NULL,
+    salary_amount bigint NOT NULL DEFAULT 0,
+    salary_currency varchar(3) NOT NULL DEFAULT 'USD',
+    is_available boolean DEFAULT true,
+    status text NOT NULL DEFAULT 'active',
+    status_reason text,
+    status_changed_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_spy_cats_deleted_at ON spy_cats (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_spy_cats_status ON spy_cats (status);
+
+CREATE TABLE IF NOT EXISTS missions (
+    id bigserial,
+    cat_id bigint,
+    is_completed boolean DEFAULT false,
+    planned_start_at timestamptz,
+    deadline_at timestamptz,
+    started_at timestamptz,
+    ended_at timestamptz,
+    is_overdue boolean DEFAULT false,
+    budget_amount bigint NOT NULL DEFAULT 0,
+    budget_currency varchar(3) NOT NULL DEFAULT 'USD',
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_missions_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE INDEX IF NOT EXISTS idx_missions_deleted_at ON missions (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_missions_is_overdue ON missions (is_overdue);
+CREATE INDEX IF NOT EXISTS idx_missions_deadline_at ON missions (deadline_at);
+CREATE INDEX IF NOT EXISTS idx_missions_cat_id ON missions (cat_id);
+
+CREATE TABLE IF NOT EXISTS targets (
+    id bigserial,
+    mission_id bigint NOT NULL,
+    name text NOT NULL,
+    country text NOT NULL,
+    country_name text,
+    notes text,
+    classification text NOT NULL DEFAULT 'confidential',
+    notes_purged_at timestamptz,
+    pseudonymized_at timestamptz,
+    cat_id bigint,
+    dossier_id bigint,
+    latitude decimal,
+    longitude decimal,
+    last_seen_lat decimal,
+    last_seen_lng decimal,
+    last_seen_at timestamptz,
+    deadline_at timestamptz,
+    is_completed boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_targets_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id),
+    CONSTRAINT fk_missions_targets FOREIGN KEY (mission_id) REFERENCES missions (id)
+);
+CREATE INDEX IF NOT EXISTS idx_targets_deleted_at ON targets (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_targets_dossier_id ON targets (dossier_id);
+CREATE INDEX IF NOT EXISTS idx_targets_cat_id ON targets (cat_id);
+CREATE INDEX IF NOT EXISTS idx_targets_classification ON targets (classification);
+CREATE INDEX IF NOT EXISTS idx_targets_country ON targets (country);
+CREATE INDEX IF NOT EXISTS idx_targets_mission_id ON targets (mission_id);
+
+CREATE TABLE IF NOT EXISTS availability_entries (
+    id bigserial,
+    cat_id bigint NOT NULL,
+    kind text NOT NULL,
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz,
+    mission_id bigint,
+    note text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_deleted_at ON availability_entries (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_mission_id ON availability_entries (mission_id);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_ends_at ON availability_entries (ends_at);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_starts_at ON availability_entries (starts_at);
+CREATE INDEX IF NOT EXISTS idx_availability_entries_cat_id ON availability_entries (cat_id);
+
+CREATE TABLE IF NOT EXISTS skills (
+    id bigserial,
+    name text NOT NULL,
+    category text,
+    description text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_name ON skills (name);
+
+CREATE TABLE IF NOT EXISTS cat_skills (
+    id bigserial,
+    cat_id bigint NOT NULL,
+    skill_id bigint NOT NULL,
+    level bigint NOT NULL,
+    certified_at timestamptz,
+    expires_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_cat_skills_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id),
+    CONSTRAINT fk_cat_skills_skill FOREIGN KEY (skill_id) REFERENCES skills (id)
+);
+CREATE INDEX IF NOT EXISTS idx_cat_skills_expires_at ON cat_skills (expires_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cat_skill ON cat_skills (cat_id,skill_id);
+
+CREATE TABLE IF NOT EXISTS mission_skill_requirements (
+    id bigserial,
+    mission_id bigint NOT NULL,
+    skill_id bigint NOT NULL,
+    min_level bigint NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_mission_skill_requirements_skill FOREIGN KEY (skill_id) REFERENCES skills (id),
+    CONSTRAINT fk_missions_required_skills FOREIGN KEY (mission_id) REFERENCES missions (id)
+);
+CREATE INDEX IF NOT EXISTS idx_mission_skill_requirements_mission_id ON mission_skill_requirements (mission_id);
+
+CREATE TABLE IF NOT EXISTS salary_records (
+    id bigserial,
+    cat_id bigint NOT NULL,
+    salary_amount bigint NOT NULL DEFAULT 0,
+    salary_currency varchar(3) NOT NULL DEFAULT 'USD',
+    effective_from timestamptz NOT NULL,
+    reason text,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_salary_records_effective_from ON salary_records (effective_from);
+CREATE INDEX IF NOT EXISTS idx_salary_records_cat_id ON salary_records (cat_id);
+
+CREATE TABLE IF NOT EXISTS hazard_pay_rates (
+    id bigserial,
+    country text NOT NULL,
+    country_name text,
+    rate_amount bigint NOT NULL DEFAULT 0,
+    rate_currency varchar(3) NOT NULL DEFAULT 'USD',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hazard_pay_rates_country ON hazard_pay_rates (country);
+
+CREATE TABLE IF NOT EXISTS payroll_runs (
+    id bigserial,
+    period text NOT NULL,
+    period_start timestamptz NOT NULL,
+    period_end timestamptz NOT NULL,
+    total_amount bigint NOT NULL DEFAULT 0,
+    total_currency varchar(3) NOT NULL DEFAULT 'USD',
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payroll_runs_period ON payroll_runs (period);
+
+CREATE TABLE IF NOT EXISTS payroll_entries (
+    id bigserial,
+    run_id bigint NOT NULL,
+    cat_id bigint NOT NULL,
+    base_amount bigint NOT NULL DEFAULT 0,
+    base_currency varchar(3) NOT NULL DEFAULT 'USD',
+    bonus_amount bigint NOT NULL DEFAULT 0,
+    bonus_currency varchar(3) NOT NULL DEFAULT 'USD',
+    hazard_amount bigint NOT NULL DEFAULT 0,
+    hazard_currency varchar(3) NOT NULL DEFAULT 'USD',
+    total_amount bigint NOT NULL DEFAULT 0,
+    total_currency varchar(3) NOT NULL DEFAULT 'USD',
+    missions_completed bigint,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_payroll_runs_entries FOREIGN KEY (run_id) REFERENCES payroll_runs (id),
+    CONSTRAINT fk_payroll_entries_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE INDEX IF NOT EXISTS idx_payroll_entries_cat_id ON payroll_entries (cat_id);
+CREATE INDEX IF NOT EXISTS idx_payroll_entries_run_id ON payroll_entries (run_id);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id bigserial,
+    mission_id bigint NOT NULL,
+    cat_id bigint NOT NULL,
+    category text NOT NULL,
+    amount_amount bigint NOT NULL DEFAULT 0,
+    amount_currency varchar(3) NOT NULL DEFAULT 'USD',
+    description text,
+    receipt_ref text,
+    status text NOT NULL DEFAULT 'pending',
+    review_note text,
+    reviewed_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_expenses_mission FOREIGN KEY (mission_id) REFERENCES missions (id),
+    CONSTRAINT fk_expenses_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE INDEX IF NOT EXISTS idx_expenses_deleted_at ON expenses (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_expenses_status ON expenses (status);
+CREATE INDEX IF NOT EXISTS idx_expenses_cat_id ON expenses (cat_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_mission_id ON expenses (mission_id);
+
+CREATE TABLE IF NOT EXISTS retention_policies (
+    id bigserial,
+    classification text NOT NULL,
+    purge_notes_after_days bigint,
+    pseudonymize_after_days bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_retention_policies_classification ON retention_policies (classification);
+
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    id bigserial,
+    subject_name text NOT NULL,
+    subject_token text NOT NULL,
+    country text,
+    reason text,
+    status text NOT NULL DEFAULT 'pending',
+    completed_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_status ON erasure_requests (status);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_subject_token ON erasure_requests (subject_token);
+
+CREATE TABLE IF NOT EXISTS erasure_records (
+    id bigserial,
+    request_id bigint NOT NULL,
+    target_id bigint NOT NULL,
+    mission_id bigint NOT NULL,
+    fields text,
+    erased_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_erasure_requests_records FOREIGN KEY (request_id) REFERENCES erasure_requests (id)
+);
+CREATE INDEX IF NOT EXISTS idx_erasure_records_target_id ON erasure_records (target_id);
+CREATE INDEX IF NOT EXISTS idx_erasure_records_request_id ON erasure_records (request_id);
+
+CREATE TABLE IF NOT EXISTS dossiers (
+    id bigserial,
+    name text NOT NULL,
+    summary text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_dossiers_deleted_at ON dossiers (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_dossiers_name ON dossiers (name);
+
+CREATE TABLE IF NOT EXISTS dossier_aliases (
+    id bigserial,
+    dossier_id bigint NOT NULL,
+    alias text NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_dossiers_aliases FOREIGN KEY (dossier_id) REFERENCES dossiers (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dossier_alias ON dossier_aliases (dossier_id,alias);
+
+CREATE TABLE IF NOT EXISTS dossier_countries (
+    id bigserial,
+    dossier_id bigint NOT NULL,
+    country text NOT NULL,
+    name text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_dossiers_countries FOREIGN KEY (dossier_id) REFERENCES dossiers (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dossier_country ON dossier_countries (dossier_id,country);
+
+CREATE TABLE IF NOT EXISTS dossier_photos (
+    id bigserial,
+    dossier_id bigint NOT NULL,
+    url text NOT NULL,
+    caption text,
+    taken_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_dossiers_photos FOREIGN KEY (dossier_id) REFERENCES dossiers (id)
+);
+CREATE INDEX IF NOT EXISTS idx_dossier_photos_dossier_id ON dossier_photos (dossier_id);
+
+CREATE TABLE IF NOT EXISTS target_notes (
+    id bigserial,
+    target_id bigint NOT NULL,
+    mission_id bigint NOT NULL,
+    cat_id bigint,
+    notes text,
+    latitude decimal,
+    longitude decimal,
+    seen_at timestamptz NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_target_notes_seen_at ON target_notes (seen_at);
+CREATE INDEX IF NOT EXISTS idx_target_notes_mission_id ON target_notes (mission_id);
+CREATE INDEX IF NOT EXISTS idx_target_notes_target_id ON target_notes (target_id);
@@ -0,0 +1,8 @@
+ALTER TABLE target_notes DROP CONSTRAINT IF EXISTS chk_target_notes_coordinates;
+ALTER TABLE targets DROP CONSTRAINT IF EXISTS chk_targets_last_seen;
+ALTER TABLE targets DROP CONSTRAINT IF EXISTS chk_targets_coordinates;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS chk_expenses_status;
+
+DROP INDEX IF EXISTS idx_expenses_pending;
+DROP INDEX IF EXISTS idx_spy_cats_assignable;
+DROP INDEX IF EXISTS idx_missions_open_deadline;
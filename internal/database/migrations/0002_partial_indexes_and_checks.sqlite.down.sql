@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS chk_target_notes_coordinates_update;
+DROP TRIGGER IF EXISTS chk_target_notes_coordinates_insert;
+DROP TRIGGER IF EXISTS chk_targets_last_seen_update;
+DROP TRIGGER IF EXISTS chk_targets_last_seen_insert;
+DROP TRIGGER IF EXISTS chk_targets_coordinates_update;
+DROP TRIGGER IF EXISTS chk_targets_coordinates_insert;
+DROP TRIGGER IF EXISTS chk_expenses_status_update;
+DROP TRIGGER IF EXISTS chk_expenses_status_insert;
+
+DROP INDEX IF EXISTS idx_expenses_pending;
+DROP INDEX IF EXISTS idx_spy_cats_assignable;
+DROP INDEX IF EXISTS idx_missions_open_deadline;
@@ -0,0 +1,45 @@
+-- The overdue checker only ever looks at open missions
+CREATE INDEX idx_missions_open_deadline ON missions (deadline_at)
+    WHERE is_completed = false AND is_overdue = false AND deleted_at IS NULL;
+
+-- Cats that can be assigned or recommended
+CREATE INDEX idx_spy_cats_assignable ON spy_cats (id)
+    WHERE is_available = true AND status = 'active' AND deleted_at IS NULL;
+
+-- Expenses awaiting review, per mission
+CREATE INDEX idx_expenses_pending ON expenses (mission_id)
+    WHERE status = 'pending' AND deleted_at IS NULL;
+
+-- SQLite cannot add CHECK constraints to existing tables; triggers reject the same rows instead.
+-- As with CHECK, a condition that is NULL because a value is missing passes.
+CREATE TRIGGER chk_expenses_status_insert BEFORE INSERT ON expenses
+    WHEN NOT (NEW.status IN ('pending', 'approved', 'rejected'))
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_expenses_status'); END;
+
+CREATE TRIGGER chk_expenses_status_update BEFORE UPDATE ON expenses
+    WHEN NOT (NEW.status IN ('pending', 'approved', 'rejected'))
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_expenses_status'); END;
+
+CREATE TRIGGER chk_targets_coordinates_insert BEFORE INSERT ON targets
+    WHEN NOT (NEW.latitude BETWEEN -90 AND 90 AND NEW.longitude BETWEEN -180 AND 180)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_targets_coordinates'); END;
+
+CREATE TRIGGER chk_targets_coordinates_update BEFORE UPDATE ON targets
+    WHEN NOT (NEW.latitude BETWEEN -90 AND 90 AND NEW.longitude BETWEEN -180 AND 180)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_targets_coordinates'); END;
+
+CREATE TRIGGER chk_targets_last_seen_insert BEFORE INSERT ON targets
+    WHEN NOT (NEW.last_seen_lat BETWEEN -90 AND 90 AND NEW.last_seen_lng BETWEEN -180 AND 180)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_targets_last_seen'); END;
+
+CREATE TRIGGER chk_targets_last_seen_update BEFORE UPDATE ON targets
+    WHEN NOT (NEW.last_seen_lat BETWEEN -90 AND 90 AND NEW.last_seen_lng BETWEEN -180 AND 180)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_targets_last_seen'); END;
+
+CREATE TRIGGER chk_target_notes_coordinates_insert BEFORE INSERT ON target_notes
+    WHEN NOT (NEW.latitude BETWEEN -90 AND 90 AND NEW.longitude BETWEEN -180 AND 180)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_target_notes_coordinates'); END;
+
+CREATE TRIGGER chk_target_notes_coordinates_update BEFORE UPDATE ON target_notes
+    WHEN NOT (NEW.latitude BETWEEN -90 AND 90 AND NEW.longitude BETWEEN -180 AND 180)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_target_notes_coordinates'); END;
@@ -0,0 +1,23 @@
+-- The overdue checker only ever looks at open missions
+CREATE INDEX idx_missions_open_deadline ON missions (deadline_at)
+    WHERE is_completed = false AND is_overdue = false AND deleted_at IS NULL;
+
+-- Cats that can be assigned or recommended
+CREATE INDEX idx_spy_cats_assignable ON spy_cats (id)
+    WHERE is_available = true AND status = 'active' AND deleted_at IS NULL;
+
+-- Expenses awaiting review, per mission
+CREATE INDEX idx_expenses_pending ON expenses (mission_id)
+    WHERE status = 'pending' AND deleted_at IS NULL;
+
+ALTER TABLE expenses ADD CONSTRAINT chk_expenses_status
+    CHECK (status IN ('pending', 'approved', 'rejected'));
+
+ALTER TABLE targets ADD CONSTRAINT chk_targets_coordinates
+    CHECK (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180);
+
+ALTER TABLE targets ADD CONSTRAINT chk_targets_last_seen
+    CHECK (last_seen_lat BETWEEN -90 AND 90 AND last_seen_lng BETWEEN -180 AND 180);
+
+ALTER TABLE target_notes ADD CONSTRAINT chk_target_notes_coordinates
+    CHECK (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180);
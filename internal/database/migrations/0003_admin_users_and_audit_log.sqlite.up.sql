@@ -0,0 +1,26 @@
+-- Admins and audit log for SQLite, see 0003_admin_users_and_audit_log.up.sql
+
+CREATE TABLE admin_users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    key_rotated_at datetime NOT NULL,
+    last_used_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX idx_admin_users_name ON admin_users (name);
+CREATE UNIQUE INDEX idx_admin_users_token_hash ON admin_users (token_hash);
+
+CREATE TABLE audit_entries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    admin_id integer,
+    actor text NOT NULL,
+    action text NOT NULL,
+    detail text,
+    status integer,
+    created_at datetime
+);
+CREATE INDEX idx_audit_entries_admin_id ON audit_entries (admin_id);
+CREATE INDEX idx_audit_entries_action ON audit_entries (action);
+CREATE INDEX idx_audit_entries_created_at ON audit_entries (created_at);
@@ -0,0 +1,26 @@
+CREATE TABLE admin_users (
+    id bigserial,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    key_rotated_at timestamptz NOT NULL,
+    last_used_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX idx_admin_users_name ON admin_users (name);
+CREATE UNIQUE INDEX idx_admin_users_token_hash ON admin_users (token_hash);
+
+CREATE TABLE audit_entries (
+    id bigserial,
+    admin_id bigint,
+    actor text NOT NULL,
+    action text NOT NULL,
+    detail text,
+    status bigint,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_audit_entries_admin_id ON audit_entries (admin_id);
+CREATE INDEX idx_audit_entries_action ON audit_entries (action);
+CREATE INDEX idx_audit_entries_created_at ON audit_entries (created_at);
@@ -0,0 +1,12 @@
+-- Mission members for SQLite, see 0004_mission_cats.up.sql
+
+CREATE TABLE mission_cats (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    mission_id integer NOT NULL,
+    cat_id integer NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_missions_members FOREIGN KEY (mission_id) REFERENCES missions (id),
+    CONSTRAINT fk_mission_cats_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE UNIQUE INDEX idx_mission_cats_mission_cat ON mission_cats (mission_id, cat_id);
+CREATE INDEX idx_mission_cats_cat_id ON mission_cats (cat_id);
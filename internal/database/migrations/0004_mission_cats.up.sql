@@ -0,0 +1,13 @@
+-- Cats working a mission alongside its lead cat in missions.cat_id
+
+CREATE TABLE mission_cats (
+    id bigserial,
+    mission_id bigint NOT NULL,
+    cat_id bigint NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_missions_members FOREIGN KEY (mission_id) REFERENCES missions (id),
+    CONSTRAINT fk_mission_cats_cat FOREIGN KEY (cat_id) REFERENCES spy_cats (id)
+);
+CREATE UNIQUE INDEX idx_mission_cats_mission_cat ON mission_cats (mission_id, cat_id);
+CREATE INDEX idx_mission_cats_cat_id ON mission_cats (cat_id);
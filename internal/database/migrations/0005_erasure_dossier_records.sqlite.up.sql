@@ -0,0 +1,14 @@
+-- Erasure dossier records for SQLite, see 0005_erasure_dossier_records.up.sql
+
+CREATE TABLE erasure_dossier_records (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    request_id integer NOT NULL,
+    dossier_id integer NOT NULL,
+    aliases integer,
+    photos integer,
+    targets_unlinked integer,
+    erased_at datetime,
+    CONSTRAINT fk_erasure_requests_dossier_records FOREIGN KEY (request_id) REFERENCES erasure_requests (id)
+);
+CREATE INDEX idx_erasure_dossier_records_request_id ON erasure_dossier_records (request_id);
+CREATE INDEX idx_erasure_dossier_records_dossier_id ON erasure_dossier_records (dossier_id);
@@ -0,0 +1,15 @@
+-- Dossiers deleted by erasure requests, listed in the erasure report
+
+CREATE TABLE erasure_dossier_records (
+    id bigserial,
+    request_id bigint NOT NULL,
+    dossier_id bigint NOT NULL,
+    aliases bigint,
+    photos bigint,
+    targets_unlinked bigint,
+    erased_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_erasure_requests_dossier_records FOREIGN KEY (request_id) REFERENCES erasure_requests (id)
+);
+CREATE INDEX idx_erasure_dossier_records_request_id ON erasure_dossier_records (request_id);
+CREATE INDEX idx_erasure_dossier_records_dossier_id ON erasure_dossier_records (dossier_id);
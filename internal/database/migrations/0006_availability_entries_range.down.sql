@@ -0,0 +1 @@
+ALTER TABLE availability_entries DROP CONSTRAINT IF EXISTS chk_availability_entries_range;
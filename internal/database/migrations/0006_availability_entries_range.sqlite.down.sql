@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS chk_availability_entries_range_update;
+DROP TRIGGER IF EXISTS chk_availability_entries_range_insert;
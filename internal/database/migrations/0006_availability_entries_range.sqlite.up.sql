@@ -0,0 +1,11 @@
+-- Booking range check for SQLite, see 0006_availability_entries_range.up.sql
+
+UPDATE availability_entries SET ends_at = starts_at WHERE ends_at < starts_at;
+
+CREATE TRIGGER chk_availability_entries_range_insert BEFORE INSERT ON availability_entries
+    WHEN NOT (NEW.ends_at IS NULL OR NEW.ends_at >= NEW.starts_at)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_availability_entries_range'); END;
+
+CREATE TRIGGER chk_availability_entries_range_update BEFORE UPDATE ON availability_entries
+    WHEN NOT (NEW.ends_at IS NULL OR NEW.ends_at >= NEW.starts_at)
+    BEGIN SELECT RAISE(ABORT, 'CHECK constraint failed: chk_availability_entries_range'); END;
@@ -0,0 +1,6 @@
+-- A booking never ends before it starts. Bookings of missions completed before they began were
+-- closed at the completion time; they end when they start instead.
+UPDATE availability_entries SET ends_at = starts_at WHERE ends_at < starts_at;
+
+ALTER TABLE availability_entries ADD CONSTRAINT chk_availability_entries_range
+    CHECK (ends_at IS NULL OR ends_at >= starts_at);
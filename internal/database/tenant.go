@@ -0,0 +1,58 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var schemaName = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// TenantURL points a Postgres connection string at a schema of its own. Tables are created in and
+// looked up from the tenant schema first; public stays on the path for extensions such as PostGIS.
+func TenantURL(databaseURL, schema string) (string, error) {
+	if !schemaName.MatchString(schema) || schema == "public" {
+		return "", fmt.Errorf("invalid schema name %q: use lowercase letters, digits and underscores", schema)
+	}
+	if strings.HasPrefix(databaseURL, sqliteScheme) {
+		return "", fmt.Errorf("tenant schemas need Postgres")
+	}
+	searchPath := schema + ",public"
+
+	if strings.HasPrefix(databaseURL, "postgres://") || strings.HasPrefix(databaseURL, "postgresql://") {
+		u, err := url.Parse(databaseURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid database URL: %w", err)
+		}
+		query := u.Query()
+		query.Set("search_path", searchPath)
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+	return strings.TrimSpace(databaseURL) + " search_path=" + searchPath, nil
+}
+
+func CreateSchema(db *gorm.DB, schema string) error {
+	if !schemaName.MatchString(schema) {
+		return fmt.Errorf("invalid schema name %q", schema)
+	}
+	return db.Exec("CREATE SCHEMA IF NOT EXISTS " + schema).Error
+}
+
+// SchemaVersion is the newest applied migration, 0 when none has been applied
+func SchemaVersion(db *gorm.DB) (int64, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return 0, err
+	}
+	var version int64
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+	fields []string
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer, fields: make([]string, len(columns))}, nil
+}
+
+func (w *csvWriter) Write(_ interface{}, row []interface{}) error {
+	for i, v := range row {
+		w.fields[i] = text(v)
+	}
+	return w.writer.Write(w.fields[:len(row)])
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
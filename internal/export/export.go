@@ -0,0 +1,102 @@
+// Package export writes records one at a time as CSV, NDJSON or XLSX, so large tables can be streamed
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+var contentTypes = map[string]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Writer takes one record at a time. CSV and XLSX write row, the record's values in column order;
+// NDJSON writes record as JSON, so lines have the same shape as API responses.
+type Writer interface {
+	Write(record interface{}, row []interface{}) error
+	// Close finishes the file; nothing is complete before it returns
+	Close() error
+}
+
+// NewWriter starts a file with the given columns. The sheet name is only used by XLSX.
+func NewWriter(w io.Writer, format, sheet string, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet, columns)
+	default:
+		return nil, fmt.Errorf("invalid format %q, expected csv, ndjson or xlsx", format)
+	}
+}
+
+func ContentType(format string) (string, error) {
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return "", fmt.Errorf("invalid format %q, expected csv, ndjson or xlsx", format)
+	}
+	return contentType, nil
+}
+
+// Number is a decimal kept as text, such as a money amount: CSV writes it unchanged and XLSX as a number
+type Number string
+
+// value dereferences optional fields; nil pointers become nil
+func value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *uint:
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+	return v
+}
+
+// text formats a value for CSV and for the string cells of XLSX; times are RFC 3339 in UTC
+func text(v interface{}) string {
+	switch v := value(v).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case Number:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
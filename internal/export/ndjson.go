@@ -0,0 +1,23 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}
+}
+
+// Encode ends every record with a newline
+func (w *ndjsonWriter) Write(record interface{}, _ []interface{}) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
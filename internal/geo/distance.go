@@ -0,0 +1,44 @@
+package geo
+
+import "math"
+
+const earthRadiusKm = 6371.0088
+
+// Distance is the great-circle distance in kilometres between two points, using the Haversine formula
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Around returns a box that contains every point within radiusKm of the centre.
+// Longitudes may wrap, in which case minLng > maxLng.
+func Around(lat, lng, radiusKm float64) (minLat, minLng, maxLat, maxLng float64) {
+	dLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLat, maxLat = lat-dLat, lat+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		return math.Max(minLat, -90), -180, math.Min(maxLat, 90), 180
+	}
+
+	dLng := math.Asin(math.Min(1, math.Sin(radians(dLat))/math.Cos(radians(lat)))) * 180 / math.Pi
+	if dLng >= 180 {
+		return minLat, -180, maxLat, 180
+	}
+	return minLat, wrap(lng - dLng), maxLat, wrap(lng + dLng)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func wrap(lng float64) float64 {
+	for lng < -180 {
+		lng += 360
+	}
+	for lng > 180 {
+		lng -= 360
+	}
+	return lng
+}
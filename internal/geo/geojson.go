@@ -0,0 +1,44 @@
+package geo
+
+// FeatureCollection and friends follow RFC 7946; coordinates are [longitude, latitude]
+type FeatureCollection struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Features   []Feature              `json:"features"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+func NewFeature(id interface{}, geometry *Geometry, properties map[string]interface{}) Feature {
+	return Feature{Type: "Feature", ID: id, Geometry: geometry, Properties: properties}
+}
+
+func Point(lat, lng float64) *Geometry {
+	return &Geometry{Type: "Point", Coordinates: []float64{lng, lat}}
+}
+
+// LineString takes its points as [latitude, longitude] pairs
+func LineString(points [][2]float64) *Geometry {
+	coordinates := make([][]float64, len(points))
+	for i, point := range points {
+		coordinates[i] = []float64{point[1], point[0]}
+	}
+	return &Geometry{Type: "LineString", Coordinates: coordinates}
+}
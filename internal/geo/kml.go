@@ -0,0 +1,160 @@
+package geo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KMLFolder groups placemarks, e.g. one folder per mission
+type KMLFolder struct {
+	Name       string
+	Properties map[string]interface{}
+	Features   []Feature
+}
+
+type kmlDocument struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Document struct {
+		Name    string      `xml:"name"`
+		Folders []kmlFolder `xml:"Folder"`
+	} `xml:"Document"`
+}
+
+type kmlFolder struct {
+	Name         string         `xml:"name"`
+	ExtendedData *kmlData       `xml:"ExtendedData,omitempty"`
+	Placemarks   []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	ID           string         `xml:"id,attr,omitempty"`
+	Name         string         `xml:"name,omitempty"`
+	ExtendedData *kmlData       `xml:"ExtendedData,omitempty"`
+	Point        *kmlCoords     `xml:"Point,omitempty"`
+	LineString   *kmlLineString `xml:"LineString,omitempty"`
+}
+
+type kmlCoords struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlData struct {
+	Data []kmlValue `xml:"Data"`
+}
+
+type kmlValue struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// WriteKML writes the folders as a KML 2.2 document; features without a geometry are left out
+func WriteKML(w io.Writer, name string, folders []KMLFolder) error {
+	doc := kmlDocument{Xmlns: "http://www.opengis.net/kml/2.2"}
+	doc.Document.Name = name
+	for _, folder := range folders {
+		out := kmlFolder{Name: folder.Name, ExtendedData: extendedData(folder.Properties)}
+		for _, feature := range folder.Features {
+			if placemark, ok := newPlacemark(feature); ok {
+				out.Placemarks = append(out.Placemarks, placemark)
+			}
+		}
+		doc.Document.Folders = append(doc.Document.Folders, out)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newPlacemark(feature Feature) (kmlPlacemark, bool) {
+	if feature.Geometry == nil {
+		return kmlPlacemark{}, false
+	}
+
+	placemark := kmlPlacemark{ExtendedData: extendedData(feature.Properties)}
+	if feature.ID != nil {
+		placemark.ID = fmt.Sprint(feature.ID)
+	}
+	if name, ok := feature.Properties["name"].(string); ok {
+		placemark.Name = name
+	}
+
+	switch coordinates := feature.Geometry.Coordinates.(type) {
+	case []float64:
+		placemark.Point = &kmlCoords{Coordinates: kmlCoordinate(coordinates)}
+	case [][]float64:
+		points := make([]string, len(coordinates))
+		for i, point := range coordinates {
+			points[i] = kmlCoordinate(point)
+		}
+		placemark.LineString = &kmlLineString{Tessellate: 1, Coordinates: strings.Join(points, " ")}
+	default:
+		return kmlPlacemark{}, false
+	}
+	return placemark, true
+}
+
+func kmlCoordinate(point []float64) string {
+	return strconv.FormatFloat(point[0], 'f', -1, 64) + "," + strconv.FormatFloat(point[1], 'f', -1, 64)
+}
+
+// Properties are written in name order so the output is stable; empty values are skipped
+func extendedData(properties map[string]interface{}) *kmlData {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	data := &kmlData{}
+	for _, name := range names {
+		if value, ok := kmlString(properties[name]); ok {
+			data.Data = append(data.Data, kmlValue{Name: name, Value: value})
+		}
+	}
+	if len(data.Data) == 0 {
+		return nil
+	}
+	return data
+}
+
+func kmlString(value interface{}) (string, bool) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", false
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	default:
+		return fmt.Sprint(value), true
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AvailabilityHandler struct {
+	availabilityService services.AvailabilityService
+	validator           *validator.Validate
+}
+
+func NewAvailabilityHandler(availabilityService services.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		availabilityService: availabilityService,
+		validator:           validator.New(),
+	}
+}
+
+func (h *AvailabilityHandler) ListEntries(c *gin.Context) {
+	idStr := c.Param("id")
+	catID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	entries, err := h.availabilityService.ListEntries(uint(catID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (h *AvailabilityHandler) AddEntry(c *gin.Context) {
+	idStr := c.Param("id")
+	catID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	var req models.CreateAvailabilityEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.availabilityService.AddEntry(uint(catID), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *AvailabilityHandler) DeleteEntry(c *gin.Context) {
+	idStr := c.Param("id")
+	catID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	entryIDStr := c.Param("entryId")
+	entryID, err := strconv.ParseUint(entryIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	err = h.availabilityService.DeleteEntry(uint(catID), uint(entryID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AvailabilityHandler) ListAvailableCats(c *gin.Context) {
+	from := time.Now()
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := parseTime(fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date"})
+			return
+		}
+		from = parsed
+	}
+
+	var to *time.Time
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := parseTime(toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date"})
+			return
+		}
+		to = &parsed
+	}
+
+	cats, err := h.availabilityService.ListAvailableCats(from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cats)
+}
@@ -44,8 +44,23 @@ func (h *CatHandler) CreateCat(c *gin.Context) {
 	c.JSON(http.StatusCreated, cat)
 }
 
+func (h *CatHandler) GetSalaryTotals(c *gin.Context) {
+	totals, err := h.catService.TotalSalaries(c.Query("currency"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
 func (h *CatHandler) ListCats(c *gin.Context) {
-	cats, err := h.catService.ListCats()
+	filter, ok := catFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	cats, err := h.catService.ListCats(filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -54,6 +69,22 @@ func (h *CatHandler) ListCats(c *gin.Context) {
 	c.JSON(http.StatusOK, cats)
 }
 
+// catFilterQuery reads the filters of the cat list, which the cat export accepts too
+func catFilterQuery(c *gin.Context) (models.CatFilter, bool) {
+	filter := models.CatFilter{Skill: c.Query("skill"), Status: c.Query("status")}
+	var ok bool
+	if minLevelStr := c.Query("min_level"); minLevelStr != "" {
+		minLevel, err := strconv.Atoi(minLevelStr)
+		if err != nil || minLevel < 1 || minLevel > 5 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_level"})
+			return filter, false
+		}
+		filter.MinLevel = minLevel
+	}
+	filter.Limit, filter.Offset, ok = pageQuery(c)
+	return filter, ok
+}
+
 func (h *CatHandler) GetCat(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
@@ -107,7 +138,18 @@ func (h *CatHandler) DeleteCat(c *gin.Context) {
 		return
 	}
 
-	err = h.catService.DeleteCat(uint(id))
+	var reassignTo *uint
+	if reassignStr := c.Query("reassign_to"); reassignStr != "" {
+		reassignID, err := strconv.ParseUint(reassignStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reassign_to"})
+			return
+		}
+		catID := uint(reassignID)
+		reassignTo = &catID
+	}
+
+	err = h.catService.DeleteCat(uint(id), reassignTo)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -115,3 +157,31 @@ func (h *CatHandler) DeleteCat(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+func (h *CatHandler) ChangeStatus(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	var req models.ChangeCatStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cat, err := h.catService.ChangeStatus(uint(id), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cat)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CountryHandler struct {
+	countryService services.CountryService
+}
+
+func NewCountryHandler(countryService services.CountryService) *CountryHandler {
+	return &CountryHandler{
+		countryService: countryService,
+	}
+}
+
+func (h *CountryHandler) ListCountries(c *gin.Context) {
+	c.JSON(http.StatusOK, h.countryService.ListCountries(c.Query("q")))
+}
+
+func (h *CountryHandler) ListUnmapped(c *gin.Context) {
+	unmapped, err := h.countryService.ListUnmapped()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, unmapped)
+}
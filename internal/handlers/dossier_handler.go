@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type DossierHandler struct {
+	dossierService services.DossierService
+	validator      *validator.Validate
+}
+
+func NewDossierHandler(dossierService services.DossierService) *DossierHandler {
+	return &DossierHandler{
+		dossierService: dossierService,
+		validator:      validator.New(),
+	}
+}
+
+func (h *DossierHandler) CreateDossier(c *gin.Context) {
+	var req models.CreateDossierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dossier, err := h.dossierService.CreateDossier(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dossier)
+}
+
+func (h *DossierHandler) ListDossiers(c *gin.Context) {
+	dossiers, err := h.dossierService.ListDossiers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dossiers)
+}
+
+func (h *DossierHandler) GetDossier(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dossier ID"})
+		return
+	}
+
+	dossier, err := h.dossierService.GetDossier(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dossier not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dossier)
+}
+
+func (h *DossierHandler) UpdateDossier(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dossier ID"})
+		return
+	}
+
+	var req models.UpdateDossierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dossier, err := h.dossierService.UpdateDossier(uint(id), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dossier)
+}
+
+func (h *DossierHandler) DeleteDossier(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dossier ID"})
+		return
+	}
+
+	err = h.dossierService.DeleteDossier(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *DossierHandler) GetDossierView(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dossier ID"})
+		return
+	}
+
+	view, err := h.dossierService.GetDossierView(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+func (h *DossierHandler) AddPhoto(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dossier ID"})
+		return
+	}
+
+	var req models.AddDossierPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	photo, err := h.dossierService.AddPhoto(uint(id), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, photo)
+}
+
+func (h *DossierHandler) DeletePhoto(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dossier ID"})
+		return
+	}
+
+	photoIDStr := c.Param("photoId")
+	photoID, err := strconv.ParseUint(photoIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid photo ID"})
+		return
+	}
+
+	err = h.dossierService.DeletePhoto(uint(id), uint(photoID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *DossierHandler) SuggestDossiers(c *gin.Context) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	matches, err := h.dossierService.SuggestDossiers(c.Query("name"), c.Query("country"), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+func (h *DossierHandler) SuggestForTarget(c *gin.Context) {
+	missionIDStr := c.Param("id")
+	missionID, err := strconv.ParseUint(missionIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	targetIDStr := c.Param("targetId")
+	targetID, err := strconv.ParseUint(targetIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		return
+	}
+
+	matches, err := h.dossierService.SuggestForTarget(uint(missionID), uint(targetID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+func (h *DossierHandler) LinkTarget(c *gin.Context) {
+	missionIDStr := c.Param("id")
+	missionID, err := strconv.ParseUint(missionIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	targetIDStr := c.Param("targetId")
+	targetID, err := strconv.ParseUint(targetIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		return
+	}
+
+	var req models.LinkTargetDossierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	target, err := h.dossierService.LinkTarget(uint(missionID), uint(targetID), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ExpenseHandler struct {
+	expenseService services.ExpenseService
+	validator      *validator.Validate
+}
+
+func NewExpenseHandler(expenseService services.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{
+		expenseService: expenseService,
+		validator:      validator.New(),
+	}
+}
+
+func (h *ExpenseHandler) SetBudget(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	var req models.SetMissionBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	mission, err := h.expenseService.SetBudget(uint(missionID), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
+
+func (h *ExpenseHandler) ListExpenses(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	expenses, err := h.expenseService.ListExpenses(uint(missionID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, expenses)
+}
+
+func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	var req models.CreateExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expense, err := h.expenseService.CreateExpense(uint(missionID), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, expense)
+}
+
+func (h *ExpenseHandler) ApproveExpense(c *gin.Context) {
+	h.reviewExpense(c, h.expenseService.ApproveExpense)
+}
+
+func (h *ExpenseHandler) RejectExpense(c *gin.Context) {
+	h.reviewExpense(c, h.expenseService.RejectExpense)
+}
+
+func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
+	missionID, expenseID, ok := parseExpenseIDs(c)
+	if !ok {
+		return
+	}
+
+	err := h.expenseService.DeleteExpense(missionID, expenseID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ExpenseHandler) GetSummary(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	summary, err := h.expenseService.GetSummary(uint(missionID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *ExpenseHandler) reviewExpense(c *gin.Context, review func(missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error)) {
+	missionID, expenseID, ok := parseExpenseIDs(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReviewExpenseRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expense, err := review(missionID, expenseID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, expense)
+}
+
+func parseExpenseIDs(c *gin.Context) (uint, uint, bool) {
+	missionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return 0, 0, false
+	}
+
+	expenseID, err := strconv.ParseUint(c.Param("expenseId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expense ID"})
+		return 0, 0, false
+	}
+
+	return uint(missionID), uint(expenseID), true
+}
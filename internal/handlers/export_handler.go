@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"spy-cat-agency/internal/export"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportService services.ExportService
+}
+
+func NewExportHandler(exportService services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+func (h *ExportHandler) ExportCats(c *gin.Context) {
+	filter, ok := catFilterQuery(c)
+	if !ok {
+		return
+	}
+	h.stream(c, "cats", http.StatusInternalServerError, func(w *exportResponse) error {
+		return h.exportService.ExportCats(w, w.format, filter)
+	})
+}
+
+func (h *ExportHandler) ExportMissions(c *gin.Context) {
+	filter, ok := missionFilterQuery(c)
+	if !ok {
+		return
+	}
+	h.stream(c, "missions", http.StatusInternalServerError, func(w *exportResponse) error {
+		return h.exportService.ExportMissions(w, w.format, filter)
+	})
+}
+
+func (h *ExportHandler) ExportTargets(c *gin.Context) {
+	var filter models.TargetFilter
+	if missionIDStr := c.Query("mission_id"); missionIDStr != "" {
+		missionID, err := strconv.ParseUint(missionIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+			return
+		}
+		filter.MissionID = uint(missionID)
+	}
+	if catIDStr := c.Query("cat_id"); catIDStr != "" {
+		catID, err := strconv.ParseUint(catIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+			return
+		}
+		filter.CatID = uint(catID)
+	}
+	h.stream(c, "targets", http.StatusNotFound, func(w *exportResponse) error {
+		return h.exportService.ExportTargets(w, w.format, filter)
+	})
+}
+
+// stream answers errors that happen before the first byte as JSON with errStatus, like the list endpoints;
+// after that the response is already under way and a failed export can only be cut short
+func (h *ExportHandler) stream(c *gin.Context, name string, errStatus int, write func(w *exportResponse) error) {
+	format := c.DefaultQuery("format", export.FormatCSV)
+	contentType, err := export.ContentType(format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	w := &exportResponse{
+		c:           c,
+		format:      format,
+		contentType: contentType,
+		filename:    fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format),
+	}
+	if err := write(w); err != nil {
+		if !w.started {
+			c.JSON(errStatus, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Export of %s failed: %v", name, err)
+		c.Abort()
+		return
+	}
+	// An empty NDJSON export writes nothing but is still a download
+	if !w.started {
+		w.Write(nil)
+	}
+}
+
+// exportResponse sets the download headers with the first write
+type exportResponse struct {
+	c           *gin.Context
+	format      string
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportResponse) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GeoHandler struct {
+	geoService services.GeoService
+}
+
+func NewGeoHandler(geoService services.GeoService) *GeoHandler {
+	return &GeoHandler{
+		geoService: geoService,
+	}
+}
+
+func (h *GeoHandler) TargetsNearby(c *gin.Context) {
+	lat, ok := floatQuery(c, "lat")
+	if !ok {
+		return
+	}
+	lng, ok := floatQuery(c, "lng")
+	if !ok {
+		return
+	}
+	radius, ok := floatQuery(c, "radius_km")
+	if !ok {
+		return
+	}
+
+	targets, err := h.geoService.TargetsNear(lat, lng, radius)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if wantsGeoJSON(c) {
+		c.Header("Content-Type", "application/geo+json")
+		c.JSON(http.StatusOK, services.NearbyTargetFeatures(targets))
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+func (h *GeoHandler) TargetsInBox(c *gin.Context) {
+	var box models.BoundingBox
+	for _, param := range []struct {
+		name  string
+		value *float64
+	}{
+		{"min_lat", &box.MinLat},
+		{"min_lng", &box.MinLng},
+		{"max_lat", &box.MaxLat},
+		{"max_lng", &box.MaxLng},
+	} {
+		value, ok := floatQuery(c, param.name)
+		if !ok {
+			return
+		}
+		*param.value = value
+	}
+
+	targets, err := h.geoService.TargetsInBox(box)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if wantsGeoJSON(c) {
+		c.Header("Content-Type", "application/geo+json")
+		c.JSON(http.StatusOK, services.TargetFeatures(targets))
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+func floatQuery(c *gin.Context, name string) (float64, bool) {
+	value, err := strconv.ParseFloat(c.Query(name), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return 0, false
+	}
+	return value, true
+}
+
+func wantsGeoJSON(c *gin.Context) bool {
+	return c.Query("format") == "geojson" || strings.Contains(c.GetHeader("Accept"), "application/geo+json")
+}
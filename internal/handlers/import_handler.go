@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Largest import file accepted by the API; bigger files can be imported with the import command
+const maxImportSize = 32 << 20
+
+type ImportHandler struct {
+	importService services.ImportService
+}
+
+func NewImportHandler(importService services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// Import reads the request body as a CSV or NDJSON file. The format comes from the format parameter
+// or else the Content-Type. An all-or-nothing import with failed rows answers 422 with the report.
+func (h *ImportHandler) Import(c *gin.Context) {
+	opts := models.ImportOptions{
+		Type:   c.Query("type"),
+		Format: c.Query("format"),
+		Mode:   c.Query("mode"),
+	}
+	if opts.Format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			opts.Format = models.ImportFormatCSV
+		case "application/x-ndjson", "application/jsonl", "application/json":
+			opts.Format = models.ImportFormatNDJSON
+		}
+	}
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		dryRun, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dry_run"})
+			return
+		}
+		opts.DryRun = dryRun
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	report, err := h.importService.Import(body, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusOK
+	if !report.Committed && !report.DryRun {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/geo"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const kmlContentType = "application/vnd.google-earth.kml+xml"
+
+type MapHandler struct {
+	mapService services.MapService
+}
+
+func NewMapHandler(mapService services.MapService) *MapHandler {
+	return &MapHandler{
+		mapService: mapService,
+	}
+}
+
+func (h *MapHandler) MissionGeoJSON(c *gin.Context) {
+	missionMap, ok := h.missionMap(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "application/geo+json")
+	c.JSON(http.StatusOK, services.MapFeatures([]services.MissionMap{*missionMap}, missionMap.Metadata))
+}
+
+func (h *MapHandler) MissionKML(c *gin.Context) {
+	missionMap, ok := h.missionMap(c)
+	if !ok {
+		return
+	}
+
+	name := fmt.Sprintf("Mission %d", missionMap.Mission.ID)
+	h.writeKML(c, name, services.MapFolders([]services.MissionMap{*missionMap}))
+}
+
+func (h *MapHandler) AgencyGeoJSON(c *gin.Context) {
+	state, ok := missionStateQuery(c)
+	if !ok {
+		return
+	}
+
+	maps, err := h.mapService.AgencyMap(state)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	properties := map[string]interface{}{"missions": len(maps)}
+	if state != "" {
+		properties["mission_state"] = state
+	}
+	c.Header("Content-Type", "application/geo+json")
+	c.JSON(http.StatusOK, services.MapFeatures(maps, properties))
+}
+
+func (h *MapHandler) AgencyKML(c *gin.Context) {
+	state, ok := missionStateQuery(c)
+	if !ok {
+		return
+	}
+
+	maps, err := h.mapService.AgencyMap(state)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := "Spy Cat Agency"
+	if state != "" {
+		name += " (" + state + " missions)"
+	}
+	h.writeKML(c, name, services.MapFolders(maps))
+}
+
+func (h *MapHandler) missionMap(c *gin.Context) (*services.MissionMap, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return nil, false
+	}
+
+	missionMap, err := h.mapService.MissionMap(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Mission not found"})
+		return nil, false
+	}
+	return missionMap, true
+}
+
+func (h *MapHandler) writeKML(c *gin.Context, name string, folders []geo.KMLFolder) {
+	c.Header("Content-Type", kmlContentType)
+	c.Status(http.StatusOK)
+	if err := geo.WriteKML(c.Writer, name, folders); err != nil {
+		c.Error(err)
+	}
+}
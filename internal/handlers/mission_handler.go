@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/internal/services"
@@ -45,7 +46,12 @@ func (h *MissionHandler) CreateMission(c *gin.Context) {
 }
 
 func (h *MissionHandler) ListMissions(c *gin.Context) {
-	missions, err := h.missionService.ListMissions()
+	filter, ok := missionFilterQuery(c)
+	if !ok {
+		return
+	}
+
+	missions, err := h.missionService.ListMissions(filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -54,6 +60,48 @@ func (h *MissionHandler) ListMissions(c *gin.Context) {
 	c.JSON(http.StatusOK, missions)
 }
 
+// missionFilterQuery reads the filters of the mission list, which the mission export accepts too
+func missionFilterQuery(c *gin.Context) (models.MissionFilter, bool) {
+	var filter models.MissionFilter
+
+	if overdueStr := c.Query("overdue"); overdueStr != "" {
+		overdue, err := strconv.ParseBool(overdueStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid overdue filter"})
+			return filter, false
+		}
+		filter.Overdue = &overdue
+	}
+
+	if dueBeforeStr := c.Query("due_before"); dueBeforeStr != "" {
+		dueBefore, err := parseTime(dueBeforeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_before filter"})
+			return filter, false
+		}
+		filter.DueBefore = &dueBefore
+	}
+
+	state, ok := missionStateQuery(c)
+	if !ok {
+		return filter, false
+	}
+	filter.State = state
+	filter.Limit, filter.Offset, ok = pageQuery(c)
+	return filter, ok
+}
+
+func missionStateQuery(c *gin.Context) (string, bool) {
+	state := c.Query("state")
+	switch state {
+	case "", models.MissionStateUnassigned, models.MissionStateActive, models.MissionStateOverdue, models.MissionStateCompleted:
+		return state, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid state filter"})
+		return "", false
+	}
+}
+
 func (h *MissionHandler) GetMission(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
@@ -141,6 +189,55 @@ func (h *MissionHandler) AssignCat(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Cat assigned successfully"})
 }
 
+func (h *MissionHandler) AddMissionCat(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	var req struct {
+		CatID uint `json:"cat_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	mission, err := h.missionService.AddMissionCat(uint(missionID), req.CatID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mission)
+}
+
+func (h *MissionHandler) RemoveMissionCat(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	catIDStr := c.Param("catId")
+	catID, err := strconv.ParseUint(catIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	err = h.missionService.RemoveMissionCat(uint(missionID), uint(catID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 func (h *MissionHandler) CompleteMission(c *gin.Context) {
 	idStr := c.Param("id")
 	missionID, err := strconv.ParseUint(idStr, 10, 32)
@@ -260,7 +357,15 @@ func (h *MissionHandler) CompleteTarget(c *gin.Context) {
 		return
 	}
 
-	err = h.missionService.CompleteTarget(uint(missionID), uint(targetID))
+	var req models.CompleteTargetRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	err = h.missionService.CompleteTarget(uint(missionID), uint(targetID), &req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -303,3 +408,161 @@ func (h *MissionHandler) UpdateTargetNotes(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Notes updated successfully"})
 }
+
+func (h *MissionHandler) ListTargets(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	targets, err := h.missionService.ListTargets(uint(missionID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if wantsGeoJSON(c) {
+		c.Header("Content-Type", "application/geo+json")
+		c.JSON(http.StatusOK, services.TargetFeatures(targets))
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+func (h *MissionHandler) AddTargetNote(c *gin.Context) {
+	missionIDStr := c.Param("id")
+	missionID, err := strconv.ParseUint(missionIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	targetIDStr := c.Param("targetId")
+	targetID, err := strconv.ParseUint(targetIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		return
+	}
+
+	var req models.AddTargetNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.missionService.AddTargetNote(uint(missionID), uint(targetID), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+func (h *MissionHandler) ListTargetNotes(c *gin.Context) {
+	missionIDStr := c.Param("id")
+	missionID, err := strconv.ParseUint(missionIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	targetIDStr := c.Param("targetId")
+	targetID, err := strconv.ParseUint(targetIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		return
+	}
+
+	notes, err := h.missionService.ListTargetNotes(uint(missionID), uint(targetID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+func (h *MissionHandler) AssignTargetCat(c *gin.Context) {
+	missionIDStr := c.Param("id")
+	missionID, err := strconv.ParseUint(missionIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	targetIDStr := c.Param("targetId")
+	targetID, err := strconv.ParseUint(targetIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target ID"})
+		return
+	}
+
+	var req models.AssignTargetCatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	target, err := h.missionService.AssignTargetCat(uint(missionID), uint(targetID), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+func (h *MissionHandler) ListCatTargets(c *gin.Context) {
+	idStr := c.Param("id")
+	catID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	targets, err := h.missionService.ListCatTargets(uint(catID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+// pageQuery reads the optional limit and offset parameters of list endpoints
+func pageQuery(c *gin.Context) (int, int, bool) {
+	var limit, offset int
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return 0, 0, false
+		}
+		limit = parsed
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+			return 0, 0, false
+		}
+		offset = parsed
+	}
+	return limit, offset, true
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
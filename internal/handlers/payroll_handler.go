@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PayrollHandler struct {
+	payrollService services.PayrollService
+	validator      *validator.Validate
+}
+
+func NewPayrollHandler(payrollService services.PayrollService) *PayrollHandler {
+	return &PayrollHandler{
+		payrollService: payrollService,
+		validator:      validator.New(),
+	}
+}
+
+func (h *PayrollHandler) PreviewPayroll(c *gin.Context) {
+	run, err := h.payrollService.PreviewPayroll(c.Query("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (h *PayrollHandler) RunPayroll(c *gin.Context) {
+	var req models.CreatePayrollRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	run, err := h.payrollService.RunPayroll(req.Period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, run)
+}
+
+func (h *PayrollHandler) ListRuns(c *gin.Context) {
+	runs, err := h.payrollService.ListRuns()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+func (h *PayrollHandler) GetRun(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payroll run ID"})
+		return
+	}
+
+	run, err := h.payrollService.GetRun(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Payroll run not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (h *PayrollHandler) GetCompensation(c *gin.Context) {
+	idStr := c.Param("id")
+	catID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cat ID"})
+		return
+	}
+
+	compensation, err := h.payrollService.GetCompensation(uint(catID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, compensation)
+}
+
+func (h *PayrollHandler) ListHazardRates(c *gin.Context) {
+	rates, err := h.payrollService.ListHazardRates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+func (h *PayrollHandler) SetHazardRate(c *gin.Context) {
+	var req models.SetHazardPayRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.payrollService.SetHazardRate(c.Param("country"), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+func (h *PayrollHandler) DeleteHazardRate(c *gin.Context) {
+	err := h.payrollService.DeleteHazardRate(c.Param("country"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
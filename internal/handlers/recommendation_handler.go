@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	recommendationService services.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationService services.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationService: recommendationService}
+}
+
+func (h *RecommendationHandler) ListCandidates(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	opts, ok := candidateOptions(c)
+	if !ok {
+		return
+	}
+
+	candidates, err := h.recommendationService.RankCandidates(uint(missionID), opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, candidates)
+}
+
+func (h *RecommendationHandler) AutoAssign(c *gin.Context) {
+	idStr := c.Param("id")
+	missionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mission ID"})
+		return
+	}
+
+	opts, ok := candidateOptions(c)
+	if !ok {
+		return
+	}
+
+	candidate, err := h.recommendationService.AutoAssign(uint(missionID), opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, candidate)
+}
+
+func candidateOptions(c *gin.Context) (models.CandidateOptions, bool) {
+	var opts models.CandidateOptions
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return opts, false
+		}
+		opts.Limit = limit
+	}
+
+	if budgetStr := c.Query("budget"); budgetStr != "" {
+		var budget money.Money
+		var err error
+		if currency := c.Query("currency"); currency != "" {
+			budget, err = money.Parse(budgetStr, currency)
+		} else {
+			budget, err = money.ParseBare(budgetStr)
+		}
+		if err != nil || !budget.IsPositive() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget"})
+			return opts, false
+		}
+		opts.SalaryBudget = &budget
+	}
+
+	return opts, true
+}
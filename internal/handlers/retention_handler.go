@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type RetentionHandler struct {
+	retentionService services.RetentionService
+	validator        *validator.Validate
+}
+
+func NewRetentionHandler(retentionService services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+		validator:        validator.New(),
+	}
+}
+
+func (h *RetentionHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.retentionService.ListPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+func (h *RetentionHandler) SetPolicy(c *gin.Context) {
+	var req models.SetRetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.retentionService.SetPolicy(c.Param("classification"), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+func (h *RetentionHandler) DeletePolicy(c *gin.Context) {
+	err := h.retentionService.DeletePolicy(c.Param("classification"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RetentionHandler) ApplyPolicies(c *gin.Context) {
+	result, err := h.retentionService.ApplyPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *RetentionHandler) CreateErasureRequest(c *gin.Context) {
+	var req models.CreateErasureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.retentionService.CreateErasureRequest(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+func (h *RetentionHandler) ListErasureRequests(c *gin.Context) {
+	requests, err := h.retentionService.ListErasureRequests()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+func (h *RetentionHandler) GetErasureRequest(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid erasure request ID"})
+		return
+	}
+
+	request, err := h.retentionService.GetErasureRequest(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Erasure request not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *RetentionHandler) ExecuteErasureRequest(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid erasure request ID"})
+		return
+	}
+
+	report, err := h.retentionService.ExecuteErasureRequest(uint(id))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *RetentionHandler) GetErasureReport(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid erasure request ID"})
+		return
+	}
+
+	report, err := h.retentionService.GetErasureReport(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
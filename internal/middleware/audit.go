@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditMiddleware records every request that may change data, with the admin who made it.
+// It must run after AuthMiddleware.
+func AuditMiddleware(adminService services.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		entry := &models.AuditEntry{
+			Actor:  "anonymous",
+			Action: c.Request.Method + " " + route,
+			Detail: c.Request.URL.RequestURI(),
+			Status: c.Writer.Status(),
+		}
+		if admin := CurrentAdmin(c); admin != nil {
+			entry.AdminID = &admin.ID
+			entry.Actor = admin.Name
+		}
+
+		if err := adminService.RecordAudit(entry); err != nil {
+			log.Printf("Failed to audit %s: %v", entry.Action, err)
+		}
+	}
+}
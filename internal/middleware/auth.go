@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const adminContextKey = "admin"
+
+// AuthMiddleware identifies the admin behind an "Authorization: Bearer <token>" header.
+// Invalid tokens are always rejected; requests without a token only when auth is required.
+func AuthMiddleware(adminService services.AdminService, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			if required {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+				return
+			}
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
+			return
+		}
+
+		admin, err := adminService.Authenticate(strings.TrimSpace(token))
+		if errors.Is(err, services.ErrInvalidToken) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set(adminContextKey, admin)
+		c.Next()
+	}
+}
+
+// CurrentAdmin is the authenticated admin, or nil for anonymous requests
+func CurrentAdmin(c *gin.Context) *models.AdminUser {
+	if value, ok := c.Get(adminContextKey); ok {
+		if admin, ok := value.(*models.AdminUser); ok {
+			return admin
+		}
+	}
+	return nil
+}
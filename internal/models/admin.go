@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// AdminUser authenticates API requests with a bearer token; only a hash of the token is stored
+type AdminUser struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Name         string     `json:"name" gorm:"not null;uniqueIndex"`
+	TokenHash    string     `json:"-" gorm:"not null;uniqueIndex"`
+	KeyRotatedAt time.Time  `json:"key_rotated_at" gorm:"not null"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// AuditEntry records a change made through the API or an admin command
+type AuditEntry struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	AdminID   *uint     `json:"admin_id" gorm:"index"`
+	Actor     string    `json:"actor" gorm:"not null"`
+	Action    string    `json:"action" gorm:"not null;index"`
+	Detail    string    `json:"detail" gorm:"type:text"`
+	Status    int       `json:"status"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+type AuditFilter struct {
+	Actor  string
+	Action string
+	Since  *time.Time
+	Limit  int
+}
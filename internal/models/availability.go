@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AvailabilityLeave        = "leave"
+	AvailabilityMedicalLeave = "medical_leave"
+	AvailabilityTraining     = "training"
+	AvailabilityMission      = "mission"
+)
+
+type AvailabilityEntry struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	CatID     uint           `json:"cat_id" gorm:"not null;index"`
+	Kind      string         `json:"kind" gorm:"not null"`
+	StartsAt  time.Time      `json:"starts_at" gorm:"not null;index"`
+	EndsAt    *time.Time     `json:"ends_at" gorm:"index"`
+	MissionID *uint          `json:"mission_id" gorm:"index"`
+	Note      string         `json:"note"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+type CreateAvailabilityEntryRequest struct {
+	Kind     string     `json:"kind" validate:"required,oneof=leave medical_leave training"`
+	StartsAt time.Time  `json:"starts_at" validate:"required"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Note     string     `json:"note" validate:"max=500"`
+}
@@ -3,28 +3,54 @@ package models
 import (
 	"time"
 
+	"spy-cat-agency/internal/money"
+
 	"gorm.io/gorm"
 )
 
+const (
+	CatStatusActive    = "active"
+	CatStatusSuspended = "suspended"
+	CatStatusRetired   = "retired"
+	CatStatusKIA       = "kia"
+)
+
 type SpyCat struct {
 	ID              uint           `json:"id" gorm:"primaryKey"`
 	Name            string         `json:"name" gorm:"not null" validate:"required,min=2,max=100"`
 	YearsExperience int            `json:"years_experience" gorm:"not null" validate:"required,min=0,max=50"`
 	Breed           string         `json:"breed" gorm:"not null" validate:"required"`
-	Salary          float64        `json:"salary" gorm:"not null" validate:"required,min=0"`
+	Salary          money.Money    `json:"salary" gorm:"embedded;embeddedPrefix:salary_"`
 	IsAvailable     bool           `json:"is_available" gorm:"default:true"`
+	Status          string         `json:"status" gorm:"not null;default:active;index"`
+	StatusReason    string         `json:"status_reason"`
+	StatusChangedAt *time.Time     `json:"status_changed_at"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type CreateCatRequest struct {
-	Name            string  `json:"name" validate:"required,min=2,max=100"`
-	YearsExperience int     `json:"years_experience" validate:"required,min=0,max=50"`
-	Breed           string  `json:"breed" validate:"required"`
-	Salary          float64 `json:"salary" validate:"required,min=0"`
+	Name            string      `json:"name" validate:"required,min=2,max=100"`
+	YearsExperience int         `json:"years_experience" validate:"required,min=0,max=50"`
+	Breed           string      `json:"breed" validate:"required"`
+	Salary          money.Money `json:"salary"`
 }
 
 type UpdateCatRequest struct {
-	Salary float64 `json:"salary" validate:"required,min=0"`
+	Salary        money.Money `json:"salary"`
+	Reason        string      `json:"reason" validate:"max=255"`
+	EffectiveFrom *time.Time  `json:"effective_from"`
+}
+
+type ChangeCatStatusRequest struct {
+	Status     string `json:"status" validate:"required,oneof=active suspended retired kia"`
+	Reason     string `json:"reason" validate:"max=255"`
+	ReassignTo *uint  `json:"reassign_to"`
+}
+
+type SalaryTotals struct {
+	Total      money.Money            `json:"total"`
+	Cats       int                    `json:"cats"`
+	ByCurrency map[string]money.Money `json:"by_currency"`
 }
@@ -0,0 +1,7 @@
+package models
+
+type UnmappedCountry struct {
+	Table string `json:"table"`
+	Value string `json:"value"`
+	Rows  int64  `json:"rows" gorm:"column:row_count"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Dossier struct {
+	ID        uint             `json:"id" gorm:"primaryKey"`
+	Name      string           `json:"name" gorm:"not null;index"`
+	Summary   string           `json:"summary" gorm:"type:text"`
+	Aliases   []DossierAlias   `json:"aliases" gorm:"foreignKey:DossierID"`
+	Countries []DossierCountry `json:"countries" gorm:"foreignKey:DossierID"`
+	Photos    []DossierPhoto   `json:"photos,omitempty" gorm:"foreignKey:DossierID"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt gorm.DeletedAt   `json:"-" gorm:"index"`
+}
+
+type DossierAlias struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	DossierID uint   `json:"dossier_id" gorm:"not null;uniqueIndex:idx_dossier_alias"`
+	Alias     string `json:"alias" gorm:"not null;uniqueIndex:idx_dossier_alias"`
+}
+
+type DossierCountry struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	DossierID uint   `json:"dossier_id" gorm:"not null;uniqueIndex:idx_dossier_country"`
+	Country   string `json:"country" gorm:"not null;uniqueIndex:idx_dossier_country"`
+	Name      string `json:"name"`
+}
+
+type DossierPhoto struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	DossierID uint       `json:"dossier_id" gorm:"not null;index"`
+	URL       string     `json:"url" gorm:"not null"`
+	Caption   string     `json:"caption"`
+	TakenAt   *time.Time `json:"taken_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type DossierMatch struct {
+	Dossier     Dossier `json:"dossier"`
+	Score       float64 `json:"score"`
+	MatchedOn   string  `json:"matched_on"`
+	SameCountry bool    `json:"same_country"`
+}
+
+type DossierAppearance struct {
+	MissionID        uint       `json:"mission_id"`
+	TargetID         uint       `json:"target_id"`
+	Name             string     `json:"name"`
+	Country          string     `json:"country"`
+	Classification   string     `json:"classification"`
+	Notes            string     `json:"notes"`
+	CatID            *uint      `json:"cat_id"`
+	CatName          string     `json:"cat_name,omitempty"`
+	Outcome          string     `json:"outcome"`
+	MissionCompleted bool       `json:"mission_completed"`
+	StartedAt        *time.Time `json:"started_at"`
+	EndedAt          *time.Time `json:"ended_at"`
+	Deleted          bool       `json:"deleted"`
+}
+
+type DossierView struct {
+	Dossier          Dossier             `json:"dossier"`
+	Missions         int                 `json:"missions"`
+	TargetsCompleted int                 `json:"targets_completed"`
+	TargetsOpen      int                 `json:"targets_open"`
+	CountriesSeen    []string            `json:"countries_seen"`
+	FirstSeenAt      *time.Time          `json:"first_seen_at"`
+	LastSeenAt       *time.Time          `json:"last_seen_at"`
+	Appearances      []DossierAppearance `json:"appearances"`
+}
+
+type CreateDossierRequest struct {
+	Name      string   `json:"name" validate:"required,min=2,max=100"`
+	Summary   string   `json:"summary"`
+	Aliases   []string `json:"aliases" validate:"dive,min=2,max=100"`
+	Countries []string `json:"countries" validate:"dive,min=2,max=100"`
+}
+
+type UpdateDossierRequest struct {
+	Name      string   `json:"name" validate:"required,min=2,max=100"`
+	Summary   string   `json:"summary"`
+	Aliases   []string `json:"aliases" validate:"dive,min=2,max=100"`
+	Countries []string `json:"countries" validate:"dive,min=2,max=100"`
+}
+
+type AddDossierPhotoRequest struct {
+	URL     string     `json:"url" validate:"required,url"`
+	Caption string     `json:"caption" validate:"max=255"`
+	TakenAt *time.Time `json:"taken_at"`
+}
+
+type LinkTargetDossierRequest struct {
+	DossierID *uint `json:"dossier_id"`
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/money"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ExpenseCategoryTravel     = "travel"
+	ExpenseCategoryLodging    = "lodging"
+	ExpenseCategoryEquipment  = "equipment"
+	ExpenseCategoryInformants = "informants"
+	ExpenseCategoryOther      = "other"
+
+	ExpenseStatusPending  = "pending"
+	ExpenseStatusApproved = "approved"
+	ExpenseStatusRejected = "rejected"
+)
+
+type Expense struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	MissionID   uint           `json:"mission_id" gorm:"not null;index"`
+	Mission     *Mission       `json:"mission,omitempty" gorm:"foreignKey:MissionID"`
+	CatID       uint           `json:"cat_id" gorm:"not null;index"`
+	Cat         *SpyCat        `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	Category    string         `json:"category" gorm:"not null"`
+	Amount      money.Money    `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Description string         `json:"description"`
+	ReceiptRef  string         `json:"receipt_ref"`
+	Status      string         `json:"status" gorm:"not null;default:pending;index"`
+	ReviewNote  string         `json:"review_note"`
+	ReviewedAt  *time.Time     `json:"reviewed_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+type ExpenseSummary struct {
+	MissionID   uint                   `json:"mission_id"`
+	Budget      money.Money            `json:"budget"`
+	Approved    money.Money            `json:"approved"`
+	Pending     money.Money            `json:"pending"`
+	Rejected    money.Money            `json:"rejected"`
+	Remaining   money.Money            `json:"remaining"`
+	UsedPercent float64                `json:"used_percent"`
+	ByCategory  map[string]money.Money `json:"by_category"`
+	Warnings    []string               `json:"warnings"`
+}
+
+type SetMissionBudgetRequest struct {
+	Budget money.Money `json:"budget"`
+}
+
+type CreateExpenseRequest struct {
+	CatID       uint        `json:"cat_id" validate:"required"`
+	Category    string      `json:"category" validate:"required,oneof=travel lodging equipment informants other"`
+	Amount      money.Money `json:"amount"`
+	Description string      `json:"description" validate:"max=500"`
+	ReceiptRef  string      `json:"receipt_ref" validate:"max=100"`
+}
+
+type ReviewExpenseRequest struct {
+	Note string `json:"note" validate:"max=500"`
+}
@@ -0,0 +1,37 @@
+package models
+
+const (
+	ImportTypeCats     = "cats"
+	ImportTypeMissions = "missions"
+
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	ImportModeAllOrNothing = "all_or_nothing"
+	ImportModeBestEffort   = "best_effort"
+)
+
+type ImportOptions struct {
+	Type   string
+	Format string
+	Mode   string
+	DryRun bool
+}
+
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type ImportReport struct {
+	Type      string           `json:"type"`
+	Format    string           `json:"format"`
+	Mode      string           `json:"mode"`
+	DryRun    bool             `json:"dry_run"`
+	Committed bool             `json:"committed"`
+	Rows      int              `json:"rows"`
+	Valid     int              `json:"valid"`
+	Failed    int              `json:"failed"`
+	IDs       []uint           `json:"ids"`
+	Errors    []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// TargetNote is a dated note entry, optionally with where the target was seen
+type TargetNote struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TargetID  uint      `json:"target_id" gorm:"not null;index"`
+	MissionID uint      `json:"mission_id" gorm:"not null;index"`
+	CatID     *uint     `json:"cat_id"`
+	Notes     string    `json:"notes" gorm:"type:text"`
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	SeenAt    time.Time `json:"seen_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TargetDistance struct {
+	Target     Target  `json:"target"`
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type BoundingBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+type AddTargetNoteRequest struct {
+	CatID     *uint      `json:"cat_id"`
+	Notes     string     `json:"notes" validate:"max=10000"`
+	Latitude  *float64   `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude *float64   `json:"longitude" validate:"omitempty,min=-180,max=180"`
+	SeenAt    *time.Time `json:"seen_at"`
+}
+
+// TargetLocation is where the target was last seen, or its recorded coordinates if it was never seen
+func TargetLocation(target *Target) (lat, lng float64, ok bool) {
+	if target.LastSeenLat != nil && target.LastSeenLng != nil {
+		return *target.LastSeenLat, *target.LastSeenLng, true
+	}
+	if target.Latitude != nil && target.Longitude != nil {
+		return *target.Latitude, *target.Longitude, true
+	}
+	return 0, 0, false
+}
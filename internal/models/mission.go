@@ -3,57 +3,150 @@ package models
 import (
 	"time"
 
+	"spy-cat-agency/internal/money"
+
 	"gorm.io/gorm"
 )
 
 type Mission struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	CatID       *uint          `json:"cat_id" gorm:"index"`
-	Cat         *SpyCat        `json:"cat,omitempty" gorm:"foreignKey:CatID"`
-	IsCompleted bool           `json:"is_completed" gorm:"default:false"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
-	Targets     []Target       `json:"targets,omitempty" gorm:"foreignKey:MissionID"`
+	ID             uint                      `json:"id" gorm:"primaryKey"`
+	CatID          *uint                     `json:"cat_id" gorm:"index"`
+	Cat            *SpyCat                   `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	IsCompleted    bool                      `json:"is_completed" gorm:"default:false"`
+	PlannedStartAt *time.Time                `json:"planned_start_at"`
+	DeadlineAt     *time.Time                `json:"deadline_at" gorm:"index"`
+	StartedAt      *time.Time                `json:"started_at"`
+	EndedAt        *time.Time                `json:"ended_at"`
+	IsOverdue      bool                      `json:"is_overdue" gorm:"default:false;index"`
+	Budget         money.Money               `json:"budget" gorm:"embedded;embeddedPrefix:budget_"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt            `json:"-" gorm:"index"`
+	Targets        []Target                  `json:"targets,omitempty" gorm:"foreignKey:MissionID"`
+	RequiredSkills []MissionSkillRequirement `json:"required_skills,omitempty" gorm:"foreignKey:MissionID"`
+	Members        []MissionCat              `json:"members,omitempty" gorm:"foreignKey:MissionID"`
+}
+
+// MissionCat puts a cat on a mission alongside the lead cat in Mission.CatID
+type MissionCat struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	MissionID uint      `json:"mission_id" gorm:"not null;uniqueIndex:idx_mission_cats_mission_cat"`
+	CatID     uint      `json:"cat_id" gorm:"not null;uniqueIndex:idx_mission_cats_mission_cat;index"`
+	Cat       *SpyCat   `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Target struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	MissionID   uint           `json:"mission_id" gorm:"not null;index"`
-	Mission     *Mission       `json:"mission,omitempty" gorm:"foreignKey:MissionID"`
-	Name        string         `json:"name" gorm:"not null" validate:"required,min=2,max=100"`
-	Country     string         `json:"country" gorm:"not null" validate:"required,min=2,max=100"`
-	Notes       string         `json:"notes" gorm:"type:text"`
-	IsCompleted bool           `json:"is_completed" gorm:"default:false"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	MissionID       uint           `json:"mission_id" gorm:"not null;index"`
+	Mission         *Mission       `json:"mission,omitempty" gorm:"foreignKey:MissionID"`
+	Name            string         `json:"name" gorm:"not null" validate:"required,min=2,max=100"`
+	Country         string         `json:"country" gorm:"not null;index" validate:"required,min=2,max=100"`
+	CountryName     string         `json:"country_name"`
+	Notes           string         `json:"notes" gorm:"type:text"`
+	Classification  string         `json:"classification" gorm:"not null;default:confidential;index"`
+	NotesPurgedAt   *time.Time     `json:"notes_purged_at"`
+	PseudonymizedAt *time.Time     `json:"pseudonymized_at"`
+	CatID           *uint          `json:"cat_id" gorm:"index"`
+	Cat             *SpyCat        `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	DossierID       *uint          `json:"dossier_id" gorm:"index"`
+	Latitude        *float64       `json:"latitude"`
+	Longitude       *float64       `json:"longitude"`
+	LastSeenLat     *float64       `json:"last_seen_latitude"`
+	LastSeenLng     *float64       `json:"last_seen_longitude"`
+	LastSeenAt      *time.Time     `json:"last_seen_at"`
+	DeadlineAt      *time.Time     `json:"deadline_at"`
+	IsCompleted     bool           `json:"is_completed" gorm:"default:false"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type CreateMissionRequest struct {
-	CatID   *uint                 `json:"cat_id"`
-	Targets []CreateTargetRequest `json:"targets" validate:"required,min=1,max=3,dive"`
+	CatID          *uint                     `json:"cat_id"`
+	PlannedStartAt *time.Time                `json:"planned_start_at"`
+	DeadlineAt     *time.Time                `json:"deadline_at"`
+	Targets        []CreateTargetRequest     `json:"targets" validate:"required,min=1,max=3,dive"`
+	RequiredSkills []SkillRequirementRequest `json:"required_skills" validate:"dive"`
 }
 
 type CreateTargetRequest struct {
-	Name    string `json:"name" validate:"required,min=2,max=100"`
-	Country string `json:"country" validate:"required,min=2,max=100"`
+	Name           string     `json:"name" validate:"required,min=2,max=100"`
+	Country        string     `json:"country" validate:"required,min=2,max=100"`
+	Classification string     `json:"classification" validate:"omitempty,oneof=public confidential secret top_secret"`
+	DeadlineAt     *time.Time `json:"deadline_at"`
+	Latitude       *float64   `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude      *float64   `json:"longitude" validate:"omitempty,min=-180,max=180"`
 }
 
 type UpdateMissionRequest struct {
-	CatID *uint `json:"cat_id"`
+	CatID          *uint      `json:"cat_id"`
+	PlannedStartAt *time.Time `json:"planned_start_at"`
+	DeadlineAt     *time.Time `json:"deadline_at"`
 }
 
 type AddTargetRequest struct {
-	Name    string `json:"name" validate:"required,min=2,max=100"`
-	Country string `json:"country" validate:"required,min=2,max=100"`
+	Name           string     `json:"name" validate:"required,min=2,max=100"`
+	Country        string     `json:"country" validate:"required,min=2,max=100"`
+	Classification string     `json:"classification" validate:"omitempty,oneof=public confidential secret top_secret"`
+	DeadlineAt     *time.Time `json:"deadline_at"`
+	Latitude       *float64   `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude      *float64   `json:"longitude" validate:"omitempty,min=-180,max=180"`
 }
 
 type UpdateTargetRequest struct {
-	Name    string `json:"name" validate:"required,min=2,max=100"`
-	Country string `json:"country" validate:"required,min=2,max=100"`
+	Name           string     `json:"name" validate:"required,min=2,max=100"`
+	Country        string     `json:"country" validate:"required,min=2,max=100"`
+	Classification string     `json:"classification" validate:"omitempty,oneof=public confidential secret top_secret"`
+	DeadlineAt     *time.Time `json:"deadline_at"`
+	Latitude       *float64   `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude      *float64   `json:"longitude" validate:"omitempty,min=-180,max=180"`
 }
 
 type UpdateTargetNotesRequest struct {
+	CatID *uint  `json:"cat_id"`
 	Notes string `json:"notes" validate:"required"`
 }
+
+type CompleteTargetRequest struct {
+	CatID *uint `json:"cat_id"`
+}
+
+type AssignTargetCatRequest struct {
+	CatID *uint `json:"cat_id"`
+}
+
+const (
+	MissionStateUnassigned = "unassigned"
+	MissionStateActive     = "active"
+	MissionStateOverdue    = "overdue"
+	MissionStateCompleted  = "completed"
+)
+
+// MissionState matches the state filter of the mission list
+func MissionState(mission *Mission) string {
+	switch {
+	case mission.IsCompleted:
+		return MissionStateCompleted
+	case mission.IsOverdue:
+		return MissionStateOverdue
+	case mission.CatID != nil:
+		return MissionStateActive
+	default:
+		return MissionStateUnassigned
+	}
+}
+
+type MissionFilter struct {
+	Overdue   *bool
+	DueBefore *time.Time
+	State     string
+	Limit     int
+	Offset    int
+}
+
+// TargetFilter narrows a target export to one mission or the targets assigned to one cat
+type TargetFilter struct {
+	MissionID uint
+	CatID     uint
+}
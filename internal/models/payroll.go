@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/money"
+)
+
+type SalaryRecord struct {
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	CatID         uint        `json:"cat_id" gorm:"not null;index"`
+	Salary        money.Money `json:"salary" gorm:"embedded;embeddedPrefix:salary_"`
+	EffectiveFrom time.Time   `json:"effective_from" gorm:"not null;index"`
+	Reason        string      `json:"reason"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type HazardPayRate struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	Country     string      `json:"country" gorm:"not null;uniqueIndex"`
+	CountryName string      `json:"country_name"`
+	Rate        money.Money `json:"rate" gorm:"embedded;embeddedPrefix:rate_"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type PayrollRun struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Period      string         `json:"period" gorm:"not null;uniqueIndex"`
+	PeriodStart time.Time      `json:"period_start" gorm:"not null"`
+	PeriodEnd   time.Time      `json:"period_end" gorm:"not null"`
+	Total       money.Money    `json:"total" gorm:"embedded;embeddedPrefix:total_"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Entries     []PayrollEntry `json:"entries,omitempty" gorm:"foreignKey:RunID"`
+}
+
+type PayrollEntry struct {
+	ID                uint        `json:"id" gorm:"primaryKey"`
+	RunID             uint        `json:"run_id" gorm:"not null;index"`
+	Run               *PayrollRun `json:"run,omitempty" gorm:"foreignKey:RunID"`
+	CatID             uint        `json:"cat_id" gorm:"not null;index"`
+	Cat               *SpyCat     `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	BaseSalary        money.Money `json:"base_salary" gorm:"embedded;embeddedPrefix:base_"`
+	MissionBonus      money.Money `json:"mission_bonus" gorm:"embedded;embeddedPrefix:bonus_"`
+	HazardPay         money.Money `json:"hazard_pay" gorm:"embedded;embeddedPrefix:hazard_"`
+	Total             money.Money `json:"total" gorm:"embedded;embeddedPrefix:total_"`
+	MissionsCompleted int         `json:"missions_completed"`
+}
+
+type Compensation struct {
+	Cat           SpyCat         `json:"cat"`
+	CurrentSalary money.Money    `json:"current_salary"`
+	SalaryHistory []SalaryRecord `json:"salary_history"`
+	Payslips      []PayrollEntry `json:"payslips"`
+	TotalPaid     money.Money    `json:"total_paid"`
+}
+
+type CreatePayrollRunRequest struct {
+	Period string `json:"period" validate:"required,datetime=2006-01"`
+}
+
+type SetHazardPayRateRequest struct {
+	Rate money.Money `json:"rate"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "spy-cat-agency/internal/money"
+
+type CandidateOptions struct {
+	SalaryBudget *money.Money
+	Limit        int
+}
+
+type ScoreComponent struct {
+	Factor       string  `json:"factor"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+	Detail       string  `json:"detail"`
+}
+
+type CandidateScore struct {
+	Cat       SpyCat           `json:"cat"`
+	Score     float64          `json:"score"`
+	Breakdown []ScoreComponent `json:"breakdown"`
+}
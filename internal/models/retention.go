@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+const (
+	ClassificationPublic       = "public"
+	ClassificationConfidential = "confidential"
+	ClassificationSecret       = "secret"
+	ClassificationTopSecret    = "top_secret"
+
+	ErasureStatusPending   = "pending"
+	ErasureStatusCompleted = "completed"
+)
+
+type RetentionPolicy struct {
+	ID                    uint      `json:"id" gorm:"primaryKey"`
+	Classification        string    `json:"classification" gorm:"not null;uniqueIndex"`
+	PurgeNotesAfterDays   int       `json:"purge_notes_after_days"`
+	PseudonymizeAfterDays int       `json:"pseudonymize_after_days"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+type ErasureRequest struct {
+	ID             uint                   `json:"id" gorm:"primaryKey"`
+	SubjectName    string                 `json:"subject_name" gorm:"not null"`
+	SubjectToken   string                 `json:"subject_token" gorm:"not null;index"`
+	Country        string                 `json:"country"`
+	Reason         string                 `json:"reason"`
+	Status         string                 `json:"status" gorm:"not null;default:pending;index"`
+	CompletedAt    *time.Time             `json:"completed_at"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	Records        []ErasureRecord        `json:"records,omitempty" gorm:"foreignKey:RequestID"`
+	Matches        []Target               `json:"matches,omitempty" gorm:"-"`
+	DossierRecords []ErasureDossierRecord `json:"dossier_records,omitempty" gorm:"foreignKey:RequestID"`
+	DossierMatches []Dossier              `json:"dossier_matches,omitempty" gorm:"-"`
+}
+
+type ErasureRecord struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	RequestID uint      `json:"request_id" gorm:"not null;index"`
+	TargetID  uint      `json:"target_id" gorm:"not null;index"`
+	MissionID uint      `json:"mission_id" gorm:"not null"`
+	Fields    string    `json:"fields"`
+	ErasedAt  time.Time `json:"erased_at"`
+}
+
+// ErasureDossierRecord is a dossier deleted by an erasure request, with its aliases and photos
+type ErasureDossierRecord struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	RequestID       uint      `json:"request_id" gorm:"not null;index"`
+	DossierID       uint      `json:"dossier_id" gorm:"not null;index"`
+	Aliases         int       `json:"aliases"`
+	Photos          int       `json:"photos"`
+	TargetsUnlinked int       `json:"targets_unlinked"`
+	ErasedAt        time.Time `json:"erased_at"`
+}
+
+type RetentionResult struct {
+	NotesPurged   int64 `json:"notes_purged"`
+	Pseudonymized int64 `json:"pseudonymized"`
+}
+
+type ErasureReport struct {
+	RequestID       uint                   `json:"request_id"`
+	SubjectToken    string                 `json:"subject_token"`
+	Status          string                 `json:"status"`
+	CompletedAt     *time.Time             `json:"completed_at"`
+	TargetsErased   int                    `json:"targets_erased"`
+	MissionsTouched int                    `json:"missions_touched"`
+	DossiersErased  int                    `json:"dossiers_erased"`
+	Records         []ErasureRecord        `json:"records"`
+	DossierRecords  []ErasureDossierRecord `json:"dossier_records"`
+}
+
+type SetRetentionPolicyRequest struct {
+	PurgeNotesAfterDays   int `json:"purge_notes_after_days" validate:"min=0"`
+	PseudonymizeAfterDays int `json:"pseudonymize_after_days" validate:"min=0"`
+}
+
+type CreateErasureRequest struct {
+	SubjectName string `json:"subject_name" validate:"required,min=2,max=100"`
+	Country     string `json:"country" validate:"omitempty,min=2,max=100"`
+	Reason      string `json:"reason" validate:"max=500"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+type Skill struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex" validate:"required,min=2,max=100"`
+	Category    string    `json:"category" validate:"max=50"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CatSkill struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	CatID       uint       `json:"cat_id" gorm:"not null;uniqueIndex:idx_cat_skill"`
+	Cat         *SpyCat    `json:"cat,omitempty" gorm:"foreignKey:CatID"`
+	SkillID     uint       `json:"skill_id" gorm:"not null;uniqueIndex:idx_cat_skill"`
+	Skill       *Skill     `json:"skill,omitempty" gorm:"foreignKey:SkillID"`
+	Level       int        `json:"level" gorm:"not null"`
+	CertifiedAt *time.Time `json:"certified_at"`
+	ExpiresAt   *time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type MissionSkillRequirement struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	MissionID uint   `json:"mission_id" gorm:"not null;index"`
+	SkillID   uint   `json:"skill_id" gorm:"not null"`
+	Skill     *Skill `json:"skill,omitempty" gorm:"foreignKey:SkillID"`
+	MinLevel  int    `json:"min_level" gorm:"not null"`
+}
+
+type CreateSkillRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Category    string `json:"category" validate:"max=50"`
+	Description string `json:"description"`
+}
+
+type UpdateSkillRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Category    string `json:"category" validate:"max=50"`
+	Description string `json:"description"`
+}
+
+type AddCatSkillRequest struct {
+	SkillID     uint       `json:"skill_id" validate:"required"`
+	Level       int        `json:"level" validate:"required,min=1,max=5"`
+	CertifiedAt *time.Time `json:"certified_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type UpdateCatSkillRequest struct {
+	Level       int        `json:"level" validate:"required,min=1,max=5"`
+	CertifiedAt *time.Time `json:"certified_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type SkillRequirementRequest struct {
+	SkillID  uint `json:"skill_id" validate:"required"`
+	MinLevel int  `json:"min_level" validate:"required,min=1,max=5"`
+}
+
+type SetMissionSkillsRequest struct {
+	RequiredSkills []SkillRequirementRequest `json:"required_skills" validate:"dive"`
+}
+
+type CatFilter struct {
+	Skill    string
+	MinLevel int
+	Status   string
+	// Limit of 0 returns every match; Offset only applies together with a limit
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	TrashTypeCat     = "cat"
+	TrashTypeMission = "mission"
+	TrashTypeTarget  = "target"
+)
+
+type TrashItem struct {
+	Type      string      `json:"type"`
+	ID        uint        `json:"id"`
+	Label     string      `json:"label"`
+	DeletedAt time.Time   `json:"deleted_at"`
+	PurgeAt   *time.Time  `json:"purge_at"`
+	Record    interface{} `json:"record"`
+}
+
+type PurgeResult struct {
+	Cats     int64 `json:"cats"`
+	Missions int64 `json:"missions"`
+	Targets  int64 `json:"targets"`
+}
@@ -0,0 +1,209 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const DefaultCurrency = "USD"
+
+// Money is an amount in minor units (e.g. cents) of an ISO 4217 currency
+type Money struct {
+	Amount   int64  `json:"amount" gorm:"not null;default:0"`
+	Currency string `json:"currency" gorm:"type:varchar(3);not null;default:'USD'"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// ParseBare reads an amount given without a currency; see OrCurrency
+func ParseBare(amount string) (Money, error) {
+	parsed, err := Parse(amount, DefaultCurrency)
+	if err != nil {
+		return Money{}, err
+	}
+	parsed.Currency = ""
+	return parsed, nil
+}
+
+// Parse reads a decimal amount such as "1250.50" without going through float64
+func Parse(amount, currency string) (Money, error) {
+	code, ok := NormalizeCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", currency)
+	}
+
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(strings.TrimPrefix(amount, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(amount, ".")
+	exp := exponent(code)
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(fraction) > exp {
+		trimmed := strings.TrimRight(fraction[exp:], "0")
+		if trimmed != "" {
+			return Money{}, fmt.Errorf("amount %q has more than %d decimal places for %s", amount, exp, code)
+		}
+		fraction = fraction[:exp]
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	digits := whole + fraction
+	if digits == "" {
+		digits = "0"
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || !value.IsInt64() {
+		return Money{}, fmt.Errorf("amount %q is out of range", amount)
+	}
+
+	minor := value.Int64()
+	if negative {
+		minor = -minor
+	}
+	return Money{Amount: minor, Currency: code}, nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("cannot add %s to %s", other.Currency, m.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("cannot subtract %s from %s", other.Currency, m.Currency)
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Multiply(factor int64) Money {
+	return Money{Amount: m.Amount * factor, Currency: m.Currency}
+}
+
+// Float is only meant for ratios and scoring, never for storing amounts
+func (m Money) Float() float64 {
+	f, _ := m.rat().Float64()
+	return f
+}
+
+func (m Money) Decimal() string {
+	exp := exponent(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := fmt.Sprintf("%0*d", exp+1, amount)
+	if exp == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(exponent(m.Currency)))
+}
+
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+// UnmarshalJSON accepts {"amount": "12.50", "currency": "EUR"}, with the amount
+// as a string or number, or a bare amount whose currency is filled in later
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	var raw struct {
+		Amount   json.Number `json:"amount"`
+		Currency string      `json:"currency"`
+	}
+	if len(data) > 0 && data[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, &raw.Amount); err != nil {
+		return fmt.Errorf("invalid money value %s", data)
+	}
+
+	var parsed Money
+	var err error
+	if raw.Currency == "" {
+		parsed, err = ParseBare(raw.Amount.String())
+	} else {
+		parsed, err = Parse(raw.Amount.String(), raw.Currency)
+	}
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// OrCurrency assigns a currency to an amount that was given without one
+func (m Money) OrCurrency(currency string) (Money, error) {
+	if m.Currency != "" {
+		return m, nil
+	}
+
+	code, ok := NormalizeCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", currency)
+	}
+
+	// Bare amounts are parsed with two decimal places
+	scaled := new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(2))
+	scaled.Mul(scaled, new(big.Rat).SetInt(pow10(exponent(code))))
+	if !scaled.IsInt() {
+		return Money{}, fmt.Errorf("amount %s has too many decimal places for %s", Money{Amount: m.Amount, Currency: DefaultCurrency}.Decimal(), code)
+	}
+	return Money{Amount: scaled.Num().Int64(), Currency: code}, nil
+}
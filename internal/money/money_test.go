@@ -0,0 +1,166 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		want             Money
+		wantErr          bool
+	}{
+		{amount: "1250.50", currency: "USD", want: New(125050, "USD")},
+		{amount: "12.5", currency: "USD", want: New(1250, "USD")},
+		{amount: " 7 ", currency: "usd", want: New(700, "USD")},
+		{amount: "+3", currency: "EUR", want: New(300, "EUR")},
+		{amount: "-0.01", currency: "EUR", want: New(-1, "EUR")},
+		{amount: ".5", currency: "EUR", want: New(50, "EUR")},
+		{amount: "5.", currency: "EUR", want: New(500, "EUR")},
+		{amount: "1.230", currency: "USD", want: New(123, "USD")},
+		{amount: "100", currency: "JPY", want: New(100, "JPY")},
+		{amount: "1.234", currency: "KWD", want: New(1234, "KWD")},
+		{amount: "1.234", currency: "USD", wantErr: true},
+		{amount: "100.5", currency: "JPY", wantErr: true},
+		{amount: "", currency: "USD", wantErr: true},
+		{amount: "-", currency: "USD", wantErr: true},
+		{amount: "abc", currency: "USD", wantErr: true},
+		{amount: "1e3", currency: "USD", wantErr: true},
+		{amount: "1,000", currency: "USD", wantErr: true},
+		{amount: "99999999999999999999", currency: "USD", wantErr: true},
+		{amount: "10", currency: "XXX", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.amount, test.currency)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q, %q) = %v, want an error", test.amount, test.currency, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("Parse(%q, %q) = %v, %v, want %v", test.amount, test.currency, got, err, test.want)
+		}
+	}
+}
+
+func TestParseBare(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    Money
+		wantErr bool
+	}{
+		{amount: "12.50", want: Money{Amount: 1250}},
+		{amount: "-3", want: Money{Amount: -300}},
+		{amount: "0.001", wantErr: true},
+		{amount: "abc", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := ParseBare(test.amount)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("ParseBare(%q) = %v, want an error", test.amount, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseBare(%q) = %v, %v, want %v", test.amount, got, err, test.want)
+		}
+	}
+}
+
+func TestConvertRounding(t *testing.T) {
+	rates := NewRates("usd")
+	if rates.Base() != "USD" {
+		t.Fatalf("base: got %q, want USD", rates.Base())
+	}
+	for code, rate := range map[string]string{"EUR": "0.5", "GBP": "0.4", "JPY": "150"} {
+		if err := rates.Set(code, rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		from     Money
+		currency string
+		want     Money
+	}{
+		// Halves round away from zero in both signs
+		{from: New(1, "USD"), currency: "EUR", want: New(1, "EUR")},
+		{from: New(-1, "USD"), currency: "EUR", want: New(-1, "EUR")},
+		{from: New(3, "USD"), currency: "EUR", want: New(2, "EUR")},
+		{from: New(-3, "USD"), currency: "EUR", want: New(-2, "EUR")},
+		{from: New(1, "USD"), currency: "JPY", want: New(2, "JPY")},
+		{from: New(-1, "USD"), currency: "JPY", want: New(-2, "JPY")},
+		// Below a half rounds towards zero
+		{from: New(1, "USD"), currency: "GBP", want: New(0, "GBP")},
+		{from: New(-1, "USD"), currency: "GBP", want: New(0, "GBP")},
+		{from: New(150, "JPY"), currency: "EUR", want: New(50, "EUR")},
+		{from: New(1250, "EUR"), currency: "EUR", want: New(1250, "EUR")},
+	}
+	for _, test := range tests {
+		got, err := rates.Convert(test.from, test.currency)
+		if err != nil || got != test.want {
+			t.Errorf("Convert(%v, %s) = %v, %v, want %v", test.from, test.currency, got, err, test.want)
+		}
+	}
+
+	if _, err := rates.Convert(New(100, "CHF"), "USD"); err == nil {
+		t.Error("Convert from a currency without a rate: got no error")
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Money
+		wantErr bool
+	}{
+		{data: `{"amount": "12.50", "currency": "EUR"}`, want: New(1250, "EUR")},
+		{data: `{"amount": 12.5, "currency": "eur"}`, want: New(1250, "EUR")},
+		{data: `{"amount": -7, "currency": "JPY"}`, want: New(-7, "JPY")},
+		{data: `"12.50"`, want: Money{Amount: 1250}},
+		{data: `12.5`, want: Money{Amount: 1250}},
+		{data: `null`, want: Money{}},
+		{data: `{"amount": "1.234", "currency": "USD"}`, wantErr: true},
+		{data: `{"amount": 1, "currency": "XXX"}`, wantErr: true},
+		{data: `true`, wantErr: true},
+	}
+	for _, test := range tests {
+		var got Money
+		err := json.Unmarshal([]byte(test.data), &got)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %v, want an error", test.data, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", test.data, got, err, test.want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		money Money
+		json  string
+	}{
+		{money: New(125050, "USD"), json: `{"amount":"1250.50","currency":"USD"}`},
+		{money: New(-1, "EUR"), json: `{"amount":"-0.01","currency":"EUR"}`},
+		{money: New(100, "JPY"), json: `{"amount":"100","currency":"JPY"}`},
+		{money: New(1234, "KWD"), json: `{"amount":"1.234","currency":"KWD"}`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.money)
+		if err != nil || string(data) != test.json {
+			t.Errorf("Marshal(%v) = %s, %v, want %s", test.money, data, err, test.json)
+			continue
+		}
+		var got Money
+		if err := json.Unmarshal(data, &got); err != nil || got != test.money {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, got, err, test.money)
+		}
+	}
+}
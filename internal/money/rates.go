@@ -0,0 +1,125 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// Rates holds how many units of each currency buy one unit of the base currency
+type Rates struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+type ratesFile struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// NewRates starts with only the base currency, whose code is normalized like every other currency
+func NewRates(base string) *Rates {
+	base, _ = NormalizeCurrency(base)
+	return &Rates{
+		base:  base,
+		rates: map[string]*big.Rat{base: big.NewRat(1, 1)},
+	}
+}
+
+// LoadRates reads a JSON file such as {"base": "USD", "rates": {"EUR": "0.92"}}
+func LoadRates(path string) (*Rates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exchange rates: %w", err)
+	}
+
+	var file ratesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rates: %w", err)
+	}
+
+	base, ok := NormalizeCurrency(file.Base)
+	if !ok {
+		return nil, fmt.Errorf("unknown base currency %q", file.Base)
+	}
+
+	rates := NewRates(base)
+	for code, value := range file.Rates {
+		if err := rates.Set(code, value.String()); err != nil {
+			return nil, err
+		}
+	}
+	return rates, nil
+}
+
+func (r *Rates) Base() string {
+	return r.base
+}
+
+func (r *Rates) Set(currency, rate string) error {
+	code, ok := NormalizeCurrency(currency)
+	if !ok {
+		return fmt.Errorf("unknown currency %q", currency)
+	}
+
+	value, ok := new(big.Rat).SetString(rate)
+	if !ok || value.Sign() <= 0 {
+		return fmt.Errorf("invalid exchange rate %q for %s", rate, code)
+	}
+
+	r.rates[code] = value
+	return nil
+}
+
+// Convert rounds half away from zero to the minor unit of the target currency
+func (r *Rates) Convert(m Money, currency string) (Money, error) {
+	if m.Currency == currency {
+		return m, nil
+	}
+
+	from, ok := r.rates[m.Currency]
+	if !ok {
+		return Money{}, fmt.Errorf("no exchange rate for %s", m.Currency)
+	}
+	to, ok := r.rates[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("no exchange rate for %s", currency)
+	}
+
+	value := m.rat()
+	value.Quo(value, from)
+	value.Mul(value, to)
+	value.Mul(value, new(big.Rat).SetInt(pow10(exponent(currency))))
+
+	return Money{Amount: roundRat(value), Currency: currency}, nil
+}
+
+func (r *Rates) Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, amount := range amounts {
+		converted, err := r.Convert(amount, currency)
+		if err != nil {
+			return Money{}, err
+		}
+		total.Amount += converted.Amount
+	}
+	return total, nil
+}
+
+func roundRat(value *big.Rat) int64 {
+	num := new(big.Int).Set(value.Num())
+	den := value.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if negative {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
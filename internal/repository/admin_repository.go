@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AdminRepository interface {
+	Create(admin *models.AdminUser) error
+	GetByName(name string) (*models.AdminUser, error)
+	GetByTokenHash(hash string) (*models.AdminUser, error)
+	GetAll() ([]models.AdminUser, error)
+	SetTokenHash(id uint, hash string, at time.Time) error
+	TouchLastUsed(id uint, at time.Time) error
+	CreateAuditEntry(entry *models.AuditEntry) error
+	GetAuditEntries(filter models.AuditFilter) ([]models.AuditEntry, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+func (r *adminRepository) Create(admin *models.AdminUser) error {
+	return r.db.Create(admin).Error
+}
+
+func (r *adminRepository) GetByName(name string) (*models.AdminUser, error) {
+	var admin models.AdminUser
+	err := r.db.Where("name = ?", name).First(&admin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) GetByTokenHash(hash string) (*models.AdminUser, error) {
+	var admin models.AdminUser
+	err := r.db.Where("token_hash = ?", hash).First(&admin).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) GetAll() ([]models.AdminUser, error) {
+	var admins []models.AdminUser
+	err := r.db.Order("name").Find(&admins).Error
+	return admins, err
+}
+
+func (r *adminRepository) SetTokenHash(id uint, hash string, at time.Time) error {
+	return r.db.Model(&models.AdminUser{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"token_hash": hash, "key_rotated_at": at}).Error
+}
+
+// Touching the admin does not count as an update
+func (r *adminRepository) TouchLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&models.AdminUser{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
+
+func (r *adminRepository) CreateAuditEntry(entry *models.AuditEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *adminRepository) GetAuditEntries(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	var entries []models.AuditEntry
+	query := r.db.Order("created_at DESC, id DESC")
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	err := query.Find(&entries).Error
+	return entries, err
+}
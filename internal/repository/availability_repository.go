@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AvailabilityRepository interface {
+	Create(entry *models.AvailabilityEntry) error
+	GetByID(id uint) (*models.AvailabilityEntry, error)
+	GetByCatID(catID uint) ([]models.AvailabilityEntry, error)
+	Delete(id uint) error
+	FindOverlapping(catID uint, from time.Time, to *time.Time) ([]models.AvailabilityEntry, error)
+	GetUnavailableCatIDs(from time.Time, to *time.Time) ([]uint, error)
+	DeleteByMissionID(missionID uint) error
+	CloseMissionBooking(missionID uint, at time.Time) error
+}
+
+type availabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) AvailabilityRepository {
+	return &availabilityRepository{db: db}
+}
+
+func (r *availabilityRepository) Create(entry *models.AvailabilityEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *availabilityRepository) GetByID(id uint) (*models.AvailabilityEntry, error) {
+	var entry models.AvailabilityEntry
+	err := r.db.First(&entry, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *availabilityRepository) GetByCatID(catID uint) ([]models.AvailabilityEntry, error) {
+	var entries []models.AvailabilityEntry
+	err := r.db.Where("cat_id = ?", catID).Order("starts_at").Find(&entries).Error
+	return entries, err
+}
+
+func (r *availabilityRepository) Delete(id uint) error {
+	return r.db.Delete(&models.AvailabilityEntry{}, id).Error
+}
+
+func (r *availabilityRepository) FindOverlapping(catID uint, from time.Time, to *time.Time) ([]models.AvailabilityEntry, error) {
+	var entries []models.AvailabilityEntry
+	err := overlapping(r.db, from, to).Where("cat_id = ?", catID).Order("starts_at").Find(&entries).Error
+	return entries, err
+}
+
+func (r *availabilityRepository) GetUnavailableCatIDs(from time.Time, to *time.Time) ([]uint, error) {
+	var catIDs []uint
+	err := overlapping(r.db.Model(&models.AvailabilityEntry{}), from, to).Distinct().Pluck("cat_id", &catIDs).Error
+	return catIDs, err
+}
+
+func (r *availabilityRepository) DeleteByMissionID(missionID uint) error {
+	return r.db.Where("mission_id = ?", missionID).Delete(&models.AvailabilityEntry{}).Error
+}
+
+// CloseMissionBooking ends a mission's bookings at the given time; a booking that has not started yet
+// ends when it starts, so it never ends before it begins
+func (r *availabilityRepository) CloseMissionBooking(missionID uint, at time.Time) error {
+	return r.db.Model(&models.AvailabilityEntry{}).Where("mission_id = ?", missionID).
+		Update("ends_at", gorm.Expr("CASE WHEN starts_at > ? THEN starts_at ELSE ? END", at, at)).Error
+}
+
+func overlapping(query *gorm.DB, from time.Time, to *time.Time) *gorm.DB {
+	query = query.Where("(ends_at IS NULL OR ends_at > ?)", from)
+	if to != nil {
+		query = query.Where("starts_at < ?", *to)
+	}
+	return query
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"database/sql"
+	"time"
+
 	"spy-cat-agency/internal/models"
 
 	"gorm.io/gorm"
@@ -10,10 +13,16 @@ type CatRepository interface {
 	Create(cat *models.SpyCat) error
 	GetByID(id uint) (*models.SpyCat, error)
 	GetAll() ([]models.SpyCat, error)
+	List(filter models.CatFilter) ([]models.SpyCat, error)
+	Stream(filter models.CatFilter, fn func(cat *models.SpyCat) error) error
 	Update(cat *models.SpyCat) error
 	Delete(id uint) error
 	GetAvailable() ([]models.SpyCat, error)
 	SetAvailability(id uint, available bool) error
+	ListDeleted() ([]models.SpyCat, error)
+	GetDeleted(id uint) (*models.SpyCat, error)
+	Restore(id uint) error
+	PurgeDeleted(before time.Time) (int64, error)
 }
 
 type catRepository struct {
@@ -43,6 +52,41 @@ func (r *catRepository) GetAll() ([]models.SpyCat, error) {
 	return cats, err
 }
 
+func (r *catRepository) List(filter models.CatFilter) ([]models.SpyCat, error) {
+	var cats []models.SpyCat
+	err := r.listQuery(filter).Find(&cats).Error
+	return cats, err
+}
+
+// Stream calls fn for every cat matching filter, reading one row at a time
+func (r *catRepository) Stream(filter models.CatFilter, fn func(cat *models.SpyCat) error) error {
+	return streamRows(r.listQuery(filter).Model(&models.SpyCat{}), func(db *gorm.DB, rows *sql.Rows) error {
+		var cat models.SpyCat
+		if err := db.ScanRows(rows, &cat); err != nil {
+			return err
+		}
+		return fn(&cat)
+	})
+}
+
+func (r *catRepository) listQuery(filter models.CatFilter) *gorm.DB {
+	query := r.db
+	if filter.Skill != "" {
+		skilled := r.db.Model(&models.CatSkill{}).Select("cat_skills.cat_id").
+			Joins("JOIN skills ON skills.id = cat_skills.skill_id").
+			Where("LOWER(skills.name) = LOWER(?) AND cat_skills.level >= ?", filter.Skill, filter.MinLevel).
+			Where("cat_skills.expires_at IS NULL OR cat_skills.expires_at > ?", time.Now())
+		query = query.Where("id IN (?)", skilled)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	return query.Order("id")
+}
+
 func (r *catRepository) Update(cat *models.SpyCat) error {
 	return r.db.Save(cat).Error
 }
@@ -53,10 +97,58 @@ func (r *catRepository) Delete(id uint) error {
 
 func (r *catRepository) GetAvailable() ([]models.SpyCat, error) {
 	var cats []models.SpyCat
-	err := r.db.Where("is_available = ?", true).Find(&cats).Error
+	err := r.db.Where("is_available = ? AND status = ?", true, models.CatStatusActive).Find(&cats).Error
 	return cats, err
 }
 
 func (r *catRepository) SetAvailability(id uint, available bool) error {
 	return r.db.Model(&models.SpyCat{}).Where("id = ?", id).Update("is_available", available).Error
 }
+
+func (r *catRepository) ListDeleted() ([]models.SpyCat, error) {
+	var cats []models.SpyCat
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&cats).Error
+	return cats, err
+}
+
+func (r *catRepository) GetDeleted(id uint) (*models.SpyCat, error) {
+	var cat models.SpyCat
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&cat, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (r *catRepository) Restore(id uint) error {
+	return r.db.Unscoped().Model(&models.SpyCat{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// Cats still referenced by missions, targets, payslips or expenses are kept to preserve history
+func (r *catRepository) PurgeDeleted(before time.Time) (int64, error) {
+	var ids []uint
+	err := r.db.Unscoped().Model(&models.SpyCat{}).
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM missions WHERE missions.cat_id = spy_cats.id)").
+		Where("NOT EXISTS (SELECT 1 FROM mission_cats WHERE mission_cats.cat_id = spy_cats.id)").
+		Where("NOT EXISTS (SELECT 1 FROM targets WHERE targets.cat_id = spy_cats.id)").
+		Where("NOT EXISTS (SELECT 1 FROM payroll_entries WHERE payroll_entries.cat_id = spy_cats.id)").
+		Where("NOT EXISTS (SELECT 1 FROM expenses WHERE expenses.cat_id = spy_cats.id)").
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.CatSkill{}, &models.SalaryRecord{}, &models.AvailabilityEntry{}} {
+			if err := tx.Unscoped().Where("cat_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&models.SpyCat{}, ids).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CountryRepository interface {
+	GetUnmapped() ([]models.UnmappedCountry, error)
+}
+
+type countryRepository struct {
+	db *gorm.DB
+}
+
+func NewCountryRepository(db *gorm.DB) CountryRepository {
+	return &countryRepository{db: db}
+}
+
+// Rows keep an empty display name until their country is mapped to an ISO code
+func (r *countryRepository) GetUnmapped() ([]models.UnmappedCountry, error) {
+	unmapped := make([]models.UnmappedCountry, 0)
+	sources := []struct {
+		table  string
+		model  interface{}
+		column string
+	}{
+		{"targets", &models.Target{}, "country_name"},
+		{"hazard_pay_rates", &models.HazardPayRate{}, "country_name"},
+		{"dossier_countries", &models.DossierCountry{}, "name"},
+	}
+
+	for _, source := range sources {
+		var values []models.UnmappedCountry
+		err := r.db.Unscoped().Model(source.model).
+			Select("country AS value, COUNT(*) AS row_count").
+			Where(source.column + " = '' OR " + source.column + " IS NULL").
+			Group("country").Order("country").Scan(&values).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			value.Table = source.table
+			unmapped = append(unmapped, value)
+		}
+	}
+
+	return unmapped, nil
+}
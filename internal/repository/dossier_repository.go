@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DossierRepository interface {
+	Create(dossier *models.Dossier) error
+	GetByID(id uint) (*models.Dossier, error)
+	GetAll() ([]models.Dossier, error)
+	Update(dossier *models.Dossier) error
+	Delete(id uint) error
+	AddCountry(dossierID uint, country, name string) error
+	AddPhoto(photo *models.DossierPhoto) error
+	DeletePhoto(dossierID, photoID uint) (int64, error)
+	GetTargets(dossierID uint) ([]models.Target, error)
+}
+
+type dossierRepository struct {
+	db *gorm.DB
+}
+
+func NewDossierRepository(db *gorm.DB) DossierRepository {
+	return &dossierRepository{db: db}
+}
+
+func (r *dossierRepository) Create(dossier *models.Dossier) error {
+	return r.db.Create(dossier).Error
+}
+
+func (r *dossierRepository) GetByID(id uint) (*models.Dossier, error) {
+	var dossier models.Dossier
+	err := r.db.Preload("Aliases", ordered("alias")).
+		Preload("Countries", ordered("country")).
+		Preload("Photos", ordered("id")).
+		First(&dossier, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dossier, nil
+}
+
+func (r *dossierRepository) GetAll() ([]models.Dossier, error) {
+	var dossiers []models.Dossier
+	err := r.db.Preload("Aliases", ordered("alias")).
+		Preload("Countries", ordered("country")).
+		Order("name, id").Find(&dossiers).Error
+	return dossiers, err
+}
+
+// Aliases and countries are replaced wholesale with the ones on the dossier
+func (r *dossierRepository) Update(dossier *models.Dossier) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(dossier).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("dossier_id = ?", dossier.ID).Delete(&models.DossierAlias{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("dossier_id = ?", dossier.ID).Delete(&models.DossierCountry{}).Error; err != nil {
+			return err
+		}
+
+		for i := range dossier.Aliases {
+			dossier.Aliases[i].ID = 0
+			dossier.Aliases[i].DossierID = dossier.ID
+		}
+		for i := range dossier.Countries {
+			dossier.Countries[i].ID = 0
+			dossier.Countries[i].DossierID = dossier.ID
+		}
+
+		if len(dossier.Aliases) > 0 {
+			if err := tx.Create(&dossier.Aliases).Error; err != nil {
+				return err
+			}
+		}
+		if len(dossier.Countries) > 0 {
+			if err := tx.Create(&dossier.Countries).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Linked targets are released rather than deleted with the dossier
+func (r *dossierRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Target{}).Where("dossier_id = ?", id).Update("dossier_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Dossier{}, id).Error
+	})
+}
+
+func (r *dossierRepository) AddCountry(dossierID uint, country, name string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.DossierCountry{
+		DossierID: dossierID,
+		Country:   country,
+		Name:      name,
+	}).Error
+}
+
+func (r *dossierRepository) AddPhoto(photo *models.DossierPhoto) error {
+	return r.db.Create(photo).Error
+}
+
+func (r *dossierRepository) DeletePhoto(dossierID, photoID uint) (int64, error) {
+	result := r.db.Where("id = ? AND dossier_id = ?", photoID, dossierID).Delete(&models.DossierPhoto{})
+	return result.RowsAffected, result.Error
+}
+
+// Deleted targets and missions stay part of the person's history
+func (r *dossierRepository) GetTargets(dossierID uint) ([]models.Target, error) {
+	var targets []models.Target
+	err := r.db.Unscoped().
+		Preload("Mission", unscoped).
+		Preload("Cat", unscoped).
+		Where("dossier_id = ?", dossierID).
+		Order("mission_id, id").Find(&targets).Error
+	return targets, err
+}
+
+func ordered(column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(column)
+	}
+}
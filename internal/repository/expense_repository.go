@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ExpenseRepository interface {
+	Create(expense *models.Expense) error
+	GetByID(id uint) (*models.Expense, error)
+	GetByMissionID(missionID uint) ([]models.Expense, error)
+	Update(expense *models.Expense) error
+	Delete(id uint) error
+}
+
+type expenseRepository struct {
+	db *gorm.DB
+}
+
+func NewExpenseRepository(db *gorm.DB) ExpenseRepository {
+	return &expenseRepository{db: db}
+}
+
+func (r *expenseRepository) Create(expense *models.Expense) error {
+	return r.db.Create(expense).Error
+}
+
+func (r *expenseRepository) GetByID(id uint) (*models.Expense, error) {
+	var expense models.Expense
+	err := r.db.Preload("Cat", unscoped).First(&expense, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (r *expenseRepository) GetByMissionID(missionID uint) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Preload("Cat", unscoped).Where("mission_id = ?", missionID).Order("created_at, id").Find(&expenses).Error
+	return expenses, err
+}
+
+func (r *expenseRepository) Update(expense *models.Expense) error {
+	return r.db.Omit("Cat", "Mission").Save(expense).Error
+}
+
+func (r *expenseRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Expense{}, id).Error
+}
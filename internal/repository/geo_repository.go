@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"sort"
+
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/geo"
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	targetLatSQL = "COALESCE(last_seen_lat, latitude)"
+	targetLngSQL = "COALESCE(last_seen_lng, longitude)"
+)
+
+type GeoRepository interface {
+	UsesPostGIS() bool
+	FindInBox(box models.BoundingBox) ([]models.Target, error)
+	FindNear(lat, lng, radiusKm float64) ([]models.TargetDistance, error)
+}
+
+type geoRepository struct {
+	db      *gorm.DB
+	postgis bool
+}
+
+func NewGeoRepository(db *gorm.DB) GeoRepository {
+	return &geoRepository{db: db, postgis: database.HasPostGIS(db)}
+}
+
+func (r *geoRepository) UsesPostGIS() bool {
+	return r.postgis
+}
+
+// Targets are located by their last sighting, or their recorded coordinates if never seen
+func (r *geoRepository) FindInBox(box models.BoundingBox) ([]models.Target, error) {
+	var targets []models.Target
+	err := r.inBox(box).Order("mission_id, id").Find(&targets).Error
+	return targets, err
+}
+
+func (r *geoRepository) FindNear(lat, lng, radiusKm float64) ([]models.TargetDistance, error) {
+	if r.postgis {
+		return r.findNearPostGIS(lat, lng, radiusKm)
+	}
+
+	minLat, minLng, maxLat, maxLng := geo.Around(lat, lng, radiusKm)
+	var targets []models.Target
+	err := r.inBox(models.BoundingBox{MinLat: minLat, MinLng: minLng, MaxLat: maxLat, MaxLng: maxLng}).Find(&targets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.TargetDistance, 0, len(targets))
+	for _, target := range targets {
+		targetLat, targetLng, _ := models.TargetLocation(&target)
+		distance := geo.Distance(lat, lng, targetLat, targetLng)
+		if distance <= radiusKm {
+			result = append(result, models.TargetDistance{Target: target, DistanceKm: distance})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].DistanceKm < result[j].DistanceKm
+	})
+	return result, nil
+}
+
+func (r *geoRepository) findNearPostGIS(lat, lng, radiusKm float64) ([]models.TargetDistance, error) {
+	centre := "ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography"
+	var rows []struct {
+		ID         uint
+		DistanceKm float64
+	}
+	err := r.db.Model(&models.Target{}).
+		Select("id, ST_Distance("+database.TargetLocationSQL+", "+centre+") / 1000 AS distance_km", lng, lat).
+		Where(targetLatSQL+" IS NOT NULL AND "+targetLngSQL+" IS NOT NULL").
+		Where("ST_DWithin("+database.TargetLocationSQL+", "+centre+", ?)", lng, lat, radiusKm*1000).
+		Order("distance_km").Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return []models.TargetDistance{}, err
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var targets []models.Target
+	if err := r.db.Find(&targets, ids).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Target, len(targets))
+	for _, target := range targets {
+		byID[target.ID] = target
+	}
+
+	result := make([]models.TargetDistance, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, models.TargetDistance{Target: byID[row.ID], DistanceKm: row.DistanceKm})
+	}
+	return result, nil
+}
+
+// A box whose MinLng is greater than its MaxLng crosses the antimeridian
+func (r *geoRepository) inBox(box models.BoundingBox) *gorm.DB {
+	query := r.db.Where(targetLatSQL+" BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.MinLng <= box.MaxLng {
+		return query.Where(targetLngSQL+" BETWEEN ? AND ?", box.MinLng, box.MaxLng)
+	}
+	return query.Where(targetLngSQL+" >= ? OR "+targetLngSQL+" <= ?", box.MinLng, box.MaxLng)
+}
@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type catRepository struct {
+	store *Store
+}
+
+func NewCatRepository(store *Store) repository.CatRepository {
+	return &catRepository{store: store}
+}
+
+func (r *catRepository) Create(cat *models.SpyCat) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.createCat(cat)
+}
+
+// Columns with a default get it when left at their zero value, as GORM does
+func (s *Store) createCat(cat *models.SpyCat) error {
+	if _, ok := s.cats[cat.ID]; ok {
+		return duplicateKey("spy_cats", cat.ID)
+	}
+	cat.ID = s.nextID("spy_cats", cat.ID)
+	if !cat.IsAvailable {
+		cat.IsAvailable = true
+	}
+	if cat.Status == "" {
+		cat.Status = models.CatStatusActive
+	}
+	defaultMoney(&cat.Salary)
+	t := time.Now()
+	if cat.CreatedAt.IsZero() {
+		cat.CreatedAt = t
+	}
+	if cat.UpdatedAt.IsZero() {
+		cat.UpdatedAt = t
+	}
+	s.cats[cat.ID] = cloneCat(cat)
+	return nil
+}
+
+func (r *catRepository) GetByID(id uint) (*models.SpyCat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cat, ok := r.store.cats[id]
+	if !ok || deleted(cat.DeletedAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneCat(cat), nil
+}
+
+func (r *catRepository) GetAll() ([]models.SpyCat, error) {
+	return r.find(func(cat *models.SpyCat) bool { return true }), nil
+}
+
+func (r *catRepository) List(filter models.CatFilter) ([]models.SpyCat, error) {
+	now := time.Now()
+	cats := r.find(func(cat *models.SpyCat) bool {
+		if filter.Skill != "" && !r.store.hasSkill(cat.ID, filter.Skill, filter.MinLevel, now) {
+			return false
+		}
+		return filter.Status == "" || cat.Status == filter.Status
+	})
+	return page(cats, filter.Limit, filter.Offset), nil
+}
+
+// hasSkill reports whether the cat holds the skill, named in any case, at minLevel or above with a
+// certification that has not expired at now
+func (s *Store) hasSkill(catID uint, name string, minLevel int, now time.Time) bool {
+	for _, catSkill := range s.catSkills {
+		if catSkill.CatID != catID || catSkill.Level < minLevel {
+			continue
+		}
+		if catSkill.ExpiresAt != nil && !catSkill.ExpiresAt.After(now) {
+			continue
+		}
+		if skill, ok := s.skills[catSkill.SkillID]; ok && strings.EqualFold(skill.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stream works on a snapshot, so fn may use the repositories
+func (r *catRepository) Stream(filter models.CatFilter, fn func(cat *models.SpyCat) error) error {
+	cats, err := r.List(filter)
+	if err != nil {
+		return err
+	}
+	for i := range cats {
+		if err := fn(&cats[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// find returns the cats that are not deleted and match, ordered by ID
+func (r *catRepository) find(match func(cat *models.SpyCat) bool) []models.SpyCat {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cats := []models.SpyCat{}
+	for _, cat := range r.store.cats {
+		if !deleted(cat.DeletedAt) && match(cat) {
+			cats = append(cats, *cloneCat(cat))
+		}
+	}
+	sort.Slice(cats, func(i, j int) bool { return cats[i].ID < cats[j].ID })
+	return cats
+}
+
+// Update saves every field, or creates the cat when it has no ID yet, like GORM's Save
+func (r *catRepository) Update(cat *models.SpyCat) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.cats[cat.ID]; !ok {
+		return r.store.createCat(cat)
+	}
+	cat.UpdatedAt = time.Now()
+	r.store.cats[cat.ID] = cloneCat(cat)
+	return nil
+}
+
+func (r *catRepository) Delete(id uint) error {
+	return r.update(id, func(cat *models.SpyCat) {
+		cat.DeletedAt = softDelete()
+	})
+}
+
+func (r *catRepository) GetAvailable() ([]models.SpyCat, error) {
+	return r.find(func(cat *models.SpyCat) bool {
+		return cat.IsAvailable && cat.Status == models.CatStatusActive
+	}), nil
+}
+
+func (r *catRepository) SetAvailability(id uint, available bool) error {
+	return r.update(id, func(cat *models.SpyCat) {
+		cat.IsAvailable = available
+		cat.UpdatedAt = time.Now()
+	})
+}
+
+// update changes a cat that is not deleted; like an UPDATE matching no rows, a missing cat is no error
+func (r *catRepository) update(id uint, change func(cat *models.SpyCat)) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if cat, ok := r.store.cats[id]; ok && !deleted(cat.DeletedAt) {
+		change(cat)
+	}
+	return nil
+}
+
+func (r *catRepository) ListDeleted() ([]models.SpyCat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cats := []models.SpyCat{}
+	for _, cat := range r.store.cats {
+		if deleted(cat.DeletedAt) {
+			cats = append(cats, *cloneCat(cat))
+		}
+	}
+	sort.Slice(cats, func(i, j int) bool { return cats[i].DeletedAt.Time.After(cats[j].DeletedAt.Time) })
+	return cats, nil
+}
+
+func (r *catRepository) GetDeleted(id uint) (*models.SpyCat, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cat, ok := r.store.cats[id]
+	if !ok || !deleted(cat.DeletedAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneCat(cat), nil
+}
+
+func (r *catRepository) Restore(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if cat, ok := r.store.cats[id]; ok {
+		cat.DeletedAt = gorm.DeletedAt{}
+		cat.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// Cats still referenced by missions or targets are kept, deleted ones included
+func (r *catRepository) PurgeDeleted(before time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	referenced := make(map[uint]bool)
+	for _, mission := range r.store.missions {
+		if mission.CatID != nil {
+			referenced[*mission.CatID] = true
+		}
+	}
+	for _, members := range r.store.members {
+		for _, member := range members {
+			referenced[member.CatID] = true
+		}
+	}
+	for _, target := range r.store.targets {
+		if target.CatID != nil {
+			referenced[*target.CatID] = true
+		}
+	}
+
+	var purged int64
+	for id, cat := range r.store.cats {
+		if deleted(cat.DeletedAt) && cat.DeletedAt.Time.Before(before) && !referenced[id] {
+			for catSkillID, catSkill := range r.store.catSkills {
+				if catSkill.CatID == id {
+					delete(r.store.catSkills, catSkillID)
+				}
+			}
+			delete(r.store.cats, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
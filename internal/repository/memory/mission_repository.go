@@ -0,0 +1,425 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type missionRepository struct {
+	store *Store
+}
+
+func NewMissionRepository(store *Store) repository.MissionRepository {
+	return &missionRepository{store: store}
+}
+
+// Create also creates the mission's new targets and required skills, as GORM does
+func (r *missionRepository) Create(mission *models.Mission) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.create(mission)
+}
+
+func (r *missionRepository) create(mission *models.Mission) error {
+	if _, ok := r.store.missions[mission.ID]; ok {
+		return duplicateKey("missions", mission.ID)
+	}
+	mission.ID = r.store.nextID("missions", mission.ID)
+	defaultMoney(&mission.Budget)
+	t := time.Now()
+	if mission.CreatedAt.IsZero() {
+		mission.CreatedAt = t
+	}
+	if mission.UpdatedAt.IsZero() {
+		mission.UpdatedAt = t
+	}
+	return r.save(mission)
+}
+
+// Update saves every field and creates targets and required skills that have no ID yet, like GORM's Save
+func (r *missionRepository) Update(mission *models.Mission) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.missions[mission.ID]; !ok {
+		return r.create(mission)
+	}
+	mission.UpdatedAt = time.Now()
+	return r.save(mission)
+}
+
+// save stores the mission with its associations; a loaded cat sets the cat ID, as a belongs-to does in GORM
+func (r *missionRepository) save(mission *models.Mission) error {
+	if mission.Cat != nil && mission.Cat.ID != 0 {
+		catID := mission.Cat.ID
+		mission.CatID = &catID
+	}
+	for i := range mission.Targets {
+		target := &mission.Targets[i]
+		target.MissionID = mission.ID
+		if stored, ok := r.store.targets[target.ID]; ok {
+			stored.MissionID = mission.ID
+			continue
+		}
+		if err := r.store.createTarget(target); err != nil {
+			return err
+		}
+	}
+
+	requirements := r.store.requirements[mission.ID]
+	for i := range mission.RequiredSkills {
+		requirement := &mission.RequiredSkills[i]
+		requirement.MissionID = mission.ID
+		if requirement.ID != 0 && hasRequirement(requirements, requirement.ID) {
+			continue
+		}
+		requirement.ID = r.store.nextID("mission_skill_requirements", requirement.ID)
+		requirements = append(requirements, cloneRequirements([]models.MissionSkillRequirement{*requirement})...)
+	}
+	r.store.requirements[mission.ID] = requirements
+
+	r.store.missions[mission.ID] = cloneMission(mission)
+	return nil
+}
+
+func hasRequirement(requirements []models.MissionSkillRequirement, id uint) bool {
+	for _, requirement := range requirements {
+		if requirement.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	mission, ok := r.store.missions[id]
+	if !ok || deleted(mission.DeletedAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.store.loadMission(mission, true), nil
+}
+
+// loadMission copies a mission with its targets, required skills, members and, if withCat, the cats.
+// Retired and deleted cats still show up on the missions they worked.
+func (s *Store) loadMission(mission *models.Mission, withCat bool) *models.Mission {
+	m := cloneMission(mission)
+	if withCat && m.CatID != nil {
+		if cat, ok := s.cats[*m.CatID]; ok {
+			m.Cat = cloneCat(cat)
+		}
+	}
+	m.Targets = s.missionTargets(m.ID)
+	m.RequiredSkills = s.loadRequirements(m.ID)
+	m.Members = make([]models.MissionCat, len(s.members[m.ID]))
+	for i, member := range s.members[m.ID] {
+		m.Members[i] = member
+		if cat, ok := s.cats[member.CatID]; ok && withCat {
+			m.Members[i].Cat = cloneCat(cat)
+		}
+	}
+	return m
+}
+
+func (s *Store) isMember(missionID, catID uint) bool {
+	for _, member := range s.members[missionID] {
+		if member.CatID == catID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) missionTargets(missionID uint) []models.Target {
+	targets := []models.Target{}
+	for _, target := range s.targets {
+		if target.MissionID == missionID && !deleted(target.DeletedAt) {
+			targets = append(targets, *cloneTarget(target))
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].ID < targets[j].ID })
+	return targets
+}
+
+func (r *missionRepository) GetAll() ([]models.Mission, error) {
+	return r.find(func(mission *models.Mission) bool { return true }, true), nil
+}
+
+func (r *missionRepository) List(filter models.MissionFilter) ([]models.Mission, error) {
+	missions := r.find(listFilter(filter), true)
+	return page(missions, filter.Limit, filter.Offset), nil
+}
+
+// Stream works on a snapshot, so fn may use the repositories; associations are not loaded
+func (r *missionRepository) Stream(filter models.MissionFilter, fn func(mission *models.Mission) error) error {
+	missions := page(r.find(listFilter(filter), false), filter.Limit, filter.Offset)
+	for i := range missions {
+		if err := fn(&missions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func listFilter(filter models.MissionFilter) func(mission *models.Mission) bool {
+	return func(mission *models.Mission) bool {
+		if filter.Overdue != nil && mission.IsOverdue != *filter.Overdue {
+			return false
+		}
+		if filter.DueBefore != nil && (mission.DeadlineAt == nil || !mission.DeadlineAt.Before(*filter.DueBefore)) {
+			return false
+		}
+		switch filter.State {
+		case models.MissionStateCompleted, models.MissionStateOverdue, models.MissionStateActive, models.MissionStateUnassigned:
+			return models.MissionState(mission) == filter.State
+		}
+		return true
+	}
+}
+
+// find returns the missions that are not deleted and match, ordered by ID, with associations if load is set
+func (r *missionRepository) find(match func(mission *models.Mission) bool, load bool) []models.Mission {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	missions := []models.Mission{}
+	for _, mission := range r.store.missions {
+		if deleted(mission.DeletedAt) || !match(mission) {
+			continue
+		}
+		if load {
+			missions = append(missions, *r.store.loadMission(mission, true))
+		} else {
+			missions = append(missions, *cloneMission(mission))
+		}
+	}
+	sort.Slice(missions, func(i, j int) bool { return missions[i].ID < missions[j].ID })
+	return missions
+}
+
+func (r *missionRepository) Delete(id uint) error {
+	return r.update(id, func(mission *models.Mission) {
+		mission.DeletedAt = softDelete()
+	})
+}
+
+// GetByCatID returns the cat's open mission with the lowest ID
+func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
+	missions := r.find(r.openFor(catID), true)
+	if len(missions) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &missions[0], nil
+}
+
+func (r *missionRepository) GetActiveByCatID(catID uint) ([]models.Mission, error) {
+	missions := r.find(r.openFor(catID), true)
+	for i := range missions {
+		missions[i].Cat = nil
+	}
+	return missions, nil
+}
+
+// openFor matches the cat's open missions, whether it leads them or is a member
+func (r *missionRepository) openFor(catID uint) func(mission *models.Mission) bool {
+	return func(mission *models.Mission) bool {
+		if mission.IsCompleted {
+			return false
+		}
+		return (mission.CatID != nil && *mission.CatID == catID) || r.store.isMember(mission.ID, catID)
+	}
+}
+
+func (r *missionRepository) AssignCat(missionID, catID uint) error {
+	return r.update(missionID, func(mission *models.Mission) {
+		mission.CatID = &catID
+	})
+}
+
+func (r *missionRepository) UnassignCat(missionID uint) error {
+	return r.update(missionID, func(mission *models.Mission) {
+		mission.CatID = nil
+	})
+}
+
+func (r *missionRepository) CompleteMission(missionID uint) error {
+	return r.update(missionID, func(mission *models.Mission) {
+		endedAt := time.Now()
+		mission.IsCompleted = true
+		mission.EndedAt = &endedAt
+	})
+}
+
+func (r *missionRepository) MarkStarted(missionID uint, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if mission, ok := r.store.missions[missionID]; ok && !deleted(mission.DeletedAt) && mission.StartedAt == nil {
+		mission.StartedAt = &at
+		mission.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *missionRepository) MarkOverdue(now time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var flagged int64
+	for _, mission := range r.store.missions {
+		if deleted(mission.DeletedAt) || mission.IsCompleted || mission.IsOverdue {
+			continue
+		}
+		if mission.DeadlineAt != nil && mission.DeadlineAt.Before(now) {
+			mission.IsOverdue = true
+			mission.UpdatedAt = time.Now()
+			flagged++
+		}
+	}
+	return flagged, nil
+}
+
+func (r *missionRepository) GetCompletedBetween(from, to time.Time) ([]models.Mission, error) {
+	missions := r.find(func(mission *models.Mission) bool {
+		return mission.IsCompleted && mission.EndedAt != nil && !mission.EndedAt.Before(from) && mission.EndedAt.Before(to)
+	}, true)
+	for i := range missions {
+		missions[i].Cat = nil
+		missions[i].RequiredSkills = nil
+		for j := range missions[i].Members {
+			missions[i].Members[j].Cat = nil
+		}
+	}
+	sort.SliceStable(missions, func(i, j int) bool { return missions[i].EndedAt.Before(*missions[j].EndedAt) })
+	return missions, nil
+}
+
+func (r *missionRepository) SetBudget(missionID uint, budget money.Money) error {
+	return r.update(missionID, func(mission *models.Mission) {
+		mission.Budget = budget
+	})
+}
+
+func (r *missionRepository) AddMember(missionID, catID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.isMember(missionID, catID) {
+		return fmt.Errorf("duplicate key: cat %d is already a member of mission %d", catID, missionID)
+	}
+	member := models.MissionCat{
+		ID:        r.store.nextID("mission_cats", 0),
+		MissionID: missionID,
+		CatID:     catID,
+		CreatedAt: time.Now(),
+	}
+	r.store.members[missionID] = append(r.store.members[missionID], member)
+	return nil
+}
+
+func (r *missionRepository) RemoveMember(missionID, catID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	members := r.store.members[missionID][:0]
+	for _, member := range r.store.members[missionID] {
+		if member.CatID != catID {
+			members = append(members, member)
+		}
+	}
+	r.store.members[missionID] = members
+	return nil
+}
+
+// update changes a mission that is not deleted and touches its UpdatedAt; a missing mission is no error
+func (r *missionRepository) update(id uint, change func(mission *models.Mission)) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if mission, ok := r.store.missions[id]; ok && !deleted(mission.DeletedAt) {
+		change(mission)
+		if !deleted(mission.DeletedAt) {
+			mission.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *missionRepository) ListDeleted() ([]models.Mission, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	missions := []models.Mission{}
+	for _, mission := range r.store.missions {
+		if deleted(mission.DeletedAt) {
+			m := r.store.loadMission(mission, true)
+			m.RequiredSkills = nil
+			m.Members = nil
+			missions = append(missions, *m)
+		}
+	}
+	sort.Slice(missions, func(i, j int) bool { return missions[i].DeletedAt.Time.After(missions[j].DeletedAt.Time) })
+	return missions, nil
+}
+
+func (r *missionRepository) GetDeleted(id uint) (*models.Mission, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	mission, ok := r.store.missions[id]
+	if !ok || !deleted(mission.DeletedAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	m := r.store.loadMission(mission, true)
+	m.RequiredSkills = nil
+	m.Members = nil
+	return m, nil
+}
+
+func (r *missionRepository) Restore(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if mission, ok := r.store.missions[id]; ok {
+		mission.DeletedAt = gorm.DeletedAt{}
+		mission.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// Purging a mission also removes its targets, their notes, its required skills and members
+func (r *missionRepository) PurgeDeleted(before time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var purged int64
+	for id, mission := range r.store.missions {
+		if !deleted(mission.DeletedAt) || !mission.DeletedAt.Time.Before(before) {
+			continue
+		}
+		for noteID, note := range r.store.notes {
+			if note.MissionID == id {
+				delete(r.store.notes, noteID)
+			}
+		}
+		for targetID, target := range r.store.targets {
+			if target.MissionID == id {
+				delete(r.store.targets, targetID)
+			}
+		}
+		delete(r.store.requirements, id)
+		delete(r.store.members, id)
+		delete(r.store.missions, id)
+		purged++
+	}
+	return purged, nil
+}
@@ -0,0 +1,262 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type skillRepository struct {
+	store *Store
+}
+
+func NewSkillRepository(store *Store) repository.SkillRepository {
+	return &skillRepository{store: store}
+}
+
+func (r *skillRepository) Create(skill *models.Skill) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.skills[skill.ID]; ok {
+		return duplicateKey("skills", skill.ID)
+	}
+	if r.store.skillNamed(skill.Name, 0) {
+		return fmt.Errorf("duplicate key: skill %q already exists", skill.Name)
+	}
+	skill.ID = r.store.nextID("skills", skill.ID)
+	t := time.Now()
+	if skill.CreatedAt.IsZero() {
+		skill.CreatedAt = t
+	}
+	if skill.UpdatedAt.IsZero() {
+		skill.UpdatedAt = t
+	}
+	stored := *skill
+	r.store.skills[skill.ID] = &stored
+	return nil
+}
+
+// skillNamed reports whether a skill other than except has the name, which is unique like idx_skills_name
+func (s *Store) skillNamed(name string, except uint) bool {
+	for id, skill := range s.skills {
+		if id != except && skill.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *skillRepository) GetByID(id uint) (*models.Skill, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	skill, ok := r.store.skills[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return clonePtr(skill), nil
+}
+
+func (r *skillRepository) GetAll() ([]models.Skill, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	skills := []models.Skill{}
+	for _, skill := range r.store.skills {
+		skills = append(skills, *skill)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Name < skills[j].Name })
+	return skills, nil
+}
+
+// Update saves every field, or creates the skill when it has no ID yet, like GORM's Save
+func (r *skillRepository) Update(skill *models.Skill) error {
+	r.store.mu.Lock()
+	if _, ok := r.store.skills[skill.ID]; !ok {
+		r.store.mu.Unlock()
+		return r.Create(skill)
+	}
+	defer r.store.mu.Unlock()
+
+	if r.store.skillNamed(skill.Name, skill.ID) {
+		return fmt.Errorf("duplicate key: skill %q already exists", skill.Name)
+	}
+	skill.UpdatedAt = time.Now()
+	stored := *skill
+	r.store.skills[skill.ID] = &stored
+	return nil
+}
+
+func (r *skillRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.skills, id)
+	return nil
+}
+
+func (r *skillRepository) CountUsage(id uint) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, catSkill := range r.store.catSkills {
+		if catSkill.SkillID == id {
+			count++
+		}
+	}
+	for _, requirements := range r.store.requirements {
+		for _, requirement := range requirements {
+			if requirement.SkillID == id {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (r *skillRepository) AddCatSkill(catSkill *models.CatSkill) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.catSkills[catSkill.ID]; ok {
+		return duplicateKey("cat_skills", catSkill.ID)
+	}
+	if r.store.findCatSkill(catSkill.CatID, catSkill.SkillID) != nil {
+		return fmt.Errorf("duplicate key: cat %d already has skill %d", catSkill.CatID, catSkill.SkillID)
+	}
+	catSkill.ID = r.store.nextID("cat_skills", catSkill.ID)
+	t := time.Now()
+	if catSkill.CreatedAt.IsZero() {
+		catSkill.CreatedAt = t
+	}
+	if catSkill.UpdatedAt.IsZero() {
+		catSkill.UpdatedAt = t
+	}
+	r.store.catSkills[catSkill.ID] = cloneCatSkill(catSkill)
+	return nil
+}
+
+func (s *Store) findCatSkill(catID, skillID uint) *models.CatSkill {
+	for _, catSkill := range s.catSkills {
+		if catSkill.CatID == catID && catSkill.SkillID == skillID {
+			return catSkill
+		}
+	}
+	return nil
+}
+
+// loadCatSkill copies a cat skill with its skill and, if withCat, its cat unless that cat is deleted
+func (s *Store) loadCatSkill(catSkill *models.CatSkill, withCat bool) models.CatSkill {
+	c := *cloneCatSkill(catSkill)
+	if skill, ok := s.skills[c.SkillID]; ok {
+		c.Skill = clonePtr(skill)
+	}
+	if cat, ok := s.cats[c.CatID]; ok && withCat && !deleted(cat.DeletedAt) {
+		c.Cat = cloneCat(cat)
+	}
+	return c
+}
+
+func (r *skillRepository) GetCatSkill(catID, skillID uint) (*models.CatSkill, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	catSkill := r.store.findCatSkill(catID, skillID)
+	if catSkill == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	loaded := r.store.loadCatSkill(catSkill, false)
+	return &loaded, nil
+}
+
+func (r *skillRepository) GetCatSkills(catID uint) ([]models.CatSkill, error) {
+	return r.findCatSkills(func(catSkill *models.CatSkill) bool { return catSkill.CatID == catID }, false,
+		func(a, b *models.CatSkill) bool { return a.SkillID < b.SkillID }), nil
+}
+
+// UpdateCatSkill saves every field, or adds the cat skill when it has no ID yet, like GORM's Save
+func (r *skillRepository) UpdateCatSkill(catSkill *models.CatSkill) error {
+	r.store.mu.Lock()
+	if _, ok := r.store.catSkills[catSkill.ID]; !ok {
+		r.store.mu.Unlock()
+		return r.AddCatSkill(catSkill)
+	}
+	defer r.store.mu.Unlock()
+
+	catSkill.UpdatedAt = time.Now()
+	r.store.catSkills[catSkill.ID] = cloneCatSkill(catSkill)
+	return nil
+}
+
+func (r *skillRepository) DeleteCatSkill(catID, skillID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if catSkill := r.store.findCatSkill(catID, skillID); catSkill != nil {
+		delete(r.store.catSkills, catSkill.ID)
+	}
+	return nil
+}
+
+func (r *skillRepository) GetExpiringCatSkills(from, to time.Time) ([]models.CatSkill, error) {
+	return r.findCatSkills(func(catSkill *models.CatSkill) bool {
+		return catSkill.ExpiresAt != nil && !catSkill.ExpiresAt.Before(from) && catSkill.ExpiresAt.Before(to)
+	}, true, func(a, b *models.CatSkill) bool { return a.ExpiresAt.Before(*b.ExpiresAt) }), nil
+}
+
+func (r *skillRepository) findCatSkills(match func(catSkill *models.CatSkill) bool, withCat bool, less func(a, b *models.CatSkill) bool) []models.CatSkill {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	catSkills := []models.CatSkill{}
+	for _, catSkill := range r.store.catSkills {
+		if match(catSkill) {
+			catSkills = append(catSkills, r.store.loadCatSkill(catSkill, withCat))
+		}
+	}
+	sort.Slice(catSkills, func(i, j int) bool { return less(&catSkills[i], &catSkills[j]) })
+	return catSkills
+}
+
+func (r *skillRepository) GetMissionRequirements(missionID uint) ([]models.MissionSkillRequirement, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.loadRequirements(missionID), nil
+}
+
+func (r *skillRepository) SetMissionRequirements(missionID uint, requirements []models.MissionSkillRequirement) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := make([]models.MissionSkillRequirement, 0, len(requirements))
+	for i := range requirements {
+		requirements[i].MissionID = missionID
+		requirements[i].ID = r.store.nextID("mission_skill_requirements", 0)
+		stored = append(stored, cloneRequirements(requirements[i:i+1])...)
+	}
+	if len(stored) == 0 {
+		delete(r.store.requirements, missionID)
+		return nil
+	}
+	r.store.requirements[missionID] = stored
+	return nil
+}
+
+// loadRequirements copies a mission's required skills with their skills, when the store has them
+func (s *Store) loadRequirements(missionID uint) []models.MissionSkillRequirement {
+	requirements := cloneRequirements(s.requirements[missionID])
+	for i := range requirements {
+		if skill, ok := s.skills[requirements[i].SkillID]; ok {
+			requirements[i].Skill = clonePtr(skill)
+		}
+	}
+	return requirements
+}
@@ -0,0 +1,165 @@
+// Package memory implements the cat, mission, target and skill repositories on maps instead of a
+// database, for tests that exercise services without Postgres or SQLite.
+//
+// The repositories behave like the GORM ones, which repotest checks for both: IDs are assigned in
+// order and never reused, column defaults are filled in on create, deleted records are only visible
+// to the trash methods, missing records are reported as gorm.ErrRecordNotFound, and associations are
+// loaded and saved as GORM does. What the store does not hold is out of reach: purging cats only checks
+// missions, their members and targets for references, not payslips or expenses.
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+
+	"gorm.io/gorm"
+)
+
+// Store holds the records of all the repositories, which refer to each other like tables do.
+// It is safe for concurrent use.
+type Store struct {
+	mu           sync.RWMutex
+	cats         map[uint]*models.SpyCat
+	missions     map[uint]*models.Mission
+	targets      map[uint]*models.Target
+	notes        map[uint]*models.TargetNote
+	requirements map[uint][]models.MissionSkillRequirement
+	members      map[uint][]models.MissionCat
+	skills       map[uint]*models.Skill
+	catSkills    map[uint]*models.CatSkill
+	lastID       map[string]uint
+}
+
+func NewStore() *Store {
+	return &Store{
+		cats:         make(map[uint]*models.SpyCat),
+		missions:     make(map[uint]*models.Mission),
+		targets:      make(map[uint]*models.Target),
+		notes:        make(map[uint]*models.TargetNote),
+		requirements: make(map[uint][]models.MissionSkillRequirement),
+		members:      make(map[uint][]models.MissionCat),
+		skills:       make(map[uint]*models.Skill),
+		catSkills:    make(map[uint]*models.CatSkill),
+		lastID:       make(map[string]uint),
+	}
+}
+
+// nextID works like an autoincrement column: an explicit ID is kept and moves the counter past it
+func (s *Store) nextID(table string, id uint) uint {
+	if id == 0 {
+		id = s.lastID[table] + 1
+	}
+	if id > s.lastID[table] {
+		s.lastID[table] = id
+	}
+	return id
+}
+
+func duplicateKey(table string, id uint) error {
+	return fmt.Errorf("duplicate key: %s %d already exists", table, id)
+}
+
+func deleted(at gorm.DeletedAt) bool {
+	return at.Valid
+}
+
+func softDelete() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+func defaultMoney(m *money.Money) {
+	if m.Currency == "" {
+		m.Currency = "USD"
+	}
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneCat(cat *models.SpyCat) *models.SpyCat {
+	c := *cat
+	c.StatusChangedAt = clonePtr(cat.StatusChangedAt)
+	return &c
+}
+
+// cloneMission copies the mission's own columns; associations are loaded separately
+func cloneMission(mission *models.Mission) *models.Mission {
+	m := *mission
+	m.CatID = clonePtr(mission.CatID)
+	m.PlannedStartAt = clonePtr(mission.PlannedStartAt)
+	m.DeadlineAt = clonePtr(mission.DeadlineAt)
+	m.StartedAt = clonePtr(mission.StartedAt)
+	m.EndedAt = clonePtr(mission.EndedAt)
+	m.Cat = nil
+	m.Targets = nil
+	m.RequiredSkills = nil
+	m.Members = nil
+	return &m
+}
+
+func cloneTarget(target *models.Target) *models.Target {
+	t := *target
+	t.NotesPurgedAt = clonePtr(target.NotesPurgedAt)
+	t.PseudonymizedAt = clonePtr(target.PseudonymizedAt)
+	t.CatID = clonePtr(target.CatID)
+	t.DossierID = clonePtr(target.DossierID)
+	t.Latitude = clonePtr(target.Latitude)
+	t.Longitude = clonePtr(target.Longitude)
+	t.LastSeenLat = clonePtr(target.LastSeenLat)
+	t.LastSeenLng = clonePtr(target.LastSeenLng)
+	t.LastSeenAt = clonePtr(target.LastSeenAt)
+	t.DeadlineAt = clonePtr(target.DeadlineAt)
+	t.Mission = nil
+	t.Cat = nil
+	return &t
+}
+
+func cloneNote(note *models.TargetNote) *models.TargetNote {
+	n := *note
+	n.CatID = clonePtr(note.CatID)
+	n.Latitude = clonePtr(note.Latitude)
+	n.Longitude = clonePtr(note.Longitude)
+	return &n
+}
+
+func cloneCatSkill(catSkill *models.CatSkill) *models.CatSkill {
+	c := *catSkill
+	c.CertifiedAt = clonePtr(catSkill.CertifiedAt)
+	c.ExpiresAt = clonePtr(catSkill.ExpiresAt)
+	c.Cat = nil
+	c.Skill = nil
+	return &c
+}
+
+func cloneRequirements(requirements []models.MissionSkillRequirement) []models.MissionSkillRequirement {
+	cloned := make([]models.MissionSkillRequirement, len(requirements))
+	for i, requirement := range requirements {
+		cloned[i] = requirement
+		cloned[i].Skill = clonePtr(requirement.Skill)
+	}
+	return cloned
+}
+
+// page applies a limit and offset the way SQL does; an offset without a limit is ignored, as in listQuery
+func page[T any](items []T, limit, offset int) []T {
+	if limit <= 0 {
+		return items
+	}
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
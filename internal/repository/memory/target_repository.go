@@ -0,0 +1,348 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type targetRepository struct {
+	store *Store
+}
+
+func NewTargetRepository(store *Store) repository.TargetRepository {
+	return &targetRepository{store: store}
+}
+
+func (r *targetRepository) Create(target *models.Target) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.createTarget(target)
+}
+
+func (s *Store) createTarget(target *models.Target) error {
+	if _, ok := s.targets[target.ID]; ok {
+		return duplicateKey("targets", target.ID)
+	}
+	target.ID = s.nextID("targets", target.ID)
+	if target.Classification == "" {
+		target.Classification = models.ClassificationConfidential
+	}
+	t := time.Now()
+	if target.CreatedAt.IsZero() {
+		target.CreatedAt = t
+	}
+	if target.UpdatedAt.IsZero() {
+		target.UpdatedAt = t
+	}
+	s.targets[target.ID] = cloneTarget(target)
+	return nil
+}
+
+// GetByID loads the target's mission unless that mission is deleted
+func (r *targetRepository) GetByID(id uint) (*models.Target, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	target, ok := r.store.targets[id]
+	if !ok || deleted(target.DeletedAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.store.loadTarget(target), nil
+}
+
+func (s *Store) loadTarget(target *models.Target) *models.Target {
+	t := cloneTarget(target)
+	if mission, ok := s.missions[t.MissionID]; ok && !deleted(mission.DeletedAt) {
+		t.Mission = cloneMission(mission)
+	}
+	return t
+}
+
+func (r *targetRepository) GetByMissionID(missionID uint) ([]models.Target, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.missionTargets(missionID), nil
+}
+
+// Update saves every field, or creates the target when it has no ID yet, like GORM's Save
+func (r *targetRepository) Update(target *models.Target) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if target.Mission != nil && target.Mission.ID != 0 {
+		target.MissionID = target.Mission.ID
+	}
+	if target.Cat != nil && target.Cat.ID != 0 {
+		catID := target.Cat.ID
+		target.CatID = &catID
+	}
+	if _, ok := r.store.targets[target.ID]; !ok {
+		return r.store.createTarget(target)
+	}
+	target.UpdatedAt = time.Now()
+	r.store.targets[target.ID] = cloneTarget(target)
+	return nil
+}
+
+func (r *targetRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if target, ok := r.store.targets[id]; ok && !deleted(target.DeletedAt) {
+		target.DeletedAt = softDelete()
+	}
+	return nil
+}
+
+func (r *targetRepository) CompleteTarget(id uint) error {
+	return r.update(id, func(target *models.Target) {
+		target.IsCompleted = true
+	})
+}
+
+func (r *targetRepository) UpdateNotes(id uint, notes string) error {
+	return r.update(id, func(target *models.Target) {
+		target.Notes = notes
+	})
+}
+
+func (r *targetRepository) CountByMissionID(missionID uint) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return int64(len(r.store.missionTargets(missionID))), nil
+}
+
+func (r *targetRepository) AssignCat(id uint, catID *uint) error {
+	return r.update(id, func(target *models.Target) {
+		target.CatID = clonePtr(catID)
+	})
+}
+
+func (r *targetRepository) SetDossier(id uint, dossierID *uint) error {
+	return r.update(id, func(target *models.Target) {
+		target.DossierID = clonePtr(dossierID)
+	})
+}
+
+func (r *targetRepository) GetByCatID(catID uint) ([]models.Target, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	targets := r.store.findTargets(models.TargetFilter{CatID: catID})
+	for i := range targets {
+		targets[i] = *r.store.loadTarget(&targets[i])
+	}
+	return targets, nil
+}
+
+// Stream works on a snapshot, so fn may use the repositories
+func (r *targetRepository) Stream(filter models.TargetFilter, fn func(target *models.Target) error) error {
+	r.store.mu.RLock()
+	targets := r.store.findTargets(filter)
+	r.store.mu.RUnlock()
+
+	for i := range targets {
+		if err := fn(&targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findTargets returns the targets that are not deleted and match filter, ordered by mission and ID
+func (s *Store) findTargets(filter models.TargetFilter) []models.Target {
+	targets := []models.Target{}
+	for _, target := range s.targets {
+		if deleted(target.DeletedAt) {
+			continue
+		}
+		if filter.MissionID != 0 && target.MissionID != filter.MissionID {
+			continue
+		}
+		if filter.CatID != 0 && (target.CatID == nil || *target.CatID != filter.CatID) {
+			continue
+		}
+		targets = append(targets, *cloneTarget(target))
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].MissionID != targets[j].MissionID {
+			return targets[i].MissionID < targets[j].MissionID
+		}
+		return targets[i].ID < targets[j].ID
+	})
+	return targets
+}
+
+func (r *targetRepository) ClearCatAssignments(missionID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, target := range r.store.targets {
+		if target.MissionID == missionID && !deleted(target.DeletedAt) {
+			target.CatID = nil
+			target.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *targetRepository) UnassignCat(missionID, catID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, target := range r.store.targets {
+		if target.MissionID == missionID && target.CatID != nil && *target.CatID == catID && !deleted(target.DeletedAt) {
+			target.CatID = nil
+			target.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+// update changes a target that is not deleted and touches its UpdatedAt; a missing target is no error
+func (r *targetRepository) update(id uint, change func(target *models.Target)) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if target, ok := r.store.targets[id]; ok && !deleted(target.DeletedAt) {
+		change(target)
+		target.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *targetRepository) CreateNote(note *models.TargetNote) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.notes[note.ID]; ok {
+		return duplicateKey("target_notes", note.ID)
+	}
+	note.ID = r.store.nextID("target_notes", note.ID)
+	if note.CreatedAt.IsZero() {
+		note.CreatedAt = time.Now()
+	}
+	r.store.notes[note.ID] = cloneNote(note)
+	return nil
+}
+
+func (r *targetRepository) GetNotes(targetID uint) ([]models.TargetNote, error) {
+	return r.findNotes(func(note *models.TargetNote) bool {
+		return note.TargetID == targetID
+	}, false), nil
+}
+
+// Sightings are the note entries that recorded a location, in the order they were seen
+func (r *targetRepository) GetSightings(missionIDs []uint) ([]models.TargetNote, error) {
+	if len(missionIDs) == 0 {
+		return nil, nil
+	}
+	missions := make(map[uint]bool, len(missionIDs))
+	for _, id := range missionIDs {
+		missions[id] = true
+	}
+	return r.findNotes(func(note *models.TargetNote) bool {
+		return missions[note.MissionID] && note.Latitude != nil && note.Longitude != nil
+	}, true), nil
+}
+
+// findNotes orders notes by when they were seen, first per target if byTarget is set
+func (r *targetRepository) findNotes(match func(note *models.TargetNote) bool, byTarget bool) []models.TargetNote {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	notes := []models.TargetNote{}
+	for _, note := range r.store.notes {
+		if match(note) {
+			notes = append(notes, *cloneNote(note))
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		a, b := notes[i], notes[j]
+		if byTarget && a.TargetID != b.TargetID {
+			return a.TargetID < b.TargetID
+		}
+		if !a.SeenAt.Equal(b.SeenAt) {
+			return a.SeenAt.Before(b.SeenAt)
+		}
+		return a.ID < b.ID
+	})
+	return notes
+}
+
+// Sightings reported out of order do not move the target back to an older location
+func (r *targetRepository) UpdateLastSeen(id uint, lat, lng float64, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	target, ok := r.store.targets[id]
+	if !ok || deleted(target.DeletedAt) || (target.LastSeenAt != nil && target.LastSeenAt.After(at)) {
+		return nil
+	}
+	target.LastSeenLat = &lat
+	target.LastSeenLng = &lng
+	target.LastSeenAt = &at
+	target.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *targetRepository) ListDeleted() ([]models.Target, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	targets := []models.Target{}
+	for _, target := range r.store.targets {
+		if deleted(target.DeletedAt) {
+			targets = append(targets, *cloneTarget(target))
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].DeletedAt.Time.After(targets[j].DeletedAt.Time) })
+	return targets, nil
+}
+
+func (r *targetRepository) GetDeleted(id uint) (*models.Target, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	target, ok := r.store.targets[id]
+	if !ok || !deleted(target.DeletedAt) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneTarget(target), nil
+}
+
+func (r *targetRepository) Restore(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if target, ok := r.store.targets[id]; ok {
+		target.DeletedAt = gorm.DeletedAt{}
+		target.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *targetRepository) PurgeDeleted(before time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var purged int64
+	for id, target := range r.store.targets {
+		if !deleted(target.DeletedAt) || !target.DeletedAt.Time.Before(before) {
+			continue
+		}
+		for noteID, note := range r.store.notes {
+			if note.TargetID == id {
+				delete(r.store.notes, noteID)
+			}
+		}
+		delete(r.store.targets, id)
+		purged++
+	}
+	return purged, nil
+}
@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"database/sql"
+	"time"
+
 	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
 
 	"gorm.io/gorm"
 )
@@ -10,11 +14,25 @@ type MissionRepository interface {
 	Create(mission *models.Mission) error
 	GetByID(id uint) (*models.Mission, error)
 	GetAll() ([]models.Mission, error)
+	List(filter models.MissionFilter) ([]models.Mission, error)
+	Stream(filter models.MissionFilter, fn func(mission *models.Mission) error) error
 	Update(mission *models.Mission) error
 	Delete(id uint) error
 	GetByCatID(catID uint) (*models.Mission, error)
+	GetActiveByCatID(catID uint) ([]models.Mission, error)
 	AssignCat(missionID, catID uint) error
+	UnassignCat(missionID uint) error
 	CompleteMission(missionID uint) error
+	MarkStarted(missionID uint, at time.Time) error
+	MarkOverdue(now time.Time) (int64, error)
+	GetCompletedBetween(from, to time.Time) ([]models.Mission, error)
+	SetBudget(missionID uint, budget money.Money) error
+	AddMember(missionID, catID uint) error
+	RemoveMember(missionID, catID uint) error
+	ListDeleted() ([]models.Mission, error)
+	GetDeleted(id uint) (*models.Mission, error)
+	Restore(id uint) error
+	PurgeDeleted(before time.Time) (int64, error)
 }
 
 type missionRepository struct {
@@ -31,7 +49,7 @@ func (r *missionRepository) Create(mission *models.Mission) error {
 
 func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 	var mission models.Mission
-	err := r.db.Preload("Cat").Preload("Targets").First(&mission, id).Error
+	err := r.db.Preload("Cat", unscoped).Preload("Targets").Preload("RequiredSkills.Skill").Preload("Members.Cat", unscoped).First(&mission, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -40,12 +58,54 @@ func (r *missionRepository) GetByID(id uint) (*models.Mission, error) {
 
 func (r *missionRepository) GetAll() ([]models.Mission, error) {
 	var missions []models.Mission
-	err := r.db.Preload("Cat").Preload("Targets").Find(&missions).Error
+	err := r.db.Preload("Cat", unscoped).Preload("Targets").Preload("RequiredSkills.Skill").Preload("Members.Cat", unscoped).Find(&missions).Error
 	return missions, err
 }
 
+func (r *missionRepository) List(filter models.MissionFilter) ([]models.Mission, error) {
+	var missions []models.Mission
+	err := r.listQuery(filter).Preload("Cat", unscoped).Preload("Targets").Preload("RequiredSkills.Skill").Preload("Members.Cat", unscoped).Find(&missions).Error
+	return missions, err
+}
+
+// Stream calls fn for every mission matching filter, reading one row at a time; associations are not loaded
+func (r *missionRepository) Stream(filter models.MissionFilter, fn func(mission *models.Mission) error) error {
+	return streamRows(r.listQuery(filter).Model(&models.Mission{}), func(db *gorm.DB, rows *sql.Rows) error {
+		var mission models.Mission
+		if err := db.ScanRows(rows, &mission); err != nil {
+			return err
+		}
+		return fn(&mission)
+	})
+}
+
+func (r *missionRepository) listQuery(filter models.MissionFilter) *gorm.DB {
+	query := r.db
+	if filter.Overdue != nil {
+		query = query.Where("is_overdue = ?", *filter.Overdue)
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("deadline_at < ?", *filter.DueBefore)
+	}
+	switch filter.State {
+	case models.MissionStateCompleted:
+		query = query.Where("is_completed = ?", true)
+	case models.MissionStateOverdue:
+		query = query.Where("is_completed = ? AND is_overdue = ?", false, true)
+	case models.MissionStateActive:
+		query = query.Where("is_completed = ? AND is_overdue = ? AND cat_id IS NOT NULL", false, false)
+	case models.MissionStateUnassigned:
+		query = query.Where("is_completed = ? AND is_overdue = ? AND cat_id IS NULL", false, false)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	return query.Order("id")
+}
+
+// Update saves the mission with its targets and required skills; members are changed with AddMember and RemoveMember
 func (r *missionRepository) Update(mission *models.Mission) error {
-	return r.db.Save(mission).Error
+	return r.db.Omit("Members").Save(mission).Error
 }
 
 func (r *missionRepository) Delete(id uint) error {
@@ -54,7 +114,8 @@ func (r *missionRepository) Delete(id uint) error {
 
 func (r *missionRepository) GetByCatID(catID uint) (*models.Mission, error) {
 	var mission models.Mission
-	err := r.db.Preload("Cat").Preload("Targets").Where("cat_id = ? AND is_completed = ?", catID, false).First(&mission).Error
+	err := r.db.Preload("Cat", unscoped).Preload("Targets").Preload("RequiredSkills.Skill").Preload("Members.Cat", unscoped).
+		Where("(cat_id = ? OR id IN (?)) AND is_completed = ?", catID, r.memberOf(catID), false).First(&mission).Error
 	if err != nil {
 		return nil, err
 	}
@@ -66,5 +127,106 @@ func (r *missionRepository) AssignCat(missionID, catID uint) error {
 }
 
 func (r *missionRepository) CompleteMission(missionID uint) error {
-	return r.db.Model(&models.Mission{}).Where("id = ?", missionID).Update("is_completed", true).Error
+	return r.db.Model(&models.Mission{}).Where("id = ?", missionID).Updates(map[string]interface{}{
+		"is_completed": true,
+		"ended_at":     time.Now(),
+	}).Error
+}
+
+func (r *missionRepository) MarkStarted(missionID uint, at time.Time) error {
+	return r.db.Model(&models.Mission{}).Where("id = ? AND started_at IS NULL", missionID).Update("started_at", at).Error
+}
+
+func (r *missionRepository) MarkOverdue(now time.Time) (int64, error) {
+	result := r.db.Model(&models.Mission{}).
+		Where("is_completed = ? AND is_overdue = ? AND deadline_at < ?", false, false, now).
+		Update("is_overdue", true)
+	return result.RowsAffected, result.Error
+}
+
+func (r *missionRepository) GetCompletedBetween(from, to time.Time) ([]models.Mission, error) {
+	var missions []models.Mission
+	err := r.db.Preload("Targets").Preload("Members").
+		Where("is_completed = ? AND ended_at >= ? AND ended_at < ?", true, from, to).
+		Order("ended_at").Find(&missions).Error
+	return missions, err
+}
+
+func (r *missionRepository) GetActiveByCatID(catID uint) ([]models.Mission, error) {
+	var missions []models.Mission
+	err := r.db.Preload("Targets").Preload("RequiredSkills.Skill").Preload("Members.Cat", unscoped).
+		Where("(cat_id = ? OR id IN (?)) AND is_completed = ?", catID, r.memberOf(catID), false).Order("id").Find(&missions).Error
+	return missions, err
+}
+
+func (r *missionRepository) UnassignCat(missionID uint) error {
+	return r.db.Model(&models.Mission{}).Where("id = ?", missionID).Update("cat_id", nil).Error
+}
+
+func (r *missionRepository) SetBudget(missionID uint, budget money.Money) error {
+	return r.db.Model(&models.Mission{}).Where("id = ?", missionID).Updates(map[string]interface{}{
+		"budget_amount":   budget.Amount,
+		"budget_currency": budget.Currency,
+	}).Error
+}
+
+func (r *missionRepository) AddMember(missionID, catID uint) error {
+	return r.db.Create(&models.MissionCat{MissionID: missionID, CatID: catID}).Error
+}
+
+func (r *missionRepository) RemoveMember(missionID, catID uint) error {
+	return r.db.Where("mission_id = ? AND cat_id = ?", missionID, catID).Delete(&models.MissionCat{}).Error
+}
+
+// memberOf selects the missions a cat works on as a member rather than as the lead
+func (r *missionRepository) memberOf(catID uint) *gorm.DB {
+	return r.db.Model(&models.MissionCat{}).Select("mission_id").Where("cat_id = ?", catID)
+}
+
+// Retired and deleted cats still show up on the missions they worked
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+func (r *missionRepository) ListDeleted() ([]models.Mission, error) {
+	var missions []models.Mission
+	err := r.db.Unscoped().Preload("Cat", unscoped).Preload("Targets").
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&missions).Error
+	return missions, err
+}
+
+func (r *missionRepository) GetDeleted(id uint) (*models.Mission, error) {
+	var mission models.Mission
+	err := r.db.Unscoped().Preload("Cat", unscoped).Preload("Targets").
+		Where("deleted_at IS NOT NULL").First(&mission, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+func (r *missionRepository) Restore(id uint) error {
+	return r.db.Unscoped().Model(&models.Mission{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// Purging a mission also removes its targets, required skills, members, bookings and expenses
+func (r *missionRepository) PurgeDeleted(before time.Time) (int64, error) {
+	var ids []uint
+	err := r.db.Unscoped().Model(&models.Mission{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.TargetNote{}, &models.Target{}, &models.MissionSkillRequirement{}, &models.MissionCat{}, &models.AvailabilityEntry{}, &models.Expense{}} {
+			if err := tx.Unscoped().Where("mission_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&models.Mission{}, ids).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
 }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayrollRepository interface {
+	CreateSalaryRecord(record *models.SalaryRecord) error
+	GetSalaryHistory(catID uint) ([]models.SalaryRecord, error)
+	GetHazardRates() ([]models.HazardPayRate, error)
+	UpsertHazardRate(rate *models.HazardPayRate) error
+	DeleteHazardRate(country string) error
+	CreateRun(run *models.PayrollRun) error
+	GetRunByID(id uint) (*models.PayrollRun, error)
+	GetRunByPeriod(period string) (*models.PayrollRun, error)
+	GetRuns() ([]models.PayrollRun, error)
+	GetEntriesByCatID(catID uint) ([]models.PayrollEntry, error)
+}
+
+type payrollRepository struct {
+	db *gorm.DB
+}
+
+func NewPayrollRepository(db *gorm.DB) PayrollRepository {
+	return &payrollRepository{db: db}
+}
+
+func (r *payrollRepository) CreateSalaryRecord(record *models.SalaryRecord) error {
+	return r.db.Create(record).Error
+}
+
+func (r *payrollRepository) GetSalaryHistory(catID uint) ([]models.SalaryRecord, error) {
+	var records []models.SalaryRecord
+	err := r.db.Where("cat_id = ?", catID).Order("effective_from, id").Find(&records).Error
+	return records, err
+}
+
+func (r *payrollRepository) GetHazardRates() ([]models.HazardPayRate, error) {
+	var rates []models.HazardPayRate
+	err := r.db.Order("country").Find(&rates).Error
+	return rates, err
+}
+
+func (r *payrollRepository) UpsertHazardRate(rate *models.HazardPayRate) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "country"}},
+		DoUpdates: clause.AssignmentColumns([]string{"country_name", "rate_amount", "rate_currency", "updated_at"}),
+	}).Create(rate).Error
+}
+
+func (r *payrollRepository) DeleteHazardRate(country string) error {
+	return r.db.Where("country = ?", country).Delete(&models.HazardPayRate{}).Error
+}
+
+func (r *payrollRepository) CreateRun(run *models.PayrollRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *payrollRepository) GetRunByID(id uint) (*models.PayrollRun, error) {
+	var run models.PayrollRun
+	err := r.db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("cat_id")
+	}).Preload("Entries.Cat", unscoped).First(&run, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *payrollRepository) GetRunByPeriod(period string) (*models.PayrollRun, error) {
+	var run models.PayrollRun
+	err := r.db.Where("period = ?", period).First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *payrollRepository) GetRuns() ([]models.PayrollRun, error) {
+	var runs []models.PayrollRun
+	err := r.db.Order("period DESC").Find(&runs).Error
+	return runs, err
+}
+
+func (r *payrollRepository) GetEntriesByCatID(catID uint) ([]models.PayrollEntry, error) {
+	var entries []models.PayrollEntry
+	err := r.db.Preload("Run").Where("cat_id = ?", catID).Order("run_id").Find(&entries).Error
+	return entries, err
+}
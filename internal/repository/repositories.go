@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// Repositories bundles every repository on one connection, so commands and
+// transactions can hand them around together
+type Repositories struct {
+	Cats         CatRepository
+	Missions     MissionRepository
+	Targets      TargetRepository
+	Availability AvailabilityRepository
+	Skills       SkillRepository
+	Payroll      PayrollRepository
+	Expenses     ExpenseRepository
+	Retention    RetentionRepository
+	Dossiers     DossierRepository
+	Countries    CountryRepository
+	Geo          GeoRepository
+	Admins       AdminRepository
+
+	db *gorm.DB
+}
+
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Cats:         NewCatRepository(db),
+		Missions:     NewMissionRepository(db),
+		Targets:      NewTargetRepository(db),
+		Availability: NewAvailabilityRepository(db),
+		Skills:       NewSkillRepository(db),
+		Payroll:      NewPayrollRepository(db),
+		Expenses:     NewExpenseRepository(db),
+		Retention:    NewRetentionRepository(db),
+		Dossiers:     NewDossierRepository(db),
+		Countries:    NewCountryRepository(db),
+		Geo:          NewGeoRepository(db),
+		Admins:       NewAdminRepository(db),
+		db:           db,
+	}
+}
+
+// Transaction runs fn with repositories bound to one transaction, which is committed when fn returns nil.
+// Calling Transaction on those repositories again opens a savepoint.
+func (r *Repositories) Transaction(fn func(tx *Repositories) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
+
+// streamRows runs query and calls scan for each row while the cursor is open,
+// so exports do not hold a whole table in memory
+func streamRows(query *gorm.DB, scan func(db *gorm.DB, rows *sql.Rows) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(query, rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
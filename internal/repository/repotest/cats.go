@@ -0,0 +1,283 @@
+package repotest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+)
+
+var catTests = []test{
+	{"CatCreateFillsDefaults", testCatCreateFillsDefaults},
+	{"CatNotFound", testCatNotFound},
+	{"CatUpdate", testCatUpdate},
+	{"CatSoftDelete", testCatSoftDelete},
+	{"CatList", testCatList},
+	{"CatListBySkill", testCatListBySkill},
+	{"CatAvailable", testCatAvailable},
+	{"CatPurge", testCatPurge},
+	{"CatConcurrentCreate", testCatConcurrentCreate},
+}
+
+func testCatCreateFillsDefaults(t *testing.T, r Repositories) {
+	first := &models.SpyCat{Name: "Tom", YearsExperience: 3, Breed: "Siamese", Salary: money.Money{Amount: 100000}}
+	must(t, r.Cats.Create(first))
+	second := createCat(t, r, "Felix")
+
+	if first.ID == 0 || second.ID <= first.ID {
+		t.Fatalf("IDs %d and %d are not assigned in order", first.ID, second.ID)
+	}
+	equal(t, "is_available", first.IsAvailable, true)
+	equal(t, "status", first.Status, models.CatStatusActive)
+	equal(t, "salary currency", first.Salary.Currency, "USD")
+	if first.CreatedAt.IsZero() || first.UpdatedAt.IsZero() {
+		t.Error("timestamps are not set")
+	}
+
+	got, err := r.Cats.GetByID(first.ID)
+	must(t, err)
+	equal(t, "name", got.Name, "Tom")
+	equal(t, "breed", got.Breed, "Siamese")
+	equal(t, "salary", got.Salary, money.New(100000, "USD"))
+	equal(t, "is_available", got.IsAvailable, true)
+	sameTime(t, "created_at", &got.CreatedAt, &first.CreatedAt)
+}
+
+func testCatNotFound(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+
+	_, err := r.Cats.GetByID(cat.ID + 100)
+	notFound(t, "GetByID", err)
+	_, err = r.Cats.GetDeleted(cat.ID)
+	notFound(t, "GetDeleted of a cat that is not deleted", err)
+
+	// Changing a cat that does not exist is not an error, like an UPDATE matching no rows
+	must(t, r.Cats.SetAvailability(cat.ID+100, false))
+	must(t, r.Cats.Delete(cat.ID+100))
+}
+
+func testCatUpdate(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	cat.Name = "Thomas"
+	cat.Salary = money.New(250000, "EUR")
+	cat.Status = models.CatStatusSuspended
+	cat.StatusChangedAt = ptr(time.Now())
+	must(t, r.Cats.Update(cat))
+
+	got, err := r.Cats.GetByID(cat.ID)
+	must(t, err)
+	equal(t, "name", got.Name, "Thomas")
+	equal(t, "salary", got.Salary, money.New(250000, "EUR"))
+	equal(t, "status", got.Status, models.CatStatusSuspended)
+	sameTime(t, "status_changed_at", got.StatusChangedAt, cat.StatusChangedAt)
+
+	// Returned records are copies
+	got.Name = "Changed"
+	again, err := r.Cats.GetByID(cat.ID)
+	must(t, err)
+	equal(t, "name after changing a copy", again.Name, "Thomas")
+
+	must(t, r.Cats.SetAvailability(cat.ID, false))
+	got, err = r.Cats.GetByID(cat.ID)
+	must(t, err)
+	equal(t, "is_available", got.IsAvailable, false)
+	equal(t, "name after SetAvailability", got.Name, "Thomas")
+}
+
+func testCatSoftDelete(t *testing.T, r Repositories) {
+	tom := createCat(t, r, "Tom")
+	felix := createCat(t, r, "Felix")
+	must(t, r.Cats.Delete(tom.ID))
+
+	_, err := r.Cats.GetByID(tom.ID)
+	notFound(t, "GetByID of a deleted cat", err)
+	all, err := r.Cats.GetAll()
+	must(t, err)
+	equal(t, "GetAll", catIDs(all), []uint{felix.ID})
+	listed, err := r.Cats.List(models.CatFilter{})
+	must(t, err)
+	equal(t, "List", catIDs(listed), []uint{felix.ID})
+
+	trash, err := r.Cats.ListDeleted()
+	must(t, err)
+	equal(t, "ListDeleted", catIDs(trash), []uint{tom.ID})
+	got, err := r.Cats.GetDeleted(tom.ID)
+	must(t, err)
+	equal(t, "GetDeleted name", got.Name, "Tom")
+
+	// Deleted cats are out of reach of updates
+	must(t, r.Cats.SetAvailability(tom.ID, false))
+
+	must(t, r.Cats.Restore(tom.ID))
+	got, err = r.Cats.GetByID(tom.ID)
+	must(t, err)
+	equal(t, "is_available after restore", got.IsAvailable, true)
+	trash, err = r.Cats.ListDeleted()
+	must(t, err)
+	equal(t, "ListDeleted after restore", catIDs(trash), []uint{})
+}
+
+func testCatList(t *testing.T, r Repositories) {
+	var active []uint
+	for i := 0; i < 5; i++ {
+		cat := createCat(t, r, name(i))
+		if i%2 == 1 {
+			cat.Status = models.CatStatusRetired
+			must(t, r.Cats.Update(cat))
+			continue
+		}
+		active = append(active, cat.ID)
+	}
+
+	cats, err := r.Cats.List(models.CatFilter{Status: models.CatStatusActive})
+	must(t, err)
+	equal(t, "active cats", catIDs(cats), active)
+
+	all, err := r.Cats.List(models.CatFilter{})
+	must(t, err)
+	if len(all) != 5 {
+		t.Fatalf("List without a filter returned %d cats, want 5", len(all))
+	}
+	paged, err := r.Cats.List(models.CatFilter{Limit: 2, Offset: 1})
+	must(t, err)
+	equal(t, "page", catIDs(paged), catIDs(all[1:3]))
+	beyond, err := r.Cats.List(models.CatFilter{Limit: 2, Offset: 10})
+	must(t, err)
+	equal(t, "page past the end", catIDs(beyond), []uint{})
+	offsetOnly, err := r.Cats.List(models.CatFilter{Offset: 3})
+	must(t, err)
+	equal(t, "offset without limit", catIDs(offsetOnly), catIDs(all))
+
+	var streamed []uint
+	err = r.Cats.Stream(models.CatFilter{Status: models.CatStatusActive}, func(cat *models.SpyCat) error {
+		streamed = append(streamed, cat.ID)
+		return nil
+	})
+	must(t, err)
+	equal(t, "streamed cats", streamed, active)
+}
+
+func testCatListBySkill(t *testing.T, r Repositories) {
+	lockpicking := createSkill(t, r, "Lockpicking")
+	disguise := createSkill(t, r, "Disguise")
+	expert := createCat(t, r, "Tom")
+	novice := createCat(t, r, "Felix")
+	expired := createCat(t, r, "Garfield")
+	other := createCat(t, r, "Salem")
+	retired := createCat(t, r, "Luna")
+	retired.Status = models.CatStatusRetired
+	must(t, r.Cats.Update(retired))
+
+	now := time.Now()
+	for _, catSkill := range []models.CatSkill{
+		{CatID: expert.ID, SkillID: lockpicking.ID, Level: 4, ExpiresAt: ptr(now.Add(24 * time.Hour))},
+		{CatID: novice.ID, SkillID: lockpicking.ID, Level: 1},
+		{CatID: expired.ID, SkillID: lockpicking.ID, Level: 5, ExpiresAt: ptr(now.Add(-time.Hour))},
+		{CatID: other.ID, SkillID: disguise.ID, Level: 5},
+		{CatID: retired.ID, SkillID: lockpicking.ID, Level: 5},
+	} {
+		must(t, r.Skills.AddCatSkill(&catSkill))
+	}
+
+	cats, err := r.Cats.List(models.CatFilter{Skill: "lockpicking"})
+	must(t, err)
+	equal(t, "cats with the skill in any case", catIDs(cats), []uint{expert.ID, novice.ID, retired.ID})
+	cats, err = r.Cats.List(models.CatFilter{Skill: "Lockpicking", MinLevel: 2})
+	must(t, err)
+	equal(t, "cats at level 2 or above", catIDs(cats), []uint{expert.ID, retired.ID})
+	cats, err = r.Cats.List(models.CatFilter{Skill: "Lockpicking", MinLevel: 2, Status: models.CatStatusActive})
+	must(t, err)
+	equal(t, "active cats at level 2 or above", catIDs(cats), []uint{expert.ID})
+	cats, err = r.Cats.List(models.CatFilter{Skill: "Lockpicking", Limit: 1, Offset: 1})
+	must(t, err)
+	equal(t, "page", catIDs(cats), []uint{novice.ID})
+	cats, err = r.Cats.List(models.CatFilter{Skill: "Hacking"})
+	must(t, err)
+	equal(t, "cats with an unknown skill", catIDs(cats), []uint{})
+
+	must(t, r.Cats.Delete(expert.ID))
+	cats, err = r.Cats.List(models.CatFilter{Skill: "Lockpicking", MinLevel: 2})
+	must(t, err)
+	equal(t, "deleted cats are left out", catIDs(cats), []uint{retired.ID})
+}
+
+func testCatAvailable(t *testing.T, r Repositories) {
+	available := createCat(t, r, "Tom")
+	busy := createCat(t, r, "Felix")
+	retired := createCat(t, r, "Garfield")
+	must(t, r.Cats.SetAvailability(busy.ID, false))
+	retired.Status = models.CatStatusRetired
+	must(t, r.Cats.Update(retired))
+
+	cats, err := r.Cats.GetAvailable()
+	must(t, err)
+	equal(t, "available cats", catIDs(cats), []uint{available.ID})
+}
+
+func testCatPurge(t *testing.T, r Repositories) {
+	unreferenced := createCat(t, r, "Tom")
+	onMission := createCat(t, r, "Felix")
+	onTarget := createCat(t, r, "Garfield")
+	member := createCat(t, r, "Salem")
+	mission := createMission(t, r, &onMission.ID, "John Doe")
+	must(t, r.Targets.AssignCat(mission.Targets[0].ID, &onTarget.ID))
+	must(t, r.Missions.AddMember(mission.ID, member.ID))
+	for _, cat := range []*models.SpyCat{unreferenced, onMission, onTarget, member} {
+		must(t, r.Cats.Delete(cat.ID))
+	}
+
+	purged, err := r.Cats.PurgeDeleted(time.Now().Add(-time.Hour))
+	must(t, err)
+	equal(t, "purged before the deletion", purged, int64(0))
+
+	purged, err = r.Cats.PurgeDeleted(time.Now().Add(time.Minute))
+	must(t, err)
+	equal(t, "purged", purged, int64(1))
+	_, err = r.Cats.GetDeleted(unreferenced.ID)
+	notFound(t, "GetDeleted of a purged cat", err)
+	trash, err := r.Cats.ListDeleted()
+	must(t, err)
+	if len(trash) != 3 {
+		t.Errorf("%d cats left in the trash, want the 3 that are referenced", len(trash))
+	}
+}
+
+func testCatConcurrentCreate(t *testing.T, r Repositories) {
+	const workers, perWorker = 8, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				cat := &models.SpyCat{Name: name(w*perWorker + i), YearsExperience: 1, Breed: "Siamese"}
+				if err := r.Cats.Create(cat); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := r.Cats.GetAll(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	cats, err := r.Cats.GetAll()
+	must(t, err)
+	seen := make(map[uint]bool)
+	for _, cat := range cats {
+		seen[cat.ID] = true
+	}
+	if len(cats) != workers*perWorker || len(seen) != len(cats) {
+		t.Errorf("%d cats with %d distinct IDs, want %d", len(cats), len(seen), workers*perWorker)
+	}
+}
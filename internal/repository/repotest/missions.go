@@ -0,0 +1,384 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+)
+
+var missionTests = []test{
+	{"MissionCreateWithTargets", testMissionCreateWithTargets},
+	{"MissionLoadsDeletedCat", testMissionLoadsDeletedCat},
+	{"MissionUpdate", testMissionUpdate},
+	{"MissionList", testMissionList},
+	{"MissionMarkOverdue", testMissionMarkOverdue},
+	{"MissionByCat", testMissionByCat},
+	{"MissionSetters", testMissionSetters},
+	{"MissionCompletedBetween", testMissionCompletedBetween},
+	{"MissionMembers", testMissionMembers},
+	{"MissionSoftDelete", testMissionSoftDelete},
+	{"MissionPurge", testMissionPurge},
+}
+
+func testMissionCreateWithTargets(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	mission := createMission(t, r, &cat.ID, "John Doe", "Jane Roe")
+
+	equal(t, "budget currency", mission.Budget.Currency, "USD")
+	for _, target := range mission.Targets {
+		if target.ID == 0 {
+			t.Fatal("target ID is not assigned")
+		}
+		equal(t, "target mission_id", target.MissionID, mission.ID)
+		equal(t, "target classification", target.Classification, models.ClassificationConfidential)
+	}
+
+	got, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if got.Cat == nil {
+		t.Fatal("cat is not loaded")
+	}
+	equal(t, "cat name", got.Cat.Name, "Tom")
+	equal(t, "targets", targetIDs(got.Targets), targetIDs(mission.Targets))
+	equal(t, "target name", got.Targets[0].Name, "John Doe")
+	equal(t, "is_completed", got.IsCompleted, false)
+
+	count, err := r.Targets.CountByMissionID(mission.ID)
+	must(t, err)
+	equal(t, "target count", count, int64(2))
+
+	_, err = r.Missions.GetByID(mission.ID + 100)
+	notFound(t, "GetByID", err)
+}
+
+func testMissionLoadsDeletedCat(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	mission := createMission(t, r, &cat.ID, "John Doe", "Jane Roe")
+	must(t, r.Cats.Delete(cat.ID))
+	must(t, r.Targets.Delete(mission.Targets[0].ID))
+
+	got, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if got.Cat == nil || got.Cat.ID != cat.ID {
+		t.Errorf("deleted cat is not loaded: %v", got.Cat)
+	}
+	equal(t, "targets without the deleted one", targetIDs(got.Targets), []uint{mission.Targets[1].ID})
+
+	count, err := r.Targets.CountByMissionID(mission.ID)
+	must(t, err)
+	equal(t, "target count", count, int64(1))
+	targets, err := r.Targets.GetByMissionID(mission.ID)
+	must(t, err)
+	equal(t, "GetByMissionID", targetIDs(targets), []uint{mission.Targets[1].ID})
+}
+
+func testMissionUpdate(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	mission := createMission(t, r, nil, "John Doe")
+
+	got, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	got.Cat = nil
+	got.CatID = &cat.ID
+	got.DeadlineAt = ptr(time.Now().Add(48 * time.Hour))
+	got.Targets = append(got.Targets, models.Target{Name: "Jane Roe", Country: "FR"})
+	must(t, r.Missions.Update(got))
+	if got.Targets[1].ID == 0 {
+		t.Fatal("new target has no ID after update")
+	}
+
+	again, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if again.CatID == nil || *again.CatID != cat.ID {
+		t.Errorf("cat_id: got %v, want %d", again.CatID, cat.ID)
+	}
+	sameTime(t, "deadline_at", again.DeadlineAt, got.DeadlineAt)
+	equal(t, "targets", targetIDs(again.Targets), targetIDs(got.Targets))
+	equal(t, "new target mission_id", again.Targets[1].MissionID, mission.ID)
+}
+
+func testMissionList(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	now := time.Now()
+
+	unassigned := createMission(t, r, nil)
+	active := createMission(t, r, &cat.ID)
+	overdue := &models.Mission{CatID: &cat.ID, DeadlineAt: ptr(now.Add(-time.Hour)), IsOverdue: true}
+	must(t, r.Missions.Create(overdue))
+	completed := createMission(t, r, &cat.ID)
+	must(t, r.Missions.CompleteMission(completed.ID))
+	dueSoon := &models.Mission{DeadlineAt: ptr(now.Add(time.Hour))}
+	must(t, r.Missions.Create(dueSoon))
+
+	for state, want := range map[string][]uint{
+		models.MissionStateUnassigned: {unassigned.ID, dueSoon.ID},
+		models.MissionStateActive:     {active.ID},
+		models.MissionStateOverdue:    {overdue.ID},
+		models.MissionStateCompleted:  {completed.ID},
+		"":                            {unassigned.ID, active.ID, overdue.ID, completed.ID, dueSoon.ID},
+	} {
+		missions, err := r.Missions.List(models.MissionFilter{State: state})
+		must(t, err)
+		equal(t, "state "+state, missionIDs(missions), want)
+	}
+
+	missions, err := r.Missions.List(models.MissionFilter{Overdue: ptr(true)})
+	must(t, err)
+	equal(t, "overdue", missionIDs(missions), []uint{overdue.ID})
+	missions, err = r.Missions.List(models.MissionFilter{DueBefore: ptr(now.Add(2 * time.Hour))})
+	must(t, err)
+	equal(t, "due before", missionIDs(missions), []uint{overdue.ID, dueSoon.ID})
+	missions, err = r.Missions.List(models.MissionFilter{Limit: 2, Offset: 1})
+	must(t, err)
+	equal(t, "page", missionIDs(missions), []uint{active.ID, overdue.ID})
+
+	var streamed []uint
+	err = r.Missions.Stream(models.MissionFilter{State: models.MissionStateUnassigned}, func(mission *models.Mission) error {
+		if mission.Cat != nil || len(mission.Targets) != 0 {
+			t.Errorf("mission %d is streamed with associations", mission.ID)
+		}
+		streamed = append(streamed, mission.ID)
+		return nil
+	})
+	must(t, err)
+	equal(t, "streamed missions", streamed, []uint{unassigned.ID, dueSoon.ID})
+}
+
+func testMissionMarkOverdue(t *testing.T, r Repositories) {
+	now := time.Now()
+	late := &models.Mission{DeadlineAt: ptr(now.Add(-time.Hour))}
+	must(t, r.Missions.Create(late))
+	lateButDone := &models.Mission{DeadlineAt: ptr(now.Add(-time.Hour))}
+	must(t, r.Missions.Create(lateButDone))
+	must(t, r.Missions.CompleteMission(lateButDone.ID))
+	onTime := &models.Mission{DeadlineAt: ptr(now.Add(time.Hour))}
+	must(t, r.Missions.Create(onTime))
+	createMission(t, r, nil)
+
+	flagged, err := r.Missions.MarkOverdue(now)
+	must(t, err)
+	equal(t, "flagged", flagged, int64(1))
+	flagged, err = r.Missions.MarkOverdue(now)
+	must(t, err)
+	equal(t, "flagged again", flagged, int64(0))
+
+	got, err := r.Missions.GetByID(late.ID)
+	must(t, err)
+	equal(t, "is_overdue", got.IsOverdue, true)
+	got, err = r.Missions.GetByID(onTime.ID)
+	must(t, err)
+	equal(t, "is_overdue of a mission on time", got.IsOverdue, false)
+}
+
+func testMissionByCat(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	other := createCat(t, r, "Felix")
+	done := createMission(t, r, &cat.ID)
+	must(t, r.Missions.CompleteMission(done.ID))
+	first := createMission(t, r, &cat.ID, "John Doe")
+	second := createMission(t, r, &cat.ID)
+	createMission(t, r, &other.ID)
+
+	got, err := r.Missions.GetByCatID(cat.ID)
+	must(t, err)
+	equal(t, "open mission", got.ID, first.ID)
+	if got.Cat == nil || len(got.Targets) != 1 {
+		t.Error("associations of the open mission are not loaded")
+	}
+
+	active, err := r.Missions.GetActiveByCatID(cat.ID)
+	must(t, err)
+	equal(t, "active missions", missionIDs(active), []uint{first.ID, second.ID})
+	equal(t, "active mission targets", targetIDs(active[0].Targets), targetIDs(first.Targets))
+
+	_, err = r.Missions.GetByCatID(cat.ID + other.ID + 100)
+	notFound(t, "GetByCatID of a cat without missions", err)
+	none, err := r.Missions.GetActiveByCatID(cat.ID + other.ID + 100)
+	must(t, err)
+	equal(t, "active missions of a cat without missions", len(none), 0)
+}
+
+func testMissionSetters(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	mission := createMission(t, r, nil)
+
+	must(t, r.Missions.AssignCat(mission.ID, cat.ID))
+	got, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if got.CatID == nil || *got.CatID != cat.ID {
+		t.Errorf("cat_id after AssignCat: got %v, want %d", got.CatID, cat.ID)
+	}
+
+	must(t, r.Missions.UnassignCat(mission.ID))
+	got, err = r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if got.CatID != nil || got.Cat != nil {
+		t.Errorf("cat after UnassignCat: got %v", got.CatID)
+	}
+
+	must(t, r.Missions.SetBudget(mission.ID, money.New(500000, "EUR")))
+	started := time.Now().Add(-time.Hour)
+	must(t, r.Missions.MarkStarted(mission.ID, started))
+	must(t, r.Missions.MarkStarted(mission.ID, time.Now()))
+	got, err = r.Missions.GetByID(mission.ID)
+	must(t, err)
+	equal(t, "budget", got.Budget, money.New(500000, "EUR"))
+	sameTime(t, "started_at is only set once", got.StartedAt, &started)
+
+	before := time.Now()
+	must(t, r.Missions.CompleteMission(mission.ID))
+	got, err = r.Missions.GetByID(mission.ID)
+	must(t, err)
+	equal(t, "is_completed", got.IsCompleted, true)
+	if got.EndedAt == nil || got.EndedAt.Before(before.Add(-time.Millisecond)) {
+		t.Errorf("ended_at is not set on completion: %v", got.EndedAt)
+	}
+}
+
+func testMissionCompletedBetween(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	from := time.Now()
+	first := createMission(t, r, &cat.ID, "John Doe")
+	second := createMission(t, r, &cat.ID)
+	createMission(t, r, &cat.ID)
+	must(t, r.Missions.CompleteMission(second.ID))
+	must(t, r.Missions.CompleteMission(first.ID))
+	to := time.Now().Add(time.Second)
+
+	missions, err := r.Missions.GetCompletedBetween(from.Add(-time.Second), to)
+	must(t, err)
+	equal(t, "completed missions in order of completion", missionIDs(missions), []uint{second.ID, first.ID})
+	equal(t, "targets", targetIDs(missions[1].Targets), targetIDs(first.Targets))
+
+	missions, err = r.Missions.GetCompletedBetween(to, to.Add(time.Hour))
+	must(t, err)
+	equal(t, "completed missions later", missionIDs(missions), []uint{})
+}
+
+func testMissionMembers(t *testing.T, r Repositories) {
+	lead := createCat(t, r, "Tom")
+	first := createCat(t, r, "Felix")
+	second := createCat(t, r, "Garfield")
+	mission := createMission(t, r, &lead.ID, "John Doe", "Jane Roe")
+	must(t, r.Missions.AddMember(mission.ID, first.ID))
+	must(t, r.Missions.AddMember(mission.ID, second.ID))
+	if err := r.Missions.AddMember(mission.ID, first.ID); err == nil {
+		t.Error("adding a member twice is not rejected")
+	}
+
+	got, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if len(got.Members) != 2 || got.Members[0].CatID != first.ID || got.Members[1].CatID != second.ID {
+		t.Fatalf("members: got %v, want cats %d and %d", got.Members, first.ID, second.ID)
+	}
+	if got.Members[0].Cat == nil || got.Members[0].Cat.Name != "Felix" {
+		t.Errorf("cat of a member is not loaded: %v", got.Members[0].Cat)
+	}
+
+	open, err := r.Missions.GetByCatID(first.ID)
+	must(t, err)
+	equal(t, "open mission of a member", open.ID, mission.ID)
+	active, err := r.Missions.GetActiveByCatID(second.ID)
+	must(t, err)
+	equal(t, "active missions of a member", missionIDs(active), []uint{mission.ID})
+
+	must(t, r.Targets.AssignCat(mission.Targets[0].ID, &first.ID))
+	must(t, r.Targets.AssignCat(mission.Targets[1].ID, &lead.ID))
+	must(t, r.Missions.RemoveMember(mission.ID, first.ID))
+	must(t, r.Targets.UnassignCat(mission.ID, first.ID))
+
+	got, err = r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if len(got.Members) != 1 || got.Members[0].CatID != second.ID {
+		t.Errorf("members after removing one: got %v, want cat %d", got.Members, second.ID)
+	}
+	if got.Targets[0].CatID != nil {
+		t.Errorf("target is still assigned to the cat that left: %d", *got.Targets[0].CatID)
+	}
+	if got.Targets[1].CatID == nil || *got.Targets[1].CatID != lead.ID {
+		t.Errorf("assignment of the lead cat changed: %v", got.Targets[1].CatID)
+	}
+	active, err = r.Missions.GetActiveByCatID(first.ID)
+	must(t, err)
+	equal(t, "active missions of a cat that left", missionIDs(active), []uint{})
+
+	must(t, r.Missions.CompleteMission(mission.ID))
+	completed, err := r.Missions.GetCompletedBetween(time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+	must(t, err)
+	if len(completed) != 1 || len(completed[0].Members) != 1 || completed[0].Members[0].CatID != second.ID {
+		t.Errorf("members of completed missions are not loaded: %v", completed)
+	}
+}
+
+func testMissionSoftDelete(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	kept := createMission(t, r, nil)
+	mission := createMission(t, r, &cat.ID, "John Doe")
+	must(t, r.Missions.Delete(mission.ID))
+
+	_, err := r.Missions.GetByID(mission.ID)
+	notFound(t, "GetByID of a deleted mission", err)
+	missions, err := r.Missions.List(models.MissionFilter{})
+	must(t, err)
+	equal(t, "List", missionIDs(missions), []uint{kept.ID})
+	_, err = r.Missions.GetByCatID(cat.ID)
+	notFound(t, "GetByCatID with the mission deleted", err)
+
+	// Deleted missions are out of reach of updates
+	must(t, r.Missions.AssignCat(mission.ID, cat.ID))
+	must(t, r.Missions.UnassignCat(mission.ID))
+
+	trash, err := r.Missions.ListDeleted()
+	must(t, err)
+	equal(t, "ListDeleted", missionIDs(trash), []uint{mission.ID})
+	got, err := r.Missions.GetDeleted(mission.ID)
+	must(t, err)
+	if got.CatID == nil || got.Cat == nil {
+		t.Error("deleted mission lost its cat")
+	}
+	equal(t, "deleted mission targets", targetIDs(got.Targets), targetIDs(mission.Targets))
+	_, err = r.Missions.GetDeleted(kept.ID)
+	notFound(t, "GetDeleted of a mission that is not deleted", err)
+
+	must(t, r.Missions.Restore(mission.ID))
+	got, err = r.Missions.GetByID(mission.ID)
+	must(t, err)
+	equal(t, "restored mission targets", targetIDs(got.Targets), targetIDs(mission.Targets))
+}
+
+func testMissionPurge(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	kept := createMission(t, r, nil, "John Doe")
+	mission := createMission(t, r, nil, "Jane Roe")
+	must(t, r.Missions.AddMember(mission.ID, cat.ID))
+	note := &models.TargetNote{TargetID: mission.Targets[0].ID, MissionID: mission.ID, Notes: "seen", SeenAt: time.Now()}
+	must(t, r.Targets.CreateNote(note))
+	must(t, r.Missions.Delete(mission.ID))
+
+	purged, err := r.Missions.PurgeDeleted(time.Now().Add(-time.Hour))
+	must(t, err)
+	equal(t, "purged before the deletion", purged, int64(0))
+
+	purged, err = r.Missions.PurgeDeleted(time.Now().Add(time.Minute))
+	must(t, err)
+	equal(t, "purged", purged, int64(1))
+	_, err = r.Missions.GetDeleted(mission.ID)
+	notFound(t, "GetDeleted of a purged mission", err)
+	_, err = r.Targets.GetByID(mission.Targets[0].ID)
+	notFound(t, "target of a purged mission", err)
+	notes, err := r.Targets.GetNotes(mission.Targets[0].ID)
+	must(t, err)
+	equal(t, "notes of a purged mission", noteIDs(notes), []uint{})
+
+	got, err := r.Missions.GetByID(kept.ID)
+	must(t, err)
+	equal(t, "targets of the mission kept", targetIDs(got.Targets), targetIDs(kept.Targets))
+
+	// The purged mission's members went with it, so the cat is no longer referenced
+	must(t, r.Cats.Delete(cat.ID))
+	purged, err = r.Cats.PurgeDeleted(time.Now().Add(time.Minute))
+	must(t, err)
+	equal(t, "cats purged after their mission", purged, int64(1))
+}
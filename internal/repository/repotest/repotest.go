@@ -0,0 +1,215 @@
+// Package repotest is the conformance suite for the cat, mission, target and skill repositories. Every
+// implementation has to pass it, so services behave the same on Postgres, SQLite and in memory:
+//
+//	func TestRepositories(t *testing.T) {
+//		t.Run("sqlite", func(t *testing.T) { repotest.Run(t, repotest.SQLite) })
+//		t.Run("memory", func(t *testing.T) { repotest.Run(t, repotest.Memory) })
+//	}
+//
+// Postgres is tested the same way with repotest.Postgres(url), which empties the database first.
+package repotest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/repository/memory"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Repositories are the implementations under test, sharing one empty store
+type Repositories struct {
+	Cats     repository.CatRepository
+	Missions repository.MissionRepository
+	Targets  repository.TargetRepository
+	Skills   repository.SkillRepository
+}
+
+type test struct {
+	name string
+	run  func(t *testing.T, r Repositories)
+}
+
+// Run runs every test of the suite on fresh repositories from open
+func Run(t *testing.T, open func(t *testing.T) Repositories) {
+	var tests []test
+	tests = append(tests, catTests...)
+	tests = append(tests, missionTests...)
+	tests = append(tests, targetTests...)
+	tests = append(tests, skillTests...)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, open(t))
+		})
+	}
+}
+
+// Memory opens the in-memory repositories on an empty store
+func Memory(t *testing.T) Repositories {
+	store := memory.NewStore()
+	return Repositories{
+		Cats:     memory.NewCatRepository(store),
+		Missions: memory.NewMissionRepository(store),
+		Targets:  memory.NewTargetRepository(store),
+		Skills:   memory.NewSkillRepository(store),
+	}
+}
+
+// SQLite opens the GORM repositories on a migrated in-memory SQLite database
+func SQLite(t *testing.T) Repositories {
+	db, err := database.Initialize("sqlite://:memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if _, err := database.MigrateUp(db, 0); err != nil {
+		t.Fatal(err)
+	}
+	return GORM(db)
+}
+
+// Postgres returns an open function for the GORM repositories on the Postgres database at url. The
+// database is migrated, and the cat, mission, target and skill tables and the tables that reference them
+// are emptied before every test, so it must not hold data anyone needs.
+func Postgres(url string) func(t *testing.T) Repositories {
+	return func(t *testing.T) Repositories {
+		db, err := database.Initialize(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.Logger = logger.Default.LogMode(logger.Silent)
+		t.Cleanup(func() {
+			if sqlDB, err := db.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		if _, err := database.MigrateUp(db, 0); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Exec("TRUNCATE spy_cats, missions, targets, mission_cats, skills RESTART IDENTITY CASCADE").Error; err != nil {
+			t.Fatal(err)
+		}
+		return GORM(db)
+	}
+}
+
+// GORM opens the GORM repositories on db, which has to be migrated and empty
+func GORM(db *gorm.DB) Repositories {
+	return Repositories{
+		Cats:     repository.NewCatRepository(db),
+		Missions: repository.NewMissionRepository(db),
+		Targets:  repository.NewTargetRepository(db),
+		Skills:   repository.NewSkillRepository(db),
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func equal(t *testing.T, what string, got, want interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+func notFound(t *testing.T, what string, err error) {
+	t.Helper()
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("%s: got error %v, want gorm.ErrRecordNotFound", what, err)
+	}
+}
+
+// Databases round times to the microsecond or store them as text, so times are compared to the millisecond
+func sameTime(t *testing.T, what string, got, want *time.Time) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s: got %v, want %v", what, got, want)
+	case got.Sub(*want).Abs() > time.Millisecond:
+		t.Errorf("%s: got %v, want %v", what, *got, *want)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func createCat(t *testing.T, r Repositories, name string) *models.SpyCat {
+	t.Helper()
+	cat := &models.SpyCat{Name: name, YearsExperience: 3, Breed: "Siamese"}
+	must(t, r.Cats.Create(cat))
+	return cat
+}
+
+func createSkill(t *testing.T, r Repositories, name string) *models.Skill {
+	t.Helper()
+	skill := &models.Skill{Name: name}
+	must(t, r.Skills.Create(skill))
+	return skill
+}
+
+func createMission(t *testing.T, r Repositories, catID *uint, targets ...string) *models.Mission {
+	t.Helper()
+	mission := &models.Mission{CatID: catID}
+	for _, name := range targets {
+		mission.Targets = append(mission.Targets, models.Target{Name: name, Country: "GB"})
+	}
+	must(t, r.Missions.Create(mission))
+	return mission
+}
+
+func catIDs(cats []models.SpyCat) []uint {
+	ids := []uint{}
+	for _, cat := range cats {
+		ids = append(ids, cat.ID)
+	}
+	return ids
+}
+
+func missionIDs(missions []models.Mission) []uint {
+	ids := []uint{}
+	for _, mission := range missions {
+		ids = append(ids, mission.ID)
+	}
+	return ids
+}
+
+func targetIDs(targets []models.Target) []uint {
+	ids := []uint{}
+	for _, target := range targets {
+		ids = append(ids, target.ID)
+	}
+	return ids
+}
+
+func noteIDs(notes []models.TargetNote) []uint {
+	ids := []uint{}
+	for _, note := range notes {
+		ids = append(ids, note.ID)
+	}
+	return ids
+}
+
+func name(i int) string {
+	return fmt.Sprintf("Agent %d", i)
+}
@@ -0,0 +1,20 @@
+package repotest_test
+
+import (
+	"os"
+	"testing"
+
+	"spy-cat-agency/internal/repository/repotest"
+)
+
+func TestRepositories(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) { repotest.Run(t, repotest.SQLite) })
+	t.Run("memory", func(t *testing.T) { repotest.Run(t, repotest.Memory) })
+	t.Run("postgres", func(t *testing.T) {
+		url := os.Getenv("REPOTEST_POSTGRES_URL")
+		if url == "" {
+			t.Skip("REPOTEST_POSTGRES_URL is not set")
+		}
+		repotest.Run(t, repotest.Postgres(url))
+	})
+}
@@ -0,0 +1,136 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/models"
+)
+
+var skillTests = []test{
+	{"SkillCreateAndUpdate", testSkillCreateAndUpdate},
+	{"SkillCatSkills", testSkillCatSkills},
+	{"SkillExpiring", testSkillExpiring},
+	{"SkillMissionRequirements", testSkillMissionRequirements},
+}
+
+func testSkillCreateAndUpdate(t *testing.T, r Repositories) {
+	stealth := createSkill(t, r, "Stealth")
+	createSkill(t, r, "Disguise")
+	if stealth.ID == 0 || stealth.CreatedAt.IsZero() {
+		t.Fatal("ID or timestamps are not set")
+	}
+	if err := r.Skills.Create(&models.Skill{Name: "Stealth"}); err == nil {
+		t.Error("Create accepted a duplicate name")
+	}
+
+	stealth.Category = "field"
+	must(t, r.Skills.Update(stealth))
+	got, err := r.Skills.GetByID(stealth.ID)
+	must(t, err)
+	equal(t, "category", got.Category, "field")
+
+	skills, err := r.Skills.GetAll()
+	must(t, err)
+	names := make([]string, len(skills))
+	for i, skill := range skills {
+		names[i] = skill.Name
+	}
+	equal(t, "skills by name", names, []string{"Disguise", "Stealth"})
+
+	must(t, r.Skills.Delete(stealth.ID))
+	_, err = r.Skills.GetByID(stealth.ID)
+	notFound(t, "GetByID of a deleted skill", err)
+}
+
+func testSkillCatSkills(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	stealth := createSkill(t, r, "Stealth")
+	disguise := createSkill(t, r, "Disguise")
+
+	must(t, r.Skills.AddCatSkill(&models.CatSkill{CatID: cat.ID, SkillID: disguise.ID, Level: 2}))
+	must(t, r.Skills.AddCatSkill(&models.CatSkill{CatID: cat.ID, SkillID: stealth.ID, Level: 3}))
+	if err := r.Skills.AddCatSkill(&models.CatSkill{CatID: cat.ID, SkillID: stealth.ID, Level: 1}); err == nil {
+		t.Error("AddCatSkill accepted the same skill twice")
+	}
+
+	catSkill, err := r.Skills.GetCatSkill(cat.ID, stealth.ID)
+	must(t, err)
+	if catSkill.Skill == nil || catSkill.Skill.Name != "Stealth" {
+		t.Fatalf("skill is not loaded: %+v", catSkill.Skill)
+	}
+	catSkill.Level = 5
+	must(t, r.Skills.UpdateCatSkill(catSkill))
+
+	catSkills, err := r.Skills.GetCatSkills(cat.ID)
+	must(t, err)
+	levels := make(map[uint]int)
+	var order []uint
+	for _, cs := range catSkills {
+		levels[cs.SkillID] = cs.Level
+		order = append(order, cs.SkillID)
+	}
+	equal(t, "skills by skill ID", order, []uint{stealth.ID, disguise.ID})
+	equal(t, "updated level", levels[stealth.ID], 5)
+
+	usage, err := r.Skills.CountUsage(stealth.ID)
+	must(t, err)
+	equal(t, "usage", usage, int64(1))
+
+	must(t, r.Skills.DeleteCatSkill(cat.ID, stealth.ID))
+	_, err = r.Skills.GetCatSkill(cat.ID, stealth.ID)
+	notFound(t, "GetCatSkill of a removed skill", err)
+}
+
+func testSkillExpiring(t *testing.T, r Repositories) {
+	tom := createCat(t, r, "Tom")
+	felix := createCat(t, r, "Felix")
+	stealth := createSkill(t, r, "Stealth")
+	disguise := createSkill(t, r, "Disguise")
+
+	now := time.Now()
+	must(t, r.Skills.AddCatSkill(&models.CatSkill{CatID: tom.ID, SkillID: stealth.ID, Level: 1, ExpiresAt: ptr(now.Add(48 * time.Hour))}))
+	must(t, r.Skills.AddCatSkill(&models.CatSkill{CatID: felix.ID, SkillID: stealth.ID, Level: 1, ExpiresAt: ptr(now.Add(24 * time.Hour))}))
+	must(t, r.Skills.AddCatSkill(&models.CatSkill{CatID: tom.ID, SkillID: disguise.ID, Level: 1, ExpiresAt: ptr(now.Add(30 * 24 * time.Hour))}))
+	must(t, r.Skills.AddCatSkill(&models.CatSkill{CatID: felix.ID, SkillID: disguise.ID, Level: 1}))
+
+	expiring, err := r.Skills.GetExpiringCatSkills(now, now.Add(7*24*time.Hour))
+	must(t, err)
+	var cats []uint
+	for _, catSkill := range expiring {
+		cats = append(cats, catSkill.CatID)
+		if catSkill.Cat == nil || catSkill.Skill == nil {
+			t.Errorf("cat skill %d: cat or skill is not loaded", catSkill.ID)
+		}
+	}
+	equal(t, "expiring by date", cats, []uint{felix.ID, tom.ID})
+}
+
+func testSkillMissionRequirements(t *testing.T, r Repositories) {
+	mission := createMission(t, r, nil, "John")
+	stealth := createSkill(t, r, "Stealth")
+	disguise := createSkill(t, r, "Disguise")
+
+	must(t, r.Skills.SetMissionRequirements(mission.ID, []models.MissionSkillRequirement{{SkillID: stealth.ID, MinLevel: 2}}))
+	must(t, r.Skills.SetMissionRequirements(mission.ID, []models.MissionSkillRequirement{{SkillID: disguise.ID, MinLevel: 3}}))
+
+	requirements, err := r.Skills.GetMissionRequirements(mission.ID)
+	must(t, err)
+	if len(requirements) != 1 || requirements[0].SkillID != disguise.ID || requirements[0].MinLevel != 3 {
+		t.Fatalf("requirements: got %+v, want only Disguise at level 3", requirements)
+	}
+	if requirements[0].Skill == nil || requirements[0].Skill.Name != "Disguise" {
+		t.Errorf("skill is not loaded: %+v", requirements[0].Skill)
+	}
+
+	loaded, err := r.Missions.GetByID(mission.ID)
+	must(t, err)
+	if len(loaded.RequiredSkills) != 1 || loaded.RequiredSkills[0].Skill == nil {
+		t.Errorf("mission requirements: got %+v", loaded.RequiredSkills)
+	}
+
+	must(t, r.Skills.SetMissionRequirements(mission.ID, nil))
+	requirements, err = r.Skills.GetMissionRequirements(mission.ID)
+	must(t, err)
+	equal(t, "cleared requirements", len(requirements), 0)
+}
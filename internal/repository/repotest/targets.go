@@ -0,0 +1,242 @@
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/models"
+)
+
+var targetTests = []test{
+	{"TargetLoadsMission", testTargetLoadsMission},
+	{"TargetSetters", testTargetSetters},
+	{"TargetByCat", testTargetByCat},
+	{"TargetNotes", testTargetNotes},
+	{"TargetLastSeen", testTargetLastSeen},
+	{"TargetSoftDelete", testTargetSoftDelete},
+	{"TargetPurge", testTargetPurge},
+}
+
+func testTargetLoadsMission(t *testing.T, r Repositories) {
+	mission := createMission(t, r, nil, "John Doe")
+	target := &models.Target{MissionID: mission.ID, Name: "Jane Roe", Country: "FR", Classification: "secret"}
+	must(t, r.Targets.Create(target))
+
+	got, err := r.Targets.GetByID(target.ID)
+	must(t, err)
+	equal(t, "classification", got.Classification, "secret")
+	if got.Mission == nil || got.Mission.ID != mission.ID {
+		t.Fatalf("mission is not loaded: %v", got.Mission)
+	}
+
+	targets, err := r.Targets.GetByMissionID(mission.ID)
+	must(t, err)
+	equal(t, "GetByMissionID", targetIDs(targets), []uint{mission.Targets[0].ID, target.ID})
+
+	// A target outlives its deleted mission, which is no longer loaded
+	must(t, r.Missions.Delete(mission.ID))
+	got, err = r.Targets.GetByID(target.ID)
+	must(t, err)
+	if got.Mission != nil {
+		t.Errorf("deleted mission is loaded: %v", got.Mission)
+	}
+
+	_, err = r.Targets.GetByID(target.ID + 100)
+	notFound(t, "GetByID", err)
+}
+
+func testTargetSetters(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	mission := createMission(t, r, &cat.ID, "John Doe", "Jane Roe")
+	id := mission.Targets[0].ID
+
+	must(t, r.Targets.UpdateNotes(id, "Wears a hat"))
+	must(t, r.Targets.AssignCat(id, &cat.ID))
+	must(t, r.Targets.CompleteTarget(id))
+	got, err := r.Targets.GetByID(id)
+	must(t, err)
+	equal(t, "notes", got.Notes, "Wears a hat")
+	equal(t, "is_completed", got.IsCompleted, true)
+	if got.CatID == nil || *got.CatID != cat.ID {
+		t.Errorf("cat_id: got %v, want %d", got.CatID, cat.ID)
+	}
+	equal(t, "name is kept", got.Name, "John Doe")
+
+	must(t, r.Targets.AssignCat(id, nil))
+	got, err = r.Targets.GetByID(id)
+	must(t, err)
+	if got.CatID != nil {
+		t.Errorf("cat_id after unassigning: got %d", *got.CatID)
+	}
+
+	got.Country = "DE"
+	got.DeadlineAt = ptr(time.Now().Add(time.Hour))
+	must(t, r.Targets.Update(got))
+	again, err := r.Targets.GetByID(id)
+	must(t, err)
+	equal(t, "country", again.Country, "DE")
+	sameTime(t, "deadline_at", again.DeadlineAt, got.DeadlineAt)
+
+	for _, target := range mission.Targets {
+		must(t, r.Targets.AssignCat(target.ID, &cat.ID))
+	}
+	must(t, r.Targets.ClearCatAssignments(mission.ID))
+	targets, err := r.Targets.GetByCatID(cat.ID)
+	must(t, err)
+	equal(t, "targets of the cat after clearing", targetIDs(targets), []uint{})
+}
+
+func testTargetByCat(t *testing.T, r Repositories) {
+	tom := createCat(t, r, "Tom")
+	felix := createCat(t, r, "Felix")
+	first := createMission(t, r, nil, "A", "B")
+	second := createMission(t, r, nil, "C")
+
+	// Assigned out of order, so the result has to be sorted by mission and then ID
+	for _, target := range []models.Target{second.Targets[0], first.Targets[1], first.Targets[0]} {
+		must(t, r.Targets.AssignCat(target.ID, &tom.ID))
+	}
+	extra := &models.Target{MissionID: first.ID, Name: "D", Country: "GB", CatID: &felix.ID}
+	must(t, r.Targets.Create(extra))
+	want := []uint{first.Targets[0].ID, first.Targets[1].ID, second.Targets[0].ID}
+
+	targets, err := r.Targets.GetByCatID(tom.ID)
+	must(t, err)
+	equal(t, "GetByCatID", targetIDs(targets), want)
+	for _, target := range targets {
+		if target.Mission == nil || target.Mission.ID != target.MissionID {
+			t.Errorf("mission of target %d is not loaded", target.ID)
+		}
+	}
+
+	stream := func(filter models.TargetFilter) []uint {
+		ids := []uint{}
+		must(t, r.Targets.Stream(filter, func(target *models.Target) error {
+			ids = append(ids, target.ID)
+			return nil
+		}))
+		return ids
+	}
+	equal(t, "streamed by cat", stream(models.TargetFilter{CatID: tom.ID}), want)
+	equal(t, "streamed by mission", stream(models.TargetFilter{MissionID: first.ID}), []uint{first.Targets[0].ID, first.Targets[1].ID, extra.ID})
+	equal(t, "streamed by mission and cat", stream(models.TargetFilter{MissionID: first.ID, CatID: felix.ID}), []uint{extra.ID})
+	equal(t, "streamed", len(stream(models.TargetFilter{})), 4)
+}
+
+func testTargetNotes(t *testing.T, r Repositories) {
+	first := createMission(t, r, nil, "John Doe", "Jane Roe")
+	second := createMission(t, r, nil, "Max Mustermann")
+	john, jane := first.Targets[0], first.Targets[1]
+	now := time.Now()
+
+	note := func(target models.Target, seen time.Duration, located bool) *models.TargetNote {
+		n := &models.TargetNote{TargetID: target.ID, MissionID: target.MissionID, Notes: "seen", SeenAt: now.Add(seen)}
+		if located {
+			n.Latitude, n.Longitude = ptr(51.5), ptr(-0.12)
+		}
+		must(t, r.Targets.CreateNote(n))
+		return n
+	}
+	late := note(john, time.Hour, true)
+	early := note(john, -time.Hour, false)
+	sameTimeAsLate := note(john, time.Hour, true)
+	janeSeen := note(jane, -2*time.Hour, true)
+	note(second.Targets[0], 0, true)
+
+	notes, err := r.Targets.GetNotes(john.ID)
+	must(t, err)
+	equal(t, "notes in the order seen", noteIDs(notes), []uint{early.ID, late.ID, sameTimeAsLate.ID})
+	equal(t, "note text", notes[0].Notes, "seen")
+	sameTime(t, "seen_at", &notes[0].SeenAt, &early.SeenAt)
+
+	sightings, err := r.Targets.GetSightings([]uint{first.ID})
+	must(t, err)
+	equal(t, "sightings", noteIDs(sightings), []uint{late.ID, sameTimeAsLate.ID, janeSeen.ID})
+	if *sightings[0].Latitude != 51.5 || *sightings[0].Longitude != -0.12 {
+		t.Errorf("sighting location: got %v, %v", *sightings[0].Latitude, *sightings[0].Longitude)
+	}
+	sightings, err = r.Targets.GetSightings([]uint{first.ID, second.ID})
+	must(t, err)
+	equal(t, "sightings of both missions", len(sightings), 4)
+	sightings, err = r.Targets.GetSightings(nil)
+	must(t, err)
+	equal(t, "sightings of no missions", len(sightings), 0)
+}
+
+func testTargetLastSeen(t *testing.T, r Repositories) {
+	mission := createMission(t, r, nil, "John Doe")
+	id := mission.Targets[0].ID
+	now := time.Now()
+
+	must(t, r.Targets.UpdateLastSeen(id, 48.85, 2.35, now))
+	must(t, r.Targets.UpdateLastSeen(id, 51.5, -0.12, now.Add(-time.Hour)))
+	got, err := r.Targets.GetByID(id)
+	must(t, err)
+	sameTime(t, "last_seen_at after an older sighting", got.LastSeenAt, &now)
+	if got.LastSeenLat == nil || *got.LastSeenLat != 48.85 || *got.LastSeenLng != 2.35 {
+		t.Errorf("last seen location moved back to an older sighting: %v, %v", got.LastSeenLat, got.LastSeenLng)
+	}
+
+	later := now.Add(time.Hour)
+	must(t, r.Targets.UpdateLastSeen(id, 52.52, 13.4, later))
+	got, err = r.Targets.GetByID(id)
+	must(t, err)
+	sameTime(t, "last_seen_at after a newer sighting", got.LastSeenAt, &later)
+	equal(t, "last_seen_lat", *got.LastSeenLat, 52.52)
+}
+
+func testTargetSoftDelete(t *testing.T, r Repositories) {
+	cat := createCat(t, r, "Tom")
+	mission := createMission(t, r, nil, "John Doe", "Jane Roe")
+	id := mission.Targets[0].ID
+	must(t, r.Targets.Delete(id))
+
+	_, err := r.Targets.GetByID(id)
+	notFound(t, "GetByID of a deleted target", err)
+	_, err = r.Targets.GetDeleted(mission.Targets[1].ID)
+	notFound(t, "GetDeleted of a target that is not deleted", err)
+
+	// Deleted targets are out of reach of updates
+	must(t, r.Targets.AssignCat(id, &cat.ID))
+	must(t, r.Targets.CompleteTarget(id))
+
+	trash, err := r.Targets.ListDeleted()
+	must(t, err)
+	equal(t, "ListDeleted", targetIDs(trash), []uint{id})
+	got, err := r.Targets.GetDeleted(id)
+	must(t, err)
+	equal(t, "is_completed of a deleted target", got.IsCompleted, false)
+	if got.CatID != nil {
+		t.Errorf("cat was assigned to a deleted target: %d", *got.CatID)
+	}
+
+	must(t, r.Targets.Restore(id))
+	targets, err := r.Targets.GetByMissionID(mission.ID)
+	must(t, err)
+	equal(t, "targets after restore", targetIDs(targets), targetIDs(mission.Targets))
+}
+
+func testTargetPurge(t *testing.T, r Repositories) {
+	mission := createMission(t, r, nil, "John Doe", "Jane Roe")
+	purgedTarget, keptTarget := mission.Targets[0], mission.Targets[1]
+	for _, target := range mission.Targets {
+		must(t, r.Targets.CreateNote(&models.TargetNote{TargetID: target.ID, MissionID: mission.ID, SeenAt: time.Now()}))
+	}
+	must(t, r.Targets.Delete(purgedTarget.ID))
+
+	purged, err := r.Targets.PurgeDeleted(time.Now().Add(-time.Hour))
+	must(t, err)
+	equal(t, "purged before the deletion", purged, int64(0))
+
+	purged, err = r.Targets.PurgeDeleted(time.Now().Add(time.Minute))
+	must(t, err)
+	equal(t, "purged", purged, int64(1))
+	_, err = r.Targets.GetDeleted(purgedTarget.ID)
+	notFound(t, "GetDeleted of a purged target", err)
+	notes, err := r.Targets.GetNotes(purgedTarget.ID)
+	must(t, err)
+	equal(t, "notes of the purged target", len(notes), 0)
+	notes, err = r.Targets.GetNotes(keptTarget.ID)
+	must(t, err)
+	equal(t, "notes of the target kept", len(notes), 1)
+}
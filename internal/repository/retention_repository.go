@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RetentionRepository interface {
+	GetPolicies() ([]models.RetentionPolicy, error)
+	UpsertPolicy(policy *models.RetentionPolicy) error
+	DeletePolicy(classification string) error
+	PurgeNotes(classification string, completedBefore, now time.Time) (int64, error)
+	GetTargetsToPseudonymize(classification string, completedBefore time.Time) ([]models.Target, error)
+	PseudonymizeTarget(id uint, name string, at time.Time) error
+	FindSubjectTargets(names []string, country string) ([]models.Target, error)
+	FindSubjectDossiers(names []string, country string, targetIDs []uint) ([]models.Dossier, error)
+	CreateErasureRequest(request *models.ErasureRequest) error
+	GetErasureRequest(id uint) (*models.ErasureRequest, error)
+	GetErasureRequests() ([]models.ErasureRequest, error)
+	EraseTargets(request *models.ErasureRequest, records []models.ErasureRecord, dossierRecords []models.ErasureDossierRecord) error
+}
+
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+func (r *retentionRepository) GetPolicies() ([]models.RetentionPolicy, error) {
+	var policies []models.RetentionPolicy
+	err := r.db.Order("classification").Find(&policies).Error
+	return policies, err
+}
+
+func (r *retentionRepository) UpsertPolicy(policy *models.RetentionPolicy) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "classification"}},
+		DoUpdates: clause.AssignmentColumns([]string{"purge_notes_after_days", "pseudonymize_after_days", "updated_at"}),
+	}).Create(policy).Error
+}
+
+func (r *retentionRepository) DeletePolicy(classification string) error {
+	return r.db.Where("classification = ?", classification).Delete(&models.RetentionPolicy{}).Error
+}
+
+// Retention applies to deleted targets too, since they still hold personal data
+func (r *retentionRepository) PurgeNotes(classification string, completedBefore, now time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&models.Target{}).Select("id").
+			Where("classification = ?", classification).
+			Where("mission_id IN (?)", r.completedMissions(completedBefore))
+		if err := tx.Where("target_id IN (?)", expired).Delete(&models.TargetNote{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&models.Target{}).
+			Where("classification = ? AND notes <> ''", classification).
+			Where("mission_id IN (?)", r.completedMissions(completedBefore)).
+			Updates(map[string]interface{}{"notes": "", "notes_purged_at": now})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+func (r *retentionRepository) GetTargetsToPseudonymize(classification string, completedBefore time.Time) ([]models.Target, error) {
+	var targets []models.Target
+	err := r.db.Unscoped().
+		Where("classification = ? AND pseudonymized_at IS NULL", classification).
+		Where("mission_id IN (?)", r.completedMissions(completedBefore)).
+		Order("id").Find(&targets).Error
+	return targets, err
+}
+
+// Pseudonymizing also unlinks the target from its dossier and drops the alias its name gave the dossier
+func (r *retentionRepository) PseudonymizeTarget(id uint, name string, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var target models.Target
+		if err := tx.Unscoped().First(&target, id).Error; err != nil {
+			return err
+		}
+
+		if target.DossierID != nil {
+			err := tx.Where("dossier_id = ? AND LOWER(TRIM(alias)) = ?", *target.DossierID, strings.ToLower(strings.TrimSpace(target.Name))).
+				Delete(&models.DossierAlias{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Model(&models.Target{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"name":             name,
+			"dossier_id":       nil,
+			"pseudonymized_at": at,
+		}).Error
+	})
+}
+
+func (r *retentionRepository) FindSubjectTargets(names []string, country string) ([]models.Target, error) {
+	var targets []models.Target
+	query := r.db.Unscoped().Where("LOWER(TRIM(name)) IN ?", names)
+	if country != "" {
+		query = query.Where("LOWER(country) = ?", strings.ToLower(country))
+	}
+	err := query.Order("mission_id, id").Find(&targets).Error
+	return targets, err
+}
+
+// Dossiers match by name or alias, or by being linked to one of the subject's targets
+func (r *retentionRepository) FindSubjectDossiers(names []string, country string, targetIDs []uint) ([]models.Dossier, error) {
+	aliased := r.db.Model(&models.DossierAlias{}).Select("dossier_id").Where("LOWER(TRIM(alias)) IN ?", names)
+	named := r.db.Unscoped().Model(&models.Dossier{}).Select("id").Where("LOWER(TRIM(name)) IN ? OR id IN (?)", names, aliased)
+	if country != "" {
+		named = named.Where("id IN (?)", r.db.Model(&models.DossierCountry{}).Select("dossier_id").Where("LOWER(country) = ?", strings.ToLower(country)))
+	}
+
+	query := r.db.Unscoped().Preload("Aliases").Preload("Photos")
+	if len(targetIDs) > 0 {
+		linked := r.db.Unscoped().Model(&models.Target{}).Select("dossier_id").Where("id IN ? AND dossier_id IS NOT NULL", targetIDs)
+		query = query.Where("id IN (?) OR id IN (?)", named, linked)
+	} else {
+		query = query.Where("id IN (?)", named)
+	}
+
+	var dossiers []models.Dossier
+	err := query.Order("id").Find(&dossiers).Error
+	return dossiers, err
+}
+
+func (r *retentionRepository) CreateErasureRequest(request *models.ErasureRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *retentionRepository) GetErasureRequest(id uint) (*models.ErasureRequest, error) {
+	var request models.ErasureRequest
+	byID := func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}
+	err := r.db.Preload("Records", byID).Preload("DossierRecords", byID).First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *retentionRepository) GetErasureRequests() ([]models.ErasureRequest, error) {
+	var requests []models.ErasureRequest
+	err := r.db.Order("id DESC").Find(&requests).Error
+	return requests, err
+}
+
+// Erased dossiers are unlinked from every target and deleted outright with their aliases, countries and
+// photos; their records are filled in with the number of targets unlinked
+func (r *retentionRepository) EraseTargets(request *models.ErasureRequest, records []models.ErasureRecord, dossierRecords []models.ErasureDossierRecord) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, record := range dossierRecords {
+			result := tx.Unscoped().Model(&models.Target{}).Where("dossier_id = ?", record.DossierID).Update("dossier_id", nil)
+			if result.Error != nil {
+				return result.Error
+			}
+			dossierRecords[i].TargetsUnlinked = int(result.RowsAffected)
+			for _, model := range []interface{}{&models.DossierAlias{}, &models.DossierCountry{}, &models.DossierPhoto{}} {
+				if err := tx.Where("dossier_id = ?", record.DossierID).Delete(model).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Unscoped().Delete(&models.Dossier{}, record.DossierID).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, record := range records {
+			err := tx.Unscoped().Model(&models.Target{}).Where("id = ?", record.TargetID).Updates(map[string]interface{}{
+				"name":             request.SubjectToken,
+				"dossier_id":       nil,
+				"notes":            "",
+				"latitude":         nil,
+				"longitude":        nil,
+				"last_seen_lat":    nil,
+				"last_seen_lng":    nil,
+				"last_seen_at":     nil,
+				"pseudonymized_at": record.ErasedAt,
+				"notes_purged_at":  record.ErasedAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("target_id = ?", record.TargetID).Delete(&models.TargetNote{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(records) > 0 {
+			if err := tx.Create(&records).Error; err != nil {
+				return err
+			}
+		}
+		if len(dossierRecords) > 0 {
+			if err := tx.Create(&dossierRecords).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("Records", "DossierRecords").Save(request).Error
+	})
+}
+
+func (r *retentionRepository) completedMissions(before time.Time) *gorm.DB {
+	return r.db.Unscoped().Model(&models.Mission{}).Select("id").Where("is_completed = ? AND ended_at < ?", true, before)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"time"
+
+	"spy-cat-agency/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SkillRepository interface {
+	Create(skill *models.Skill) error
+	GetByID(id uint) (*models.Skill, error)
+	GetAll() ([]models.Skill, error)
+	Update(skill *models.Skill) error
+	Delete(id uint) error
+	CountUsage(id uint) (int64, error)
+	AddCatSkill(catSkill *models.CatSkill) error
+	GetCatSkill(catID, skillID uint) (*models.CatSkill, error)
+	GetCatSkills(catID uint) ([]models.CatSkill, error)
+	UpdateCatSkill(catSkill *models.CatSkill) error
+	DeleteCatSkill(catID, skillID uint) error
+	GetExpiringCatSkills(from, to time.Time) ([]models.CatSkill, error)
+	GetMissionRequirements(missionID uint) ([]models.MissionSkillRequirement, error)
+	SetMissionRequirements(missionID uint, requirements []models.MissionSkillRequirement) error
+}
+
+type skillRepository struct {
+	db *gorm.DB
+}
+
+func NewSkillRepository(db *gorm.DB) SkillRepository {
+	return &skillRepository{db: db}
+}
+
+func (r *skillRepository) Create(skill *models.Skill) error {
+	return r.db.Create(skill).Error
+}
+
+func (r *skillRepository) GetByID(id uint) (*models.Skill, error) {
+	var skill models.Skill
+	err := r.db.First(&skill, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+func (r *skillRepository) GetAll() ([]models.Skill, error) {
+	var skills []models.Skill
+	err := r.db.Order("name").Find(&skills).Error
+	return skills, err
+}
+
+func (r *skillRepository) Update(skill *models.Skill) error {
+	return r.db.Save(skill).Error
+}
+
+func (r *skillRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Skill{}, id).Error
+}
+
+func (r *skillRepository) CountUsage(id uint) (int64, error) {
+	var catSkills, requirements int64
+	if err := r.db.Model(&models.CatSkill{}).Where("skill_id = ?", id).Count(&catSkills).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&models.MissionSkillRequirement{}).Where("skill_id = ?", id).Count(&requirements).Error; err != nil {
+		return 0, err
+	}
+	return catSkills + requirements, nil
+}
+
+func (r *skillRepository) AddCatSkill(catSkill *models.CatSkill) error {
+	return r.db.Create(catSkill).Error
+}
+
+func (r *skillRepository) GetCatSkill(catID, skillID uint) (*models.CatSkill, error) {
+	var catSkill models.CatSkill
+	err := r.db.Preload("Skill").Where("cat_id = ? AND skill_id = ?", catID, skillID).First(&catSkill).Error
+	if err != nil {
+		return nil, err
+	}
+	return &catSkill, nil
+}
+
+func (r *skillRepository) GetCatSkills(catID uint) ([]models.CatSkill, error) {
+	var catSkills []models.CatSkill
+	err := r.db.Preload("Skill").Where("cat_id = ?", catID).Order("skill_id").Find(&catSkills).Error
+	return catSkills, err
+}
+
+func (r *skillRepository) UpdateCatSkill(catSkill *models.CatSkill) error {
+	return r.db.Save(catSkill).Error
+}
+
+func (r *skillRepository) DeleteCatSkill(catID, skillID uint) error {
+	return r.db.Where("cat_id = ? AND skill_id = ?", catID, skillID).Delete(&models.CatSkill{}).Error
+}
+
+func (r *skillRepository) GetExpiringCatSkills(from, to time.Time) ([]models.CatSkill, error) {
+	var catSkills []models.CatSkill
+	err := r.db.Preload("Skill").Preload("Cat").
+		Where("expires_at >= ? AND expires_at < ?", from, to).
+		Order("expires_at").Find(&catSkills).Error
+	return catSkills, err
+}
+
+func (r *skillRepository) GetMissionRequirements(missionID uint) ([]models.MissionSkillRequirement, error) {
+	var requirements []models.MissionSkillRequirement
+	err := r.db.Preload("Skill").Where("mission_id = ?", missionID).Find(&requirements).Error
+	return requirements, err
+}
+
+func (r *skillRepository) SetMissionRequirements(missionID uint, requirements []models.MissionSkillRequirement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("mission_id = ?", missionID).Delete(&models.MissionSkillRequirement{}).Error; err != nil {
+			return err
+		}
+		if len(requirements) == 0 {
+			return nil
+		}
+		for i := range requirements {
+			requirements[i].MissionID = missionID
+		}
+		return tx.Create(&requirements).Error
+	})
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"database/sql"
+	"time"
+
 	"spy-cat-agency/internal/models"
 
 	"gorm.io/gorm"
@@ -15,6 +18,20 @@ type TargetRepository interface {
 	CompleteTarget(id uint) error
 	UpdateNotes(id uint, notes string) error
 	CountByMissionID(missionID uint) (int64, error)
+	AssignCat(id uint, catID *uint) error
+	SetDossier(id uint, dossierID *uint) error
+	GetByCatID(catID uint) ([]models.Target, error)
+	Stream(filter models.TargetFilter, fn func(target *models.Target) error) error
+	ClearCatAssignments(missionID uint) error
+	UnassignCat(missionID, catID uint) error
+	CreateNote(note *models.TargetNote) error
+	GetNotes(targetID uint) ([]models.TargetNote, error)
+	GetSightings(missionIDs []uint) ([]models.TargetNote, error)
+	UpdateLastSeen(id uint, lat, lng float64, at time.Time) error
+	ListDeleted() ([]models.Target, error)
+	GetDeleted(id uint) (*models.Target, error)
+	Restore(id uint) error
+	PurgeDeleted(before time.Time) (int64, error)
 }
 
 type targetRepository struct {
@@ -65,3 +82,105 @@ func (r *targetRepository) CountByMissionID(missionID uint) (int64, error) {
 	err := r.db.Model(&models.Target{}).Where("mission_id = ?", missionID).Count(&count).Error
 	return count, err
 }
+
+func (r *targetRepository) AssignCat(id uint, catID *uint) error {
+	return r.db.Model(&models.Target{}).Where("id = ?", id).Update("cat_id", catID).Error
+}
+
+func (r *targetRepository) SetDossier(id uint, dossierID *uint) error {
+	return r.db.Model(&models.Target{}).Where("id = ?", id).Update("dossier_id", dossierID).Error
+}
+
+func (r *targetRepository) GetByCatID(catID uint) ([]models.Target, error) {
+	var targets []models.Target
+	err := r.db.Preload("Mission").Where("cat_id = ?", catID).Order("mission_id, id").Find(&targets).Error
+	return targets, err
+}
+
+// Stream calls fn for every target matching filter, reading one row at a time
+func (r *targetRepository) Stream(filter models.TargetFilter, fn func(target *models.Target) error) error {
+	query := r.db.Model(&models.Target{})
+	if filter.MissionID != 0 {
+		query = query.Where("mission_id = ?", filter.MissionID)
+	}
+	if filter.CatID != 0 {
+		query = query.Where("cat_id = ?", filter.CatID)
+	}
+	return streamRows(query.Order("mission_id, id"), func(db *gorm.DB, rows *sql.Rows) error {
+		var target models.Target
+		if err := db.ScanRows(rows, &target); err != nil {
+			return err
+		}
+		return fn(&target)
+	})
+}
+
+func (r *targetRepository) ClearCatAssignments(missionID uint) error {
+	return r.db.Model(&models.Target{}).Where("mission_id = ?", missionID).Update("cat_id", nil).Error
+}
+
+// UnassignCat clears the cat's assignments on the mission's targets, for when it leaves the mission
+func (r *targetRepository) UnassignCat(missionID, catID uint) error {
+	return r.db.Model(&models.Target{}).Where("mission_id = ? AND cat_id = ?", missionID, catID).Update("cat_id", nil).Error
+}
+
+func (r *targetRepository) CreateNote(note *models.TargetNote) error {
+	return r.db.Create(note).Error
+}
+
+func (r *targetRepository) GetNotes(targetID uint) ([]models.TargetNote, error) {
+	var notes []models.TargetNote
+	err := r.db.Where("target_id = ?", targetID).Order("seen_at, id").Find(&notes).Error
+	return notes, err
+}
+
+// Sightings are the note entries that recorded a location, in the order they were seen
+func (r *targetRepository) GetSightings(missionIDs []uint) ([]models.TargetNote, error) {
+	var notes []models.TargetNote
+	if len(missionIDs) == 0 {
+		return notes, nil
+	}
+	err := r.db.Where("mission_id IN ? AND latitude IS NOT NULL AND longitude IS NOT NULL", missionIDs).
+		Order("target_id, seen_at, id").Find(&notes).Error
+	return notes, err
+}
+
+// Sightings reported out of order do not move the target back to an older location
+func (r *targetRepository) UpdateLastSeen(id uint, lat, lng float64, at time.Time) error {
+	return r.db.Model(&models.Target{}).
+		Where("id = ? AND (last_seen_at IS NULL OR last_seen_at <= ?)", id, at).
+		Updates(map[string]interface{}{"last_seen_lat": lat, "last_seen_lng": lng, "last_seen_at": at}).Error
+}
+
+func (r *targetRepository) ListDeleted() ([]models.Target, error) {
+	var targets []models.Target
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&targets).Error
+	return targets, err
+}
+
+func (r *targetRepository) GetDeleted(id uint) (*models.Target, error) {
+	var target models.Target
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&target, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (r *targetRepository) Restore(id uint) error {
+	return r.db.Unscoped().Model(&models.Target{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+func (r *targetRepository) PurgeDeleted(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Unscoped().Model(&models.Target{}).Select("id").Where("deleted_at < ?", before)
+		if err := tx.Where("target_id IN (?)", deleted).Delete(&models.TargetNote{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&models.Target{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminRoutes(router *gin.RouterGroup, adminHandler *handlers.AdminHandler) {
+	router.GET("/audit-log", adminHandler.ListAuditLog)
+}
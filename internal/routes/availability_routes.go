@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAvailabilityRoutes(router *gin.RouterGroup, availabilityHandler *handlers.AvailabilityHandler) {
+	router.GET("/cats/available", availabilityHandler.ListAvailableCats)
+
+	availability := router.Group("/cats/:id/availability")
+	{
+		availability.GET("", availabilityHandler.ListEntries)
+		availability.POST("", availabilityHandler.AddEntry)
+		availability.DELETE("/:entryId", availabilityHandler.DeleteEntry)
+	}
+}
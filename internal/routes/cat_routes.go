@@ -11,8 +11,10 @@ func SetupCatRoutes(router *gin.RouterGroup, catHandler *handlers.CatHandler) {
 	{
 		cats.POST("", catHandler.CreateCat)
 		cats.GET("", catHandler.ListCats)
+		cats.GET("/salaries/total", catHandler.GetSalaryTotals)
 		cats.GET("/:id", catHandler.GetCat)
 		cats.PUT("/:id", catHandler.UpdateCat)
+		cats.PUT("/:id/status", catHandler.ChangeStatus)
 		cats.DELETE("/:id", catHandler.DeleteCat)
 	}
 }
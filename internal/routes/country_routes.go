@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCountryRoutes(router *gin.RouterGroup, countryHandler *handlers.CountryHandler) {
+	countries := router.Group("/countries")
+	{
+		countries.GET("", countryHandler.ListCountries)
+		countries.GET("/unmapped", countryHandler.ListUnmapped)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupDossierRoutes(router *gin.RouterGroup, dossierHandler *handlers.DossierHandler) {
+	dossiers := router.Group("/dossiers")
+	{
+		dossiers.POST("", dossierHandler.CreateDossier)
+		dossiers.GET("", dossierHandler.ListDossiers)
+		dossiers.GET("/suggest", dossierHandler.SuggestDossiers)
+		dossiers.GET("/:id", dossierHandler.GetDossier)
+		dossiers.PUT("/:id", dossierHandler.UpdateDossier)
+		dossiers.DELETE("/:id", dossierHandler.DeleteDossier)
+		dossiers.GET("/:id/view", dossierHandler.GetDossierView)
+		dossiers.POST("/:id/photos", dossierHandler.AddPhoto)
+		dossiers.DELETE("/:id/photos/:photoId", dossierHandler.DeletePhoto)
+	}
+
+	targets := router.Group("/missions/:id/targets")
+	{
+		targets.GET("/:targetId/dossier-suggestions", dossierHandler.SuggestForTarget)
+		targets.PUT("/:targetId/dossier", dossierHandler.LinkTarget)
+	}
+}
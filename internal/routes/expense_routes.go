@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupExpenseRoutes(router *gin.RouterGroup, expenseHandler *handlers.ExpenseHandler) {
+	router.PUT("/missions/:id/budget", expenseHandler.SetBudget)
+
+	expenses := router.Group("/missions/:id/expenses")
+	{
+		expenses.GET("", expenseHandler.ListExpenses)
+		expenses.POST("", expenseHandler.CreateExpense)
+		expenses.GET("/summary", expenseHandler.GetSummary)
+		expenses.PUT("/:expenseId/approve", expenseHandler.ApproveExpense)
+		expenses.PUT("/:expenseId/reject", expenseHandler.RejectExpense)
+		expenses.DELETE("/:expenseId", expenseHandler.DeleteExpense)
+	}
+}
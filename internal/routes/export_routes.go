@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupExportRoutes(router *gin.RouterGroup, exportHandler *handlers.ExportHandler) {
+	exports := router.Group("/exports")
+	{
+		exports.GET("/cats", exportHandler.ExportCats)
+		exports.GET("/missions", exportHandler.ExportMissions)
+		exports.GET("/targets", exportHandler.ExportTargets)
+	}
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupGeoRoutes(router *gin.RouterGroup, geoHandler *handlers.GeoHandler) {
+	targets := router.Group("/targets")
+	{
+		targets.GET("/nearby", geoHandler.TargetsNearby)
+		targets.GET("/within", geoHandler.TargetsInBox)
+	}
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupImportRoutes(router *gin.RouterGroup, importHandler *handlers.ImportHandler) {
+	router.POST("/import", importHandler.Import)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMapRoutes(router *gin.RouterGroup, mapHandler *handlers.MapHandler) {
+	router.GET("/map.geojson", mapHandler.AgencyGeoJSON)
+	router.GET("/map.kml", mapHandler.AgencyKML)
+
+	missions := router.Group("/missions")
+	{
+		missions.GET("/:id/map.geojson", mapHandler.MissionGeoJSON)
+		missions.GET("/:id/map.kml", mapHandler.MissionKML)
+	}
+}
@@ -15,6 +15,8 @@ func SetupMissionRoutes(router *gin.RouterGroup, missionHandler *handlers.Missio
 		missions.PUT("/:id", missionHandler.UpdateMission)
 		missions.DELETE("/:id", missionHandler.DeleteMission)
 		missions.PUT("/:id/assign", missionHandler.AssignCat)
+		missions.POST("/:id/cats", missionHandler.AddMissionCat)
+		missions.DELETE("/:id/cats/:catId", missionHandler.RemoveMissionCat)
 		missions.PUT("/:id/complete", missionHandler.CompleteMission)
 	}
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPayrollRoutes(router *gin.RouterGroup, payrollHandler *handlers.PayrollHandler) {
+	payroll := router.Group("/payroll")
+	{
+		payroll.GET("/preview", payrollHandler.PreviewPayroll)
+		payroll.POST("/runs", payrollHandler.RunPayroll)
+		payroll.GET("/runs", payrollHandler.ListRuns)
+		payroll.GET("/runs/:id", payrollHandler.GetRun)
+		payroll.GET("/hazard-rates", payrollHandler.ListHazardRates)
+		payroll.PUT("/hazard-rates/:country", payrollHandler.SetHazardRate)
+		payroll.DELETE("/hazard-rates/:country", payrollHandler.DeleteHazardRate)
+	}
+
+	router.GET("/cats/:id/compensation", payrollHandler.GetCompensation)
+}
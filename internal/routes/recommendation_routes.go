@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRecommendationRoutes(router *gin.RouterGroup, recommendationHandler *handlers.RecommendationHandler) {
+	missions := router.Group("/missions/:id")
+	{
+		missions.GET("/candidates", recommendationHandler.ListCandidates)
+		missions.POST("/auto-assign", recommendationHandler.AutoAssign)
+	}
+}
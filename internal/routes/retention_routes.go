@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRetentionRoutes(router *gin.RouterGroup, retentionHandler *handlers.RetentionHandler) {
+	retention := router.Group("/retention")
+	{
+		retention.GET("/policies", retentionHandler.ListPolicies)
+		retention.PUT("/policies/:classification", retentionHandler.SetPolicy)
+		retention.DELETE("/policies/:classification", retentionHandler.DeletePolicy)
+		retention.POST("/apply", retentionHandler.ApplyPolicies)
+	}
+
+	erasures := router.Group("/erasure-requests")
+	{
+		erasures.POST("", retentionHandler.CreateErasureRequest)
+		erasures.GET("", retentionHandler.ListErasureRequests)
+		erasures.GET("/:id", retentionHandler.GetErasureRequest)
+		erasures.POST("/:id/execute", retentionHandler.ExecuteErasureRequest)
+		erasures.GET("/:id/report", retentionHandler.GetErasureReport)
+	}
+}
@@ -1,16 +1,95 @@
 package routes
 
 import (
+	"time"
+
+	"spy-cat-agency/internal/config"
 	"spy-cat-agency/internal/handlers"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, catHandler *handlers.CatHandler, missionHandler *handlers.MissionHandler) {
-	v1 := router.Group("/api/v1")
+// Handlers bundles every handler the API routes to, so the router is set up from one value
+type Handlers struct {
+	Cats            *handlers.CatHandler
+	Missions        *handlers.MissionHandler
+	Availability    *handlers.AvailabilityHandler
+	Recommendations *handlers.RecommendationHandler
+	Skills          *handlers.SkillHandler
+	Payroll         *handlers.PayrollHandler
+	Expenses        *handlers.ExpenseHandler
+	Trash           *handlers.TrashHandler
+	Retention       *handlers.RetentionHandler
+	Dossiers        *handlers.DossierHandler
+	Countries       *handlers.CountryHandler
+	Geo             *handlers.GeoHandler
+	Maps            *handlers.MapHandler
+	Imports         *handlers.ImportHandler
+	Exports         *handlers.ExportHandler
+	Admins          *handlers.AdminHandler
+}
+
+// NewHandlers builds the services of the API on the repositories and the handlers that serve them
+func NewHandlers(repos *repository.Repositories, cfg *config.Config, rates *money.Rates) *Handlers {
+	missionService := services.NewMissionService(repos.Missions, repos.Targets, repos.Cats, repos.Availability, repos.Skills)
+	catService := services.NewCatService(repos.Cats, repos.Payroll, repos.Missions, missionService, rates, cfg.PayrollCurrency)
+	availabilityService := services.NewAvailabilityService(repos.Availability, repos.Cats)
+	recommendationService := services.NewRecommendationService(repos.Missions, repos.Cats, repos.Availability, repos.Skills, missionService, rates, cfg.PayrollCurrency)
+	skillService := services.NewSkillService(repos.Skills, repos.Cats, repos.Missions)
+	payrollService := services.NewPayrollService(repos.Payroll, repos.Cats, repos.Missions, rates, cfg.PayrollCurrency, cfg.MissionBonus)
+	expenseService := services.NewExpenseService(repos.Expenses, repos.Missions, rates, cfg.PayrollCurrency, cfg.ExpenseWarningThreshold)
+	trashService := services.NewTrashService(repos.Cats, repos.Missions, repos.Targets, time.Duration(cfg.TrashRetentionDays)*24*time.Hour)
+	retentionService := services.NewRetentionService(repos.Retention, cfg.PseudonymizationKey)
+	dossierService := services.NewDossierService(repos.Dossiers, repos.Targets)
+	countryService := services.NewCountryService(repos.Countries)
+	geoService := services.NewGeoService(repos.Geo)
+	mapService := services.NewMapService(repos.Missions, repos.Targets)
+	importService := services.NewImportService(repos, rates, cfg.PayrollCurrency)
+	exportService := services.NewExportService(repos.Cats, repos.Missions, repos.Targets)
+	adminService := services.NewAdminService(repos.Admins)
+
+	return &Handlers{
+		Cats:            handlers.NewCatHandler(catService),
+		Missions:        handlers.NewMissionHandler(missionService),
+		Availability:    handlers.NewAvailabilityHandler(availabilityService),
+		Recommendations: handlers.NewRecommendationHandler(recommendationService),
+		Skills:          handlers.NewSkillHandler(skillService),
+		Payroll:         handlers.NewPayrollHandler(payrollService),
+		Expenses:        handlers.NewExpenseHandler(expenseService),
+		Trash:           handlers.NewTrashHandler(trashService),
+		Retention:       handlers.NewRetentionHandler(retentionService),
+		Dossiers:        handlers.NewDossierHandler(dossierService),
+		Countries:       handlers.NewCountryHandler(countryService),
+		Geo:             handlers.NewGeoHandler(geoService),
+		Maps:            handlers.NewMapHandler(mapService),
+		Imports:         handlers.NewImportHandler(importService),
+		Exports:         handlers.NewExportHandler(exportService),
+		Admins:          handlers.NewAdminHandler(adminService),
+	}
+}
+
+func SetupRoutes(router *gin.Engine, h *Handlers, middleware ...gin.HandlerFunc) {
+	v1 := router.Group("/api/v1", middleware...)
 	{
-		SetupCatRoutes(v1, catHandler)
-		SetupMissionRoutes(v1, missionHandler)
-		SetupTargetRoutes(v1, missionHandler)
+		SetupCatRoutes(v1, h.Cats)
+		SetupMissionRoutes(v1, h.Missions)
+		SetupTargetRoutes(v1, h.Missions)
+		SetupAvailabilityRoutes(v1, h.Availability)
+		SetupRecommendationRoutes(v1, h.Recommendations)
+		SetupSkillRoutes(v1, h.Skills)
+		SetupPayrollRoutes(v1, h.Payroll)
+		SetupExpenseRoutes(v1, h.Expenses)
+		SetupTrashRoutes(v1, h.Trash)
+		SetupRetentionRoutes(v1, h.Retention)
+		SetupDossierRoutes(v1, h.Dossiers)
+		SetupCountryRoutes(v1, h.Countries)
+		SetupGeoRoutes(v1, h.Geo)
+		SetupMapRoutes(v1, h.Maps)
+		SetupImportRoutes(v1, h.Imports)
+		SetupExportRoutes(v1, h.Exports)
+		SetupAdminRoutes(v1, h.Admins)
 	}
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSkillRoutes(router *gin.RouterGroup, skillHandler *handlers.SkillHandler) {
+	skills := router.Group("/skills")
+	{
+		skills.POST("", skillHandler.CreateSkill)
+		skills.GET("", skillHandler.ListSkills)
+		skills.GET("/expiring", skillHandler.ListExpiringCertifications)
+		skills.GET("/:id", skillHandler.GetSkill)
+		skills.PUT("/:id", skillHandler.UpdateSkill)
+		skills.DELETE("/:id", skillHandler.DeleteSkill)
+	}
+
+	catSkills := router.Group("/cats/:id/skills")
+	{
+		catSkills.GET("", skillHandler.ListCatSkills)
+		catSkills.POST("", skillHandler.AddCatSkill)
+		catSkills.PUT("/:skillId", skillHandler.UpdateCatSkill)
+		catSkills.DELETE("/:skillId", skillHandler.RemoveCatSkill)
+	}
+
+	router.GET("/missions/:id/skills", skillHandler.GetMissionSkills)
+	router.PUT("/missions/:id/skills", skillHandler.SetMissionSkills)
+}
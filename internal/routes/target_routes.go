@@ -10,10 +10,16 @@ func SetupTargetRoutes(router *gin.RouterGroup, missionHandler *handlers.Mission
 
 	targets := router.Group("/missions/:id/targets")
 	{
+		targets.GET("", missionHandler.ListTargets)
 		targets.POST("", missionHandler.AddTarget)
 		targets.PUT("/:targetId", missionHandler.UpdateTarget)
 		targets.DELETE("/:targetId", missionHandler.DeleteTarget)
 		targets.PUT("/:targetId/complete", missionHandler.CompleteTarget)
 		targets.PUT("/:targetId/notes", missionHandler.UpdateTargetNotes)
+		targets.GET("/:targetId/notes", missionHandler.ListTargetNotes)
+		targets.POST("/:targetId/notes", missionHandler.AddTargetNote)
+		targets.PUT("/:targetId/assign", missionHandler.AssignTargetCat)
 	}
+
+	router.GET("/cats/:id/targets", missionHandler.ListCatTargets)
 }
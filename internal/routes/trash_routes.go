@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"spy-cat-agency/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTrashRoutes(router *gin.RouterGroup, trashHandler *handlers.TrashHandler) {
+	trash := router.Group("/trash")
+	{
+		trash.GET("", trashHandler.ListTrash)
+		trash.POST("/purge", trashHandler.Purge)
+		trash.POST("/:type/:id/restore", trashHandler.Restore)
+	}
+}
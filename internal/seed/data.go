@@ -0,0 +1,60 @@
+package seed
+
+// Breeds are TheCatAPI names, so cats are stored directly without asking the API
+var breeds = []string{
+	"Abyssinian", "Bengal", "Birman", "Bombay", "British Shorthair", "Burmese", "Chartreux",
+	"Cornish Rex", "Devon Rex", "Egyptian Mau", "Maine Coon", "Norwegian Forest Cat", "Ocicat",
+	"Persian", "Ragdoll", "Russian Blue", "Savannah", "Scottish Fold", "Siamese", "Siberian",
+	"Sphynx", "Turkish Angora", "Turkish Van",
+}
+
+var catNames = []string{
+	"Shadow", "Whiskers", "Misty", "Pepper", "Smokey", "Luna", "Oliver", "Jasper", "Cleo", "Felix",
+	"Nala", "Simba", "Tiger", "Ginger", "Oscar", "Milo", "Willow", "Salem", "Pumpkin", "Mochi",
+	"Binx", "Hazel", "Ziggy", "Duchess", "Socks", "Boots", "Pixel", "Nova", "Onyx", "Maple",
+}
+
+var firstNames = []string{
+	"Viktor", "Anna", "Jean", "Lucas", "Mariana", "Diego", "Elena", "Hans", "Yuki", "Amir",
+	"Sofia", "Lars", "Chiara", "Mehmet", "Ingrid", "Pavel", "Aisha", "Tomás", "Nadia", "Kenji",
+}
+
+var lastNames = []string{
+	"Petrov", "Kowalska", "Dupont", "Silva", "Costa", "Alvarez", "Novak", "Müller", "Tanaka", "Haddad",
+	"Rossi", "Larsen", "Yilmaz", "Berg", "Horvat", "Okafor", "Fernández", "Ivanova", "Sato", "Moreau",
+}
+
+// Targets are placed near one of these cities
+var cities = []struct {
+	country  string
+	lat, lng float64
+}{
+	{"RU", 55.7558, 37.6173},
+	{"PL", 52.2297, 21.0122},
+	{"FR", 48.8566, 2.3522},
+	{"BR", -23.5505, -46.6333},
+	{"AR", -34.6037, -58.3816},
+	{"DE", 52.5200, 13.4050},
+	{"JP", 35.6762, 139.6503},
+	{"EG", 30.0444, 31.2357},
+	{"IT", 41.9028, 12.4964},
+	{"NO", 59.9139, 10.7522},
+	{"TR", 41.0082, 28.9784},
+	{"NG", 6.5244, 3.3792},
+	{"US", 40.7128, -74.0060},
+	{"GB", 51.5074, -0.1278},
+	{"ES", 40.4168, -3.7038},
+}
+
+var classifications = []string{"public", "confidential", "confidential", "secret", "top_secret"}
+
+var sightings = []string{
+	"Left the hotel at dawn and took a taxi to the station",
+	"Met an unknown contact at a café, exchanged an envelope",
+	"Visited the embassy, stayed for about an hour",
+	"Seen buying a train ticket with cash",
+	"Changed clothes in a department store, lost for twenty minutes",
+	"Spent the evening at a jazz club near the river",
+	"Picked up a parcel from a post office box",
+	"Took several photos of the harbour",
+}
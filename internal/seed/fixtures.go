@@ -0,0 +1,32 @@
+package seed
+
+import "sort"
+
+// Fixture is a named, reproducible dataset
+type Fixture struct {
+	Name        string
+	Description string
+	Options     Options
+}
+
+var fixtures = []Fixture{
+	{"demo", "A handful of cats and missions for trying the API", Options{Seed: 1, Cats: 8, Missions: 10}},
+	{"minimal", "Two cats and one mission in each state", Options{Seed: 1, Cats: 2, Missions: 4}},
+	{"busy", "Many more missions than cats, most cats are on a mission", Options{Seed: 7, Cats: 25, Missions: 60}},
+	{"load", "A large agency for load testing", Options{Seed: 42, Cats: 1000, Missions: 2500}},
+}
+
+func GetFixture(name string) (Fixture, bool) {
+	for _, fixture := range fixtures {
+		if fixture.Name == name {
+			return fixture, true
+		}
+	}
+	return Fixture{}, false
+}
+
+func Fixtures() []Fixture {
+	sorted := append([]Fixture(nil), fixtures...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
@@ -0,0 +1,290 @@
+// Package seed fills a database with a generated, reproducible dataset for demos and load tests
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/services"
+)
+
+// Options describe a dataset. The same options always produce the same cats, missions, targets and notes;
+// dates are relative to the time of seeding.
+type Options struct {
+	Seed     int64
+	Cats     int
+	Missions int
+	// Currency of the salaries, money.DefaultCurrency when empty
+	Currency string
+}
+
+type Result struct {
+	Cats     int
+	Missions map[string]int
+	Notes    int
+}
+
+// Missions take their states from this cycle, so any four consecutive missions cover every state
+var statePattern = []string{
+	models.MissionStateCompleted, models.MissionStateActive, models.MissionStateOverdue, models.MissionStateUnassigned,
+	models.MissionStateActive, models.MissionStateCompleted, models.MissionStateUnassigned, models.MissionStateActive,
+	models.MissionStateCompleted, models.MissionStateUnassigned,
+}
+
+type Generator struct {
+	repos          *repository.Repositories
+	missionService services.MissionService
+}
+
+func NewGenerator(repos *repository.Repositories, missionService services.MissionService) *Generator {
+	return &Generator{repos: repos, missionService: missionService}
+}
+
+type run struct {
+	*Generator
+	rng    *rand.Rand
+	now    time.Time
+	result *Result
+}
+
+// Generate creates the dataset. Cats are stored through the repository because their breeds are known to be valid;
+// missions, targets, notes and assignments go through the mission service and obey the same rules as API requests.
+func (g *Generator) Generate(opts Options) (*Result, error) {
+	if opts.Cats < 0 || opts.Missions < 0 {
+		return nil, fmt.Errorf("cats and missions cannot be negative")
+	}
+	currency := opts.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	r := &run{
+		Generator: g,
+		rng:       rand.New(rand.NewSource(opts.Seed)),
+		now:       time.Now(),
+		result:    &Result{Missions: make(map[string]int)},
+	}
+
+	cats, err := r.createCats(opts.Cats, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Completed missions come first: their cats are free again afterwards and can take an open mission
+	states := make([]string, opts.Missions)
+	for i := range states {
+		states[i] = statePattern[i%len(statePattern)]
+	}
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i] == models.MissionStateCompleted && states[j] != models.MissionStateCompleted
+	})
+
+	var active []*models.SpyCat
+	for _, cat := range cats {
+		if cat.Status == models.CatStatusActive {
+			active = append(active, cat)
+		}
+	}
+	free := append([]*models.SpyCat(nil), active...)
+	r.rng.Shuffle(len(free), func(i, j int) { free[i], free[j] = free[j], free[i] })
+
+	var overdue []uint
+	for _, state := range states {
+		var cat *models.SpyCat
+		switch {
+		case state == models.MissionStateCompleted && len(active) > 0:
+			cat = active[r.rng.Intn(len(active))]
+		case (state == models.MissionStateActive || state == models.MissionStateOverdue) && len(free) > 0:
+			cat, free = free[len(free)-1], free[:len(free)-1]
+		default:
+			// Without a cat to run it, a mission stays unassigned
+			state = models.MissionStateUnassigned
+		}
+
+		mission, err := r.createMission(state, cat)
+		if err != nil {
+			return nil, err
+		}
+		if state == models.MissionStateOverdue {
+			overdue = append(overdue, mission.ID)
+		}
+		r.result.Missions[state]++
+	}
+
+	if err := r.backdate(overdue); err != nil {
+		return nil, err
+	}
+	return r.result, nil
+}
+
+// At least half of the cats are active; the others may be suspended or retired
+func (r *run) createCats(count int, currency string) ([]*models.SpyCat, error) {
+	cats := make([]*models.SpyCat, 0, count)
+	for i := 0; i < count; i++ {
+		name := catNames[i%len(catNames)]
+		if i >= len(catNames) {
+			name = fmt.Sprintf("%s %d", name, i/len(catNames)+1)
+		}
+		years := 1 + r.rng.Intn(15)
+		salary, err := money.Parse(fmt.Sprint(5000+years*500+r.rng.Intn(40)*250), currency)
+		if err != nil {
+			return nil, err
+		}
+
+		status := models.CatStatusActive
+		if i >= (count+1)/2 {
+			switch r.rng.Intn(10) {
+			case 0:
+				status = models.CatStatusSuspended
+			case 1:
+				status = models.CatStatusRetired
+			}
+		}
+
+		cat := &models.SpyCat{
+			Name:            name,
+			YearsExperience: years,
+			Breed:           breeds[r.rng.Intn(len(breeds))],
+			Salary:          salary,
+			IsAvailable:     status == models.CatStatusActive,
+			Status:          status,
+		}
+		if status != models.CatStatusActive {
+			cat.StatusChangedAt = &r.now
+			cat.StatusReason = "seeded"
+		}
+		if err := r.repos.Cats.Create(cat); err != nil {
+			return nil, fmt.Errorf("failed to create cat %s: %w", name, err)
+		}
+		record := &models.SalaryRecord{CatID: cat.ID, Salary: cat.Salary, EffectiveFrom: cat.CreatedAt, Reason: "initial salary"}
+		if err := r.repos.Payroll.CreateSalaryRecord(record); err != nil {
+			return nil, fmt.Errorf("failed to record salary of %s: %w", name, err)
+		}
+		cats = append(cats, cat)
+	}
+	r.result.Cats = len(cats)
+	return cats, nil
+}
+
+func (r *run) createMission(state string, cat *models.SpyCat) (*models.Mission, error) {
+	deadline := r.now.AddDate(0, 0, 7+r.rng.Intn(60))
+	req := &models.CreateMissionRequest{DeadlineAt: &deadline}
+	if cat != nil {
+		req.CatID = &cat.ID
+	}
+	for i, count := 0, 1+r.rng.Intn(3); i < count; i++ {
+		req.Targets = append(req.Targets, r.targetRequest())
+	}
+
+	mission, err := r.missionService.CreateMission(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mission: %w", err)
+	}
+	if cat == nil {
+		return mission, nil
+	}
+
+	// Open missions keep at least one target to work on
+	completed := len(mission.Targets)
+	if state != models.MissionStateCompleted {
+		completed = r.rng.Intn(len(mission.Targets))
+	}
+
+	for i := range mission.Targets {
+		target := &mission.Targets[i]
+		var catID *uint
+		if r.rng.Intn(2) == 0 {
+			catID = &cat.ID
+			if _, err := r.missionService.AssignTargetCat(mission.ID, target.ID, &models.AssignTargetCatRequest{CatID: catID}); err != nil {
+				return nil, fmt.Errorf("failed to assign target: %w", err)
+			}
+		}
+		if err := r.addNotes(mission.ID, target, catID); err != nil {
+			return nil, err
+		}
+		if i < completed {
+			if err := r.missionService.CompleteTarget(mission.ID, target.ID, &models.CompleteTargetRequest{CatID: catID}); err != nil {
+				return nil, fmt.Errorf("failed to complete target: %w", err)
+			}
+		}
+	}
+
+	if state == models.MissionStateCompleted {
+		if err := r.missionService.CompleteMission(mission.ID); err != nil {
+			return nil, fmt.Errorf("failed to complete mission: %w", err)
+		}
+	}
+	return mission, nil
+}
+
+func (r *run) targetRequest() models.CreateTargetRequest {
+	city := cities[r.rng.Intn(len(cities))]
+	req := models.CreateTargetRequest{
+		Name:           firstNames[r.rng.Intn(len(firstNames))] + " " + lastNames[r.rng.Intn(len(lastNames))],
+		Country:        city.country,
+		Classification: classifications[r.rng.Intn(len(classifications))],
+	}
+	if r.rng.Intn(10) < 7 {
+		lat, lng := r.near(city.lat, city.lng)
+		req.Latitude, req.Longitude = &lat, &lng
+	}
+	return req
+}
+
+// Notes are sightings over the last days, in order, most of them with a location near the target
+func (r *run) addNotes(missionID uint, target *models.Target, catID *uint) error {
+	count := r.rng.Intn(4)
+	seenAt := r.now.Add(-time.Duration(count*24+r.rng.Intn(24)) * time.Hour)
+	for i := 0; i < count; i++ {
+		seenAt = seenAt.Add(time.Duration(1+r.rng.Intn(24)) * time.Hour)
+		if seenAt.After(r.now) {
+			seenAt = r.now
+		}
+		seen := seenAt
+		req := &models.AddTargetNoteRequest{
+			CatID:  catID,
+			Notes:  sightings[r.rng.Intn(len(sightings))],
+			SeenAt: &seen,
+		}
+		if target.Latitude != nil && r.rng.Intn(4) > 0 {
+			lat, lng := r.near(*target.Latitude, *target.Longitude)
+			req.Latitude, req.Longitude = &lat, &lng
+		}
+		if _, err := r.missionService.AddTargetNote(missionID, target.ID, req); err != nil {
+			return fmt.Errorf("failed to add note: %w", err)
+		}
+		r.result.Notes++
+	}
+	return nil
+}
+
+// near returns a point within a few kilometres
+func (r *run) near(lat, lng float64) (float64, float64) {
+	return lat + (r.rng.Float64()-0.5)*0.05, lng + (r.rng.Float64()-0.5)*0.05
+}
+
+// The service only accepts future deadlines, so overdue missions are created like active ones
+// and then moved into the past, as if their deadline had passed, before the overdue check flags them
+func (r *run) backdate(missionIDs []uint) error {
+	for _, id := range missionIDs {
+		mission, err := r.repos.Missions.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("mission not found: %w", err)
+		}
+		deadline := r.now.AddDate(0, 0, -1-r.rng.Intn(14))
+		mission.DeadlineAt = &deadline
+		if err := r.repos.Missions.Update(mission); err != nil {
+			return fmt.Errorf("failed to backdate mission: %w", err)
+		}
+	}
+	if len(missionIDs) == 0 {
+		return nil
+	}
+	_, err := r.repos.Missions.MarkOverdue(r.now)
+	return err
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const adminTokenPrefix = "sca_"
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type AdminService interface {
+	CreateAdmin(name string) (*models.AdminUser, string, error)
+	RotateKey(name string) (*models.AdminUser, string, error)
+	ListAdmins() ([]models.AdminUser, error)
+	Authenticate(token string) (*models.AdminUser, error)
+	RecordAudit(entry *models.AuditEntry) error
+	ListAudit(filter models.AuditFilter) ([]models.AuditEntry, error)
+}
+
+type adminService struct {
+	adminRepo repository.AdminRepository
+}
+
+func NewAdminService(adminRepo repository.AdminRepository) AdminService {
+	return &adminService{adminRepo: adminRepo}
+}
+
+// The token is only ever returned here; the database keeps its hash
+func (s *adminService) CreateAdmin(name string) (*models.AdminUser, string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) < 2 || len(name) > 100 {
+		return nil, "", fmt.Errorf("admin name must be between 2 and 100 characters")
+	}
+	if _, err := s.adminRepo.GetByName(name); err == nil {
+		return nil, "", fmt.Errorf("admin %q already exists", name)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", fmt.Errorf("failed to look up admin: %w", err)
+	}
+
+	token, err := newAdminToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	admin := &models.AdminUser{
+		Name:         name,
+		TokenHash:    hashAdminToken(token),
+		KeyRotatedAt: time.Now(),
+	}
+	if err := s.adminRepo.Create(admin); err != nil {
+		return nil, "", fmt.Errorf("failed to create admin: %w", err)
+	}
+
+	return admin, token, nil
+}
+
+// RotateKey replaces the admin's token; the old one stops working immediately
+func (s *adminService) RotateKey(name string) (*models.AdminUser, string, error) {
+	admin, err := s.adminRepo.GetByName(strings.TrimSpace(name))
+	if err != nil {
+		return nil, "", fmt.Errorf("admin not found: %w", err)
+	}
+
+	token, err := newAdminToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if err := s.adminRepo.SetTokenHash(admin.ID, hashAdminToken(token), now); err != nil {
+		return nil, "", fmt.Errorf("failed to rotate key: %w", err)
+	}
+	admin.KeyRotatedAt = now
+
+	return admin, token, nil
+}
+
+func (s *adminService) ListAdmins() ([]models.AdminUser, error) {
+	return s.adminRepo.GetAll()
+}
+
+func (s *adminService) Authenticate(token string) (*models.AdminUser, error) {
+	if !strings.HasPrefix(token, adminTokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	admin, err := s.adminRepo.GetByTokenHash(hashAdminToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up token: %w", err)
+	}
+
+	now := time.Now()
+	if err := s.adminRepo.TouchLastUsed(admin.ID, now); err != nil {
+		log.Printf("Failed to record last use of admin %d: %v", admin.ID, err)
+	}
+	admin.LastUsedAt = &now
+
+	return admin, nil
+}
+
+func (s *adminService) RecordAudit(entry *models.AuditEntry) error {
+	if err := s.adminRepo.CreateAuditEntry(entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+func (s *adminService) ListAudit(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	if filter.Limit <= 0 || filter.Limit > 1000 {
+		filter.Limit = 100
+	}
+	return s.adminRepo.GetAuditEntries(filter)
+}
+
+func newAdminToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return adminTokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// Tokens carry 256 random bits, so a fast unsalted hash is enough to keep them out of the database
+func hashAdminToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
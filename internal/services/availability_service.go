@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"time"
+)
+
+type AvailabilityService interface {
+	ListEntries(catID uint) ([]models.AvailabilityEntry, error)
+	AddEntry(catID uint, req *models.CreateAvailabilityEntryRequest) (*models.AvailabilityEntry, error)
+	DeleteEntry(catID, entryID uint) error
+	ListAvailableCats(from time.Time, to *time.Time) ([]models.SpyCat, error)
+}
+
+type availabilityService struct {
+	availabilityRepo repository.AvailabilityRepository
+	catRepo          repository.CatRepository
+}
+
+func NewAvailabilityService(availabilityRepo repository.AvailabilityRepository, catRepo repository.CatRepository) AvailabilityService {
+	return &availabilityService{
+		availabilityRepo: availabilityRepo,
+		catRepo:          catRepo,
+	}
+}
+
+func (s *availabilityService) ListEntries(catID uint) ([]models.AvailabilityEntry, error) {
+	if _, err := s.catRepo.GetByID(catID); err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	return s.availabilityRepo.GetByCatID(catID)
+}
+
+func (s *availabilityService) AddEntry(catID uint, req *models.CreateAvailabilityEntryRequest) (*models.AvailabilityEntry, error) {
+	if _, err := s.catRepo.GetByID(catID); err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		return nil, fmt.Errorf("entry must end after it starts")
+	}
+
+	overlapping, err := s.availabilityRepo.FindOverlapping(catID, req.StartsAt, req.EndsAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check calendar: %w", err)
+	}
+
+	for _, entry := range overlapping {
+		if entry.Kind == models.AvailabilityMission {
+			return nil, fmt.Errorf("cat is booked on mission %d during this period", *entry.MissionID)
+		}
+	}
+
+	entry := &models.AvailabilityEntry{
+		CatID:    catID,
+		Kind:     req.Kind,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Note:     req.Note,
+	}
+
+	if err := s.availabilityRepo.Create(entry); err != nil {
+		return nil, fmt.Errorf("failed to create calendar entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *availabilityService) DeleteEntry(catID, entryID uint) error {
+	entry, err := s.availabilityRepo.GetByID(entryID)
+	if err != nil {
+		return fmt.Errorf("calendar entry not found: %w", err)
+	}
+
+	if entry.CatID != catID {
+		return fmt.Errorf("calendar entry does not belong to this cat")
+	}
+
+	if entry.Kind == models.AvailabilityMission {
+		return fmt.Errorf("mission bookings are managed by the mission")
+	}
+
+	return s.availabilityRepo.Delete(entryID)
+}
+
+func (s *availabilityService) ListAvailableCats(from time.Time, to *time.Time) ([]models.SpyCat, error) {
+	if to != nil && !to.After(from) {
+		return nil, fmt.Errorf("'to' must be after 'from'")
+	}
+
+	// Without an end date only the instant 'from' is checked
+	if to == nil {
+		instant := from.Add(time.Nanosecond)
+		to = &instant
+	}
+
+	cats, err := s.catRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cats: %w", err)
+	}
+
+	unavailableIDs, err := s.availabilityRepo.GetUnavailableCatIDs(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check calendar: %w", err)
+	}
+
+	unavailable := make(map[uint]bool, len(unavailableIDs))
+	for _, id := range unavailableIDs {
+		unavailable[id] = true
+	}
+
+	available := make([]models.SpyCat, 0, len(cats))
+	for _, cat := range cats {
+		if cat.Status == models.CatStatusActive && !unavailable[cat.ID] {
+			available = append(available, cat)
+		}
+	}
+
+	return available, nil
+}
@@ -6,25 +6,42 @@ import (
 	"io"
 	"net/http"
 	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
 	"spy-cat-agency/internal/repository"
+	"sync"
 	"time"
 )
 
 type CatService interface {
 	CreateCat(req *models.CreateCatRequest) (*models.SpyCat, error)
 	GetCat(id uint) (*models.SpyCat, error)
-	ListCats() ([]models.SpyCat, error)
+	ListCats(filter models.CatFilter) ([]models.SpyCat, error)
 	UpdateCat(id uint, req *models.UpdateCatRequest) (*models.SpyCat, error)
-	DeleteCat(id uint) error
+	DeleteCat(id uint, reassignTo *uint) error
+	ChangeStatus(id uint, req *models.ChangeCatStatusRequest) (*models.SpyCat, error)
 	ValidateBreed(breed string) error
+	ValidateSalary(salary money.Money) (money.Money, error)
+	TotalSalaries(currency string) (*models.SalaryTotals, error)
 }
 
 type catService struct {
-	catRepo repository.CatRepository
+	catRepo        repository.CatRepository
+	payrollRepo    repository.PayrollRepository
+	missionRepo    repository.MissionRepository
+	missionService MissionService
+	rates          *money.Rates
+	currency       string
 }
 
-func NewCatService(catRepo repository.CatRepository) CatService {
-	return &catService{catRepo: catRepo}
+func NewCatService(catRepo repository.CatRepository, payrollRepo repository.PayrollRepository, missionRepo repository.MissionRepository, missionService MissionService, rates *money.Rates, currency string) CatService {
+	return &catService{
+		catRepo:        catRepo,
+		payrollRepo:    payrollRepo,
+		missionRepo:    missionRepo,
+		missionService: missionService,
+		rates:          rates,
+		currency:       currency,
+	}
 }
 
 func (s *catService) CreateCat(req *models.CreateCatRequest) (*models.SpyCat, error) {
@@ -33,18 +50,34 @@ func (s *catService) CreateCat(req *models.CreateCatRequest) (*models.SpyCat, er
 		return nil, fmt.Errorf("invalid breed: %w", err)
 	}
 
+	salary, err := s.ValidateSalary(req.Salary)
+	if err != nil {
+		return nil, err
+	}
+
 	cat := &models.SpyCat{
 		Name:            req.Name,
 		YearsExperience: req.YearsExperience,
 		Breed:           req.Breed,
-		Salary:          req.Salary,
+		Salary:          salary,
 		IsAvailable:     true,
+		Status:          models.CatStatusActive,
 	}
 
 	if err := s.catRepo.Create(cat); err != nil {
 		return nil, fmt.Errorf("failed to create cat: %w", err)
 	}
 
+	record := &models.SalaryRecord{
+		CatID:         cat.ID,
+		Salary:        cat.Salary,
+		EffectiveFrom: cat.CreatedAt,
+		Reason:        "initial salary",
+	}
+	if err := s.payrollRepo.CreateSalaryRecord(record); err != nil {
+		return nil, fmt.Errorf("failed to record salary: %w", err)
+	}
+
 	return cat, nil
 }
 
@@ -52,8 +85,8 @@ func (s *catService) GetCat(id uint) (*models.SpyCat, error) {
 	return s.catRepo.GetByID(id)
 }
 
-func (s *catService) ListCats() ([]models.SpyCat, error) {
-	return s.catRepo.GetAll()
+func (s *catService) ListCats(filter models.CatFilter) ([]models.SpyCat, error) {
+	return s.catRepo.List(filter)
 }
 
 func (s *catService) UpdateCat(id uint, req *models.UpdateCatRequest) (*models.SpyCat, error) {
@@ -62,7 +95,56 @@ func (s *catService) UpdateCat(id uint, req *models.UpdateCatRequest) (*models.S
 		return nil, fmt.Errorf("cat not found: %w", err)
 	}
 
-	cat.Salary = req.Salary
+	salary, err := s.ValidateSalary(req.Salary)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveFrom := time.Now()
+	if req.EffectiveFrom != nil {
+		if req.EffectiveFrom.After(effectiveFrom) {
+			return nil, fmt.Errorf("salary change cannot take effect in the future")
+		}
+		effectiveFrom = *req.EffectiveFrom
+	}
+
+	// Cats created before salary history existed get their previous salary recorded first
+	history, err := s.payrollRepo.GetSalaryHistory(cat.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load salary history: %w", err)
+	}
+	if len(history) == 0 {
+		previous := &models.SalaryRecord{
+			CatID:         cat.ID,
+			Salary:        cat.Salary,
+			EffectiveFrom: cat.CreatedAt,
+			Reason:        "initial salary",
+		}
+		if err := s.payrollRepo.CreateSalaryRecord(previous); err != nil {
+			return nil, fmt.Errorf("failed to record previous salary: %w", err)
+		}
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "salary update"
+	}
+	record := &models.SalaryRecord{
+		CatID:         cat.ID,
+		Salary:        salary,
+		EffectiveFrom: effectiveFrom,
+		Reason:        reason,
+	}
+	if err := s.payrollRepo.CreateSalaryRecord(record); err != nil {
+		return nil, fmt.Errorf("failed to record salary change: %w", err)
+	}
+
+	// A backdated change may fall before a later one, so the current salary is the latest in effect
+	history, err = s.payrollRepo.GetSalaryHistory(cat.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load salary history: %w", err)
+	}
+	cat.Salary = history[len(history)-1].Salary
 
 	if err := s.catRepo.Update(cat); err != nil {
 		return nil, fmt.Errorf("failed to update cat: %w", err)
@@ -71,33 +153,196 @@ func (s *catService) UpdateCat(id uint, req *models.UpdateCatRequest) (*models.S
 	return cat, nil
 }
 
-func (s *catService) DeleteCat(id uint) error {
-	// Check if cat has active mission
-	// This will be implemented when we have mission service
+func (s *catService) DeleteCat(id uint, reassignTo *uint) error {
+	if _, err := s.catRepo.GetByID(id); err != nil {
+		return fmt.Errorf("cat not found: %w", err)
+	}
+
+	if err := s.checkActiveMissions(id, reassignTo, "deleting"); err != nil {
+		return err
+	}
+
+	if err := s.missionService.ReleaseCat(id, reassignTo); err != nil {
+		return err
+	}
+
 	return s.catRepo.Delete(id)
 }
 
+func (s *catService) ChangeStatus(id uint, req *models.ChangeCatStatusRequest) (*models.SpyCat, error) {
+	cat, err := s.catRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	if cat.Status == models.CatStatusKIA {
+		return nil, fmt.Errorf("cat is KIA; its status cannot be changed")
+	}
+	if cat.Status == req.Status {
+		return nil, fmt.Errorf("cat is already %s", req.Status)
+	}
+
+	switch req.Status {
+	case models.CatStatusRetired:
+		if err := s.checkActiveMissions(id, req.ReassignTo, "retiring"); err != nil {
+			return nil, err
+		}
+		if err := s.missionService.ReleaseCat(id, req.ReassignTo); err != nil {
+			return nil, err
+		}
+	case models.CatStatusKIA:
+		// A fallen cat cannot carry on; its missions are reassigned or left open
+		if err := s.missionService.ReleaseCat(id, req.ReassignTo); err != nil {
+			return nil, err
+		}
+	case models.CatStatusSuspended:
+		// Suspended cats keep their current missions unless asked otherwise
+		if req.ReassignTo != nil {
+			if err := s.missionService.ReleaseCat(id, req.ReassignTo); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Reload, since releasing missions may have changed availability
+	cat, err = s.catRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	now := time.Now()
+	cat.Status = req.Status
+	cat.StatusReason = req.Reason
+	cat.StatusChangedAt = &now
+
+	if err := s.catRepo.Update(cat); err != nil {
+		return nil, fmt.Errorf("failed to update cat status: %w", err)
+	}
+
+	return cat, nil
+}
+
+func (s *catService) checkActiveMissions(catID uint, reassignTo *uint, action string) error {
+	if reassignTo != nil {
+		return nil
+	}
+
+	missions, err := s.missionRepo.GetActiveByCatID(catID)
+	if err != nil {
+		return fmt.Errorf("failed to load active missions: %w", err)
+	}
+	if len(missions) > 0 {
+		return fmt.Errorf("cat is on active mission %d; reassign its missions before %s it", missions[0].ID, action)
+	}
+
+	return nil
+}
+
+func (s *catService) TotalSalaries(currency string) (*models.SalaryTotals, error) {
+	if currency == "" {
+		currency = s.currency
+	}
+	code, ok := money.NormalizeCurrency(currency)
+	if !ok {
+		return nil, fmt.Errorf("unknown currency %q", currency)
+	}
+
+	cats, err := s.catRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cats: %w", err)
+	}
+
+	totals := &models.SalaryTotals{
+		Total:      money.Zero(code),
+		Cats:       len(cats),
+		ByCurrency: make(map[string]money.Money),
+	}
+	for _, cat := range cats {
+		subtotal, ok := totals.ByCurrency[cat.Salary.Currency]
+		if !ok {
+			subtotal = money.Zero(cat.Salary.Currency)
+		}
+		subtotal, err = subtotal.Add(cat.Salary)
+		if err != nil {
+			return nil, err
+		}
+		totals.ByCurrency[cat.Salary.Currency] = subtotal
+	}
+
+	for _, subtotal := range totals.ByCurrency {
+		converted, err := s.rates.Convert(subtotal, code)
+		if err != nil {
+			return nil, err
+		}
+		totals.Total.Amount += converted.Amount
+	}
+
+	return totals, nil
+}
+
+func (s *catService) ValidateSalary(salary money.Money) (money.Money, error) {
+	salary, err := salary.OrCurrency(s.currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid salary: %w", err)
+	}
+	if !salary.IsPositive() {
+		return money.Money{}, fmt.Errorf("salary must be positive")
+	}
+	// Salaries must be convertible so budgets can be aggregated across offices
+	if _, err := s.rates.Convert(salary, s.rates.Base()); err != nil {
+		return money.Money{}, fmt.Errorf("invalid salary: %w", err)
+	}
+	return salary, nil
+}
+
+// TheCatAPI breed list rarely changes, so it is fetched at most once per breedCacheTTL.
+// Bulk imports check hundreds of cats and would otherwise fetch it for every one of them.
+const breedCacheTTL = time.Hour
+
+var breedCache struct {
+	sync.Mutex
+	names     map[string]bool
+	fetchedAt time.Time
+}
+
 func (s *catService) ValidateBreed(breed string) error {
+	names, err := knownBreeds()
+	if err != nil {
+		return err
+	}
+	if !names[breed] {
+		return fmt.Errorf("breed '%s' not found in TheCatAPI", breed)
+	}
+	return nil
+}
+
+func knownBreeds() (map[string]bool, error) {
+	breedCache.Lock()
+	defer breedCache.Unlock()
+	if breedCache.names != nil && time.Since(breedCache.fetchedAt) < breedCacheTTL {
+		return breedCache.names, nil
+	}
+
 	client := &http.Client{Timeout: 10 * time.Second}
 
 	req, err := http.NewRequest("GET", "https://api.thecatapi.com/v1/breeds", nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch breeds: %w", err)
+		return nil, fmt.Errorf("failed to fetch breeds: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var breeds []struct {
@@ -105,14 +350,14 @@ func (s *catService) ValidateBreed(breed string) error {
 	}
 
 	if err := json.Unmarshal(body, &breeds); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	names := make(map[string]bool, len(breeds))
 	for _, b := range breeds {
-		if b.Name == breed {
-			return nil
-		}
+		names[b.Name] = true
 	}
-
-	return fmt.Errorf("breed '%s' not found in TheCatAPI", breed)
+	breedCache.names = names
+	breedCache.fetchedAt = time.Now()
+	return names, nil
 }
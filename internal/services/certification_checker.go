@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"log"
+	"spy-cat-agency/internal/repository"
+	"time"
+)
+
+type CertificationChecker struct {
+	skillRepo repository.SkillRepository
+	interval  time.Duration
+	window    time.Duration
+}
+
+func NewCertificationChecker(skillRepo repository.SkillRepository, interval, window time.Duration) *CertificationChecker {
+	return &CertificationChecker{
+		skillRepo: skillRepo,
+		interval:  interval,
+		window:    window,
+	}
+}
+
+func (c *CertificationChecker) Start(ctx context.Context) {
+	runPeriodically(ctx, "certification check", c.interval, c.Check)
+}
+
+func (c *CertificationChecker) Check() {
+	now := time.Now()
+	expiring, err := c.skillRepo.GetExpiringCatSkills(now, now.Add(c.window))
+	if err != nil {
+		log.Printf("Certification expiry check failed: %v", err)
+		return
+	}
+
+	for _, catSkill := range expiring {
+		catName, skillName := "unknown", "unknown"
+		if catSkill.Cat != nil {
+			catName = catSkill.Cat.Name
+		}
+		if catSkill.Skill != nil {
+			skillName = catSkill.Skill.Name
+		}
+		log.Printf("WARNING: %s certification of cat %d (%s) expires on %s",
+			skillName, catSkill.CatID, catName, catSkill.ExpiresAt.Format("2006-01-02"))
+	}
+}
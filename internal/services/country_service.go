@@ -0,0 +1,28 @@
+package services
+
+import (
+	"spy-cat-agency/internal/country"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+)
+
+type CountryService interface {
+	ListCountries(query string) []country.Country
+	ListUnmapped() ([]models.UnmappedCountry, error)
+}
+
+type countryService struct {
+	countryRepo repository.CountryRepository
+}
+
+func NewCountryService(countryRepo repository.CountryRepository) CountryService {
+	return &countryService{countryRepo: countryRepo}
+}
+
+func (s *countryService) ListCountries(query string) []country.Country {
+	return country.Search(query)
+}
+
+func (s *countryService) ListUnmapped() ([]models.UnmappedCountry, error) {
+	return s.countryRepo.GetUnmapped()
+}
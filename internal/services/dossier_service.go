@@ -0,0 +1,452 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"spy-cat-agency/internal/country"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	dossierMatchThreshold = 0.7
+	dossierCountryBoost   = 0.1
+	defaultSuggestLimit   = 5
+)
+
+type DossierService interface {
+	CreateDossier(req *models.CreateDossierRequest) (*models.Dossier, error)
+	GetDossier(id uint) (*models.Dossier, error)
+	ListDossiers() ([]models.Dossier, error)
+	UpdateDossier(id uint, req *models.UpdateDossierRequest) (*models.Dossier, error)
+	DeleteDossier(id uint) error
+	AddPhoto(id uint, req *models.AddDossierPhotoRequest) (*models.DossierPhoto, error)
+	DeletePhoto(id, photoID uint) error
+	SuggestDossiers(name, country string, limit int) ([]models.DossierMatch, error)
+	SuggestForTarget(missionID, targetID uint) ([]models.DossierMatch, error)
+	LinkTarget(missionID, targetID uint, req *models.LinkTargetDossierRequest) (*models.Target, error)
+	GetDossierView(id uint) (*models.DossierView, error)
+}
+
+type dossierService struct {
+	dossierRepo repository.DossierRepository
+	targetRepo  repository.TargetRepository
+}
+
+func NewDossierService(dossierRepo repository.DossierRepository, targetRepo repository.TargetRepository) DossierService {
+	return &dossierService{
+		dossierRepo: dossierRepo,
+		targetRepo:  targetRepo,
+	}
+}
+
+func (s *dossierService) CreateDossier(req *models.CreateDossierRequest) (*models.Dossier, error) {
+	dossier := &models.Dossier{
+		Name:    strings.TrimSpace(req.Name),
+		Summary: req.Summary,
+	}
+	if err := setDossierLists(dossier, req.Aliases, req.Countries); err != nil {
+		return nil, err
+	}
+
+	if err := s.dossierRepo.Create(dossier); err != nil {
+		return nil, fmt.Errorf("failed to create dossier: %w", err)
+	}
+
+	return s.dossierRepo.GetByID(dossier.ID)
+}
+
+func (s *dossierService) GetDossier(id uint) (*models.Dossier, error) {
+	return s.dossierRepo.GetByID(id)
+}
+
+func (s *dossierService) ListDossiers() ([]models.Dossier, error) {
+	return s.dossierRepo.GetAll()
+}
+
+func (s *dossierService) UpdateDossier(id uint, req *models.UpdateDossierRequest) (*models.Dossier, error) {
+	dossier, err := s.dossierRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("dossier not found: %w", err)
+	}
+
+	dossier.Name = strings.TrimSpace(req.Name)
+	dossier.Summary = req.Summary
+	if err := setDossierLists(dossier, req.Aliases, req.Countries); err != nil {
+		return nil, err
+	}
+
+	if err := s.dossierRepo.Update(dossier); err != nil {
+		return nil, fmt.Errorf("failed to update dossier: %w", err)
+	}
+
+	return s.dossierRepo.GetByID(id)
+}
+
+func (s *dossierService) DeleteDossier(id uint) error {
+	if _, err := s.dossierRepo.GetByID(id); err != nil {
+		return fmt.Errorf("dossier not found: %w", err)
+	}
+	return s.dossierRepo.Delete(id)
+}
+
+func (s *dossierService) AddPhoto(id uint, req *models.AddDossierPhotoRequest) (*models.DossierPhoto, error) {
+	if _, err := s.dossierRepo.GetByID(id); err != nil {
+		return nil, fmt.Errorf("dossier not found: %w", err)
+	}
+
+	photo := &models.DossierPhoto{
+		DossierID: id,
+		URL:       req.URL,
+		Caption:   req.Caption,
+		TakenAt:   req.TakenAt,
+	}
+
+	if err := s.dossierRepo.AddPhoto(photo); err != nil {
+		return nil, fmt.Errorf("failed to add photo: %w", err)
+	}
+
+	return photo, nil
+}
+
+func (s *dossierService) DeletePhoto(id, photoID uint) error {
+	deleted, err := s.dossierRepo.DeletePhoto(id, photoID)
+	if err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("photo not found in this dossier")
+	}
+	return nil
+}
+
+// Candidates are scored on the closest of their name and aliases, with a small boost for a known country
+func (s *dossierService) SuggestDossiers(name, countryInput string, limit int) ([]models.DossierMatch, error) {
+	query := normalizePersonName(name)
+	if query == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+
+	code := ""
+	if strings.TrimSpace(countryInput) != "" {
+		normalized, _, err := country.Normalize(countryInput)
+		if err != nil {
+			return nil, err
+		}
+		code = normalized
+	}
+
+	dossiers, err := s.dossierRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dossiers: %w", err)
+	}
+
+	matches := make([]models.DossierMatch, 0)
+	for _, dossier := range dossiers {
+		match := models.DossierMatch{Dossier: dossier}
+
+		candidates := []string{dossier.Name}
+		for _, alias := range dossier.Aliases {
+			candidates = append(candidates, alias.Alias)
+		}
+		for _, candidate := range candidates {
+			if score := nameSimilarity(query, normalizePersonName(candidate)); score > match.Score {
+				match.Score = score
+				match.MatchedOn = candidate
+			}
+		}
+		if match.Score < dossierMatchThreshold {
+			continue
+		}
+
+		if code != "" {
+			for _, known := range dossier.Countries {
+				if known.Country == code {
+					match.SameCountry = true
+					match.Score += dossierCountryBoost
+					break
+				}
+			}
+		}
+
+		match.Score = float64(int(match.Score*1000+0.5)) / 1000
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
+
+func (s *dossierService) SuggestForTarget(missionID, targetID uint) ([]models.DossierMatch, error) {
+	target, err := s.targetRepo.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.MissionID != missionID {
+		return nil, fmt.Errorf("target does not belong to this mission")
+	}
+
+	matches, err := s.SuggestDossiers(target.Name, target.Country, defaultSuggestLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dossier the target is already linked to is not a suggestion
+	if target.DossierID != nil {
+		filtered := matches[:0]
+		for _, match := range matches {
+			if match.Dossier.ID != *target.DossierID {
+				filtered = append(filtered, match)
+			}
+		}
+		matches = filtered
+	}
+
+	return matches, nil
+}
+
+// Linking teaches the dossier the target's country and, unless pseudonymized, the name it was known by
+func (s *dossierService) LinkTarget(missionID, targetID uint, req *models.LinkTargetDossierRequest) (*models.Target, error) {
+	target, err := s.targetRepo.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.MissionID != missionID {
+		return nil, fmt.Errorf("target does not belong to this mission")
+	}
+
+	if req.DossierID != nil {
+		dossier, err := s.dossierRepo.GetByID(*req.DossierID)
+		if err != nil {
+			return nil, fmt.Errorf("dossier not found: %w", err)
+		}
+
+		if target.PseudonymizedAt == nil && !dossierKnowsName(dossier, target.Name) {
+			dossier.Aliases = append(dossier.Aliases, models.DossierAlias{Alias: strings.TrimSpace(target.Name)})
+			if err := s.dossierRepo.Update(dossier); err != nil {
+				return nil, fmt.Errorf("failed to record alias: %w", err)
+			}
+		}
+
+		// Targets whose country could not be migrated have no display name and are not recorded
+		if target.CountryName != "" {
+			if err := s.dossierRepo.AddCountry(dossier.ID, target.Country, target.CountryName); err != nil {
+				return nil, fmt.Errorf("failed to record country: %w", err)
+			}
+		}
+	}
+
+	if err := s.targetRepo.SetDossier(targetID, req.DossierID); err != nil {
+		return nil, fmt.Errorf("failed to link target: %w", err)
+	}
+
+	return s.targetRepo.GetByID(targetID)
+}
+
+func (s *dossierService) GetDossierView(id uint) (*models.DossierView, error) {
+	dossier, err := s.dossierRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("dossier not found: %w", err)
+	}
+
+	targets, err := s.dossierRepo.GetTargets(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load targets: %w", err)
+	}
+
+	view := &models.DossierView{
+		Dossier:       *dossier,
+		CountriesSeen: []string{},
+		Appearances:   make([]models.DossierAppearance, 0, len(targets)),
+	}
+
+	missions := make(map[uint]bool)
+	countries := make(map[string]bool)
+	for _, target := range targets {
+		appearance := models.DossierAppearance{
+			MissionID:      target.MissionID,
+			TargetID:       target.ID,
+			Name:           target.Name,
+			Country:        target.Country,
+			Classification: target.Classification,
+			Notes:          target.Notes,
+			CatID:          target.CatID,
+			Outcome:        targetOutcome(&target),
+			Deleted:        target.DeletedAt.Valid,
+		}
+		if target.Cat != nil {
+			appearance.CatName = target.Cat.Name
+		}
+
+		seenAt := target.CreatedAt
+		if target.Mission != nil {
+			appearance.MissionCompleted = target.Mission.IsCompleted
+			appearance.StartedAt = target.Mission.StartedAt
+			appearance.EndedAt = target.Mission.EndedAt
+			appearance.Deleted = appearance.Deleted || target.Mission.DeletedAt.Valid
+			if target.Mission.EndedAt != nil {
+				seenAt = *target.Mission.EndedAt
+			}
+		}
+		view.FirstSeenAt = earliest(view.FirstSeenAt, target.CreatedAt)
+		view.LastSeenAt = latest(view.LastSeenAt, seenAt)
+
+		if target.IsCompleted {
+			view.TargetsCompleted++
+		} else {
+			view.TargetsOpen++
+		}
+
+		missions[target.MissionID] = true
+		if !countries[target.Country] {
+			countries[target.Country] = true
+			view.CountriesSeen = append(view.CountriesSeen, target.Country)
+		}
+
+		view.Appearances = append(view.Appearances, appearance)
+	}
+	view.Missions = len(missions)
+	sort.Strings(view.CountriesSeen)
+
+	return view, nil
+}
+
+func targetOutcome(target *models.Target) string {
+	switch {
+	case target.IsCompleted:
+		return "completed"
+	case target.Mission != nil && target.Mission.DeletedAt.Valid:
+		return "abandoned"
+	case target.Mission != nil && target.Mission.StartedAt != nil:
+		return "in_progress"
+	default:
+		return "pending"
+	}
+}
+
+func earliest(current *time.Time, t time.Time) *time.Time {
+	if current == nil || t.Before(*current) {
+		return &t
+	}
+	return current
+}
+
+func latest(current *time.Time, t time.Time) *time.Time {
+	if current == nil || t.After(*current) {
+		return &t
+	}
+	return current
+}
+
+// Duplicates are dropped case-insensitively and an alias equal to the name is not kept
+func setDossierLists(dossier *models.Dossier, aliases, countries []string) error {
+	seen := map[string]bool{normalizePersonName(dossier.Name): true}
+	dossier.Aliases = nil
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := normalizePersonName(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		dossier.Aliases = append(dossier.Aliases, models.DossierAlias{Alias: alias})
+	}
+
+	seen = make(map[string]bool)
+	dossier.Countries = nil
+	for _, input := range countries {
+		code, name, err := country.Normalize(input)
+		if err != nil {
+			return err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		dossier.Countries = append(dossier.Countries, models.DossierCountry{Country: code, Name: name})
+	}
+	return nil
+}
+
+func dossierKnowsName(dossier *models.Dossier, name string) bool {
+	key := normalizePersonName(name)
+	if normalizePersonName(dossier.Name) == key {
+		return true
+	}
+	for _, alias := range dossier.Aliases {
+		if normalizePersonName(alias.Alias) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Punctuation is dropped so "J. Doe" and "J Doe" compare equal
+func normalizePersonName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// Names are compared as written and with their words sorted, so "Doe John" still matches "John Doe"
+func nameSimilarity(a, b string) float64 {
+	score := levenshteinRatio(a, b)
+	if sorted := levenshteinRatio(sortWords(a), sortWords(b)); sorted > score {
+		score = sorted
+	}
+	return score
+}
+
+func sortWords(s string) string {
+	words := strings.Fields(s)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func levenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"time"
+)
+
+type ExpenseService interface {
+	SetBudget(missionID uint, req *models.SetMissionBudgetRequest) (*models.Mission, error)
+	ListExpenses(missionID uint) ([]models.Expense, error)
+	CreateExpense(missionID uint, req *models.CreateExpenseRequest) (*models.Expense, error)
+	ApproveExpense(missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error)
+	RejectExpense(missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error)
+	DeleteExpense(missionID, expenseID uint) error
+	GetSummary(missionID uint) (*models.ExpenseSummary, error)
+}
+
+type expenseService struct {
+	expenseRepo      repository.ExpenseRepository
+	missionRepo      repository.MissionRepository
+	rates            *money.Rates
+	currency         string
+	warningThreshold float64
+}
+
+func NewExpenseService(expenseRepo repository.ExpenseRepository, missionRepo repository.MissionRepository, rates *money.Rates, currency string, warningThreshold float64) ExpenseService {
+	return &expenseService{
+		expenseRepo:      expenseRepo,
+		missionRepo:      missionRepo,
+		rates:            rates,
+		currency:         currency,
+		warningThreshold: warningThreshold,
+	}
+}
+
+func (s *expenseService) SetBudget(missionID uint, req *models.SetMissionBudgetRequest) (*models.Mission, error) {
+	if _, err := s.missionRepo.GetByID(missionID); err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	budget, err := req.Budget.OrCurrency(s.currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid budget: %w", err)
+	}
+	if budget.Amount < 0 {
+		return nil, fmt.Errorf("budget cannot be negative")
+	}
+	if _, err := s.rates.Convert(budget, s.currency); err != nil {
+		return nil, fmt.Errorf("invalid budget: %w", err)
+	}
+
+	if err := s.missionRepo.SetBudget(missionID, budget); err != nil {
+		return nil, fmt.Errorf("failed to set budget: %w", err)
+	}
+
+	return s.missionRepo.GetByID(missionID)
+}
+
+func (s *expenseService) ListExpenses(missionID uint) ([]models.Expense, error) {
+	if _, err := s.missionRepo.GetByID(missionID); err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+	return s.expenseRepo.GetByMissionID(missionID)
+}
+
+func (s *expenseService) CreateExpense(missionID uint, req *models.CreateExpenseRequest) (*models.Expense, error) {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	// New expenses start as pending, which a completed mission no longer takes
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("mission is completed; new expenses are not accepted")
+	}
+
+	if !missionHasCat(mission, req.CatID) {
+		return nil, fmt.Errorf("cat %d is not assigned to this mission", req.CatID)
+	}
+
+	amount, err := req.Amount.OrCurrency(s.currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if _, err := s.rates.Convert(amount, s.budgetCurrency(mission)); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	expense := &models.Expense{
+		MissionID:   missionID,
+		CatID:       req.CatID,
+		Category:    req.Category,
+		Amount:      amount,
+		Description: req.Description,
+		ReceiptRef:  req.ReceiptRef,
+		Status:      models.ExpenseStatusPending,
+	}
+
+	if err := s.expenseRepo.Create(expense); err != nil {
+		return nil, fmt.Errorf("failed to create expense: %w", err)
+	}
+
+	s.logWarnings(mission)
+	return expense, nil
+}
+
+func (s *expenseService) ApproveExpense(missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error) {
+	expense, err := s.review(missionID, expenseID, models.ExpenseStatusApproved, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if mission, err := s.missionRepo.GetByID(missionID); err == nil {
+		s.logWarnings(mission)
+	}
+	return expense, nil
+}
+
+func (s *expenseService) RejectExpense(missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error) {
+	return s.review(missionID, expenseID, models.ExpenseStatusRejected, req)
+}
+
+func (s *expenseService) DeleteExpense(missionID, expenseID uint) error {
+	expense, err := s.getMissionExpense(missionID, expenseID)
+	if err != nil {
+		return err
+	}
+
+	if expense.Status != models.ExpenseStatusPending {
+		return fmt.Errorf("cannot delete %s expense", expense.Status)
+	}
+
+	return s.expenseRepo.Delete(expenseID)
+}
+
+func (s *expenseService) GetSummary(missionID uint) (*models.ExpenseSummary, error) {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	return s.summarize(mission)
+}
+
+func (s *expenseService) review(missionID, expenseID uint, status string, req *models.ReviewExpenseRequest) (*models.Expense, error) {
+	expense, err := s.getMissionExpense(missionID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expense.Status != models.ExpenseStatusPending {
+		return nil, fmt.Errorf("expense has already been %s", expense.Status)
+	}
+
+	now := time.Now()
+	expense.Status = status
+	expense.ReviewNote = req.Note
+	expense.ReviewedAt = &now
+
+	if err := s.expenseRepo.Update(expense); err != nil {
+		return nil, fmt.Errorf("failed to update expense: %w", err)
+	}
+
+	return expense, nil
+}
+
+func (s *expenseService) getMissionExpense(missionID, expenseID uint) (*models.Expense, error) {
+	expense, err := s.expenseRepo.GetByID(expenseID)
+	if err != nil {
+		return nil, fmt.Errorf("expense not found: %w", err)
+	}
+
+	if expense.MissionID != missionID {
+		return nil, fmt.Errorf("expense does not belong to this mission")
+	}
+
+	return expense, nil
+}
+
+// Missions without a budget report their expenses in the default currency
+func (s *expenseService) budgetCurrency(mission *models.Mission) string {
+	if mission.Budget.IsPositive() {
+		return mission.Budget.Currency
+	}
+	return s.currency
+}
+
+func (s *expenseService) summarize(mission *models.Mission) (*models.ExpenseSummary, error) {
+	expenses, err := s.expenseRepo.GetByMissionID(mission.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load expenses: %w", err)
+	}
+
+	currency := s.budgetCurrency(mission)
+	summary := &models.ExpenseSummary{
+		MissionID:  mission.ID,
+		Budget:     money.Zero(currency),
+		Approved:   money.Zero(currency),
+		Pending:    money.Zero(currency),
+		Rejected:   money.Zero(currency),
+		Remaining:  money.Zero(currency),
+		ByCategory: make(map[string]money.Money),
+		Warnings:   []string{},
+	}
+	if mission.Budget.IsPositive() {
+		summary.Budget = mission.Budget
+	}
+
+	for _, expense := range expenses {
+		amount, err := s.rates.Convert(expense.Amount, currency)
+		if err != nil {
+			return nil, err
+		}
+
+		switch expense.Status {
+		case models.ExpenseStatusApproved:
+			summary.Approved.Amount += amount.Amount
+		case models.ExpenseStatusPending:
+			summary.Pending.Amount += amount.Amount
+		default:
+			summary.Rejected.Amount += amount.Amount
+			continue
+		}
+
+		category, ok := summary.ByCategory[expense.Category]
+		if !ok {
+			category = money.Zero(currency)
+		}
+		category.Amount += amount.Amount
+		summary.ByCategory[expense.Category] = category
+	}
+
+	committed := summary.Approved.Amount + summary.Pending.Amount
+	if !summary.Budget.IsPositive() {
+		if committed > 0 {
+			summary.Warnings = append(summary.Warnings, "mission has expenses but no budget")
+		}
+		return summary, nil
+	}
+
+	summary.Remaining.Amount = summary.Budget.Amount - summary.Approved.Amount
+	summary.UsedPercent = math.Round(float64(committed)/float64(summary.Budget.Amount)*10000) / 100
+
+	switch {
+	case summary.Approved.Amount > summary.Budget.Amount:
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("approved expenses of %s exceed the budget of %s", summary.Approved, summary.Budget))
+	case committed > summary.Budget.Amount:
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("pending expenses would exceed the budget of %s", summary.Budget))
+	case summary.UsedPercent >= s.warningThreshold:
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("expenses have reached %.2f%% of the budget", summary.UsedPercent))
+	}
+
+	return summary, nil
+}
+
+func (s *expenseService) logWarnings(mission *models.Mission) {
+	summary, err := s.summarize(mission)
+	if err != nil {
+		log.Printf("Expense check for mission %d failed: %v", mission.ID, err)
+		return
+	}
+
+	for _, warning := range summary.Warnings {
+		log.Printf("WARNING: mission %d: %s", mission.ID, warning)
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"io"
+
+	"spy-cat-agency/internal/export"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+)
+
+// ExportService writes cats, missions and targets as they are read from the database,
+// so an export never holds the whole table in memory
+type ExportService interface {
+	ExportCats(w io.Writer, format string, filter models.CatFilter) error
+	ExportMissions(w io.Writer, format string, filter models.MissionFilter) error
+	ExportTargets(w io.Writer, format string, filter models.TargetFilter) error
+}
+
+type exportService struct {
+	catRepo     repository.CatRepository
+	missionRepo repository.MissionRepository
+	targetRepo  repository.TargetRepository
+}
+
+func NewExportService(catRepo repository.CatRepository, missionRepo repository.MissionRepository, targetRepo repository.TargetRepository) ExportService {
+	return &exportService{
+		catRepo:     catRepo,
+		missionRepo: missionRepo,
+		targetRepo:  targetRepo,
+	}
+}
+
+var catExportColumns = []string{
+	"id", "name", "breed", "years_experience", "status", "status_reason", "status_changed_at",
+	"is_available", "salary", "salary_currency", "created_at", "updated_at",
+}
+
+var missionExportColumns = []string{
+	"id", "state", "cat_id", "planned_start_at", "deadline_at", "started_at", "ended_at",
+	"is_completed", "is_overdue", "budget", "budget_currency", "created_at", "updated_at",
+}
+
+var targetExportColumns = []string{
+	"id", "mission_id", "name", "country", "country_name", "classification", "cat_id", "dossier_id", "is_completed",
+	"deadline_at", "latitude", "longitude", "last_seen_latitude", "last_seen_longitude", "last_seen_at", "notes",
+	"created_at", "updated_at",
+}
+
+func (s *exportService) ExportCats(w io.Writer, format string, filter models.CatFilter) error {
+	writer, err := export.NewWriter(w, format, "Cats", catExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = s.catRepo.Stream(filter, func(cat *models.SpyCat) error {
+		return writer.Write(cat, []interface{}{
+			cat.ID, cat.Name, cat.Breed, cat.YearsExperience, cat.Status, cat.StatusReason, cat.StatusChangedAt,
+			cat.IsAvailable, amount(cat.Salary), cat.Salary.Currency, cat.CreatedAt, cat.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export cats: %w", err)
+	}
+	return writer.Close()
+}
+
+func (s *exportService) ExportMissions(w io.Writer, format string, filter models.MissionFilter) error {
+	writer, err := export.NewWriter(w, format, "Missions", missionExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = s.missionRepo.Stream(filter, func(mission *models.Mission) error {
+		return writer.Write(mission, []interface{}{
+			mission.ID, models.MissionState(mission), mission.CatID, mission.PlannedStartAt, mission.DeadlineAt,
+			mission.StartedAt, mission.EndedAt, mission.IsCompleted, mission.IsOverdue,
+			amount(mission.Budget), mission.Budget.Currency, mission.CreatedAt, mission.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export missions: %w", err)
+	}
+	return writer.Close()
+}
+
+func (s *exportService) ExportTargets(w io.Writer, format string, filter models.TargetFilter) error {
+	if filter.MissionID != 0 {
+		if _, err := s.missionRepo.GetByID(filter.MissionID); err != nil {
+			return fmt.Errorf("mission not found: %w", err)
+		}
+	}
+	if filter.CatID != 0 {
+		if _, err := s.catRepo.GetByID(filter.CatID); err != nil {
+			return fmt.Errorf("cat not found: %w", err)
+		}
+	}
+
+	writer, err := export.NewWriter(w, format, "Targets", targetExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = s.targetRepo.Stream(filter, func(target *models.Target) error {
+		return writer.Write(target, []interface{}{
+			target.ID, target.MissionID, target.Name, target.Country, target.CountryName, target.Classification,
+			target.CatID, target.DossierID, target.IsCompleted, target.DeadlineAt, target.Latitude, target.Longitude,
+			target.LastSeenLat, target.LastSeenLng, target.LastSeenAt, target.Notes, target.CreatedAt, target.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export targets: %w", err)
+	}
+	return writer.Close()
+}
+
+// Missions without a budget have no currency and export an empty amount
+func amount(m money.Money) interface{} {
+	if m.Currency == "" {
+		return nil
+	}
+	return export.Number(m.Decimal())
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/geo"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+)
+
+const maxSearchRadiusKm = 20038
+
+type GeoService interface {
+	TargetsInBox(box models.BoundingBox) ([]models.Target, error)
+	TargetsNear(lat, lng, radiusKm float64) ([]models.TargetDistance, error)
+}
+
+type geoService struct {
+	geoRepo repository.GeoRepository
+}
+
+func NewGeoService(geoRepo repository.GeoRepository) GeoService {
+	return &geoService{geoRepo: geoRepo}
+}
+
+func (s *geoService) TargetsInBox(box models.BoundingBox) ([]models.Target, error) {
+	if err := validatePoint(box.MinLat, box.MinLng); err != nil {
+		return nil, err
+	}
+	if err := validatePoint(box.MaxLat, box.MaxLng); err != nil {
+		return nil, err
+	}
+	if box.MinLat > box.MaxLat {
+		return nil, fmt.Errorf("min_lat must not be greater than max_lat")
+	}
+
+	return s.geoRepo.FindInBox(box)
+}
+
+func (s *geoService) TargetsNear(lat, lng, radiusKm float64) ([]models.TargetDistance, error) {
+	if err := validatePoint(lat, lng); err != nil {
+		return nil, err
+	}
+	if radiusKm <= 0 || radiusKm > maxSearchRadiusKm {
+		return nil, fmt.Errorf("radius_km must be between 0 and %d", maxSearchRadiusKm)
+	}
+
+	return s.geoRepo.FindNear(lat, lng, radiusKm)
+}
+
+// TargetFeatures places each target at its last known location; targets without one have no geometry
+func TargetFeatures(targets []models.Target) geo.FeatureCollection {
+	features := make([]geo.Feature, 0, len(targets))
+	for _, target := range targets {
+		features = append(features, targetFeature(&target, nil))
+	}
+	return geo.NewFeatureCollection(features)
+}
+
+func NearbyTargetFeatures(targets []models.TargetDistance) geo.FeatureCollection {
+	features := make([]geo.Feature, 0, len(targets))
+	for _, target := range targets {
+		distance := target.DistanceKm
+		features = append(features, targetFeature(&target.Target, &distance))
+	}
+	return geo.NewFeatureCollection(features)
+}
+
+func targetFeature(target *models.Target, distanceKm *float64) geo.Feature {
+	properties := map[string]interface{}{
+		"mission_id":     target.MissionID,
+		"name":           target.Name,
+		"country":        target.Country,
+		"country_name":   target.CountryName,
+		"classification": target.Classification,
+		"is_completed":   target.IsCompleted,
+		"cat_id":         target.CatID,
+		"last_seen_at":   target.LastSeenAt,
+	}
+	if distanceKm != nil {
+		properties["distance_km"] = *distanceKm
+	}
+
+	var geometry *geo.Geometry
+	if lat, lng, ok := models.TargetLocation(target); ok {
+		geometry = geo.Point(lat, lng)
+		properties["location"] = "recorded"
+		if target.LastSeenLat != nil {
+			properties["location"] = "last_seen"
+		}
+	}
+
+	return geo.NewFeature(target.ID, geometry, properties)
+}
+
+func validatePoint(lat, lng float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if lng < -180 || lng > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
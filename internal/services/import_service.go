@@ -0,0 +1,400 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ImportService creates cats or missions in bulk from CSV or NDJSON. Every row goes through
+// CreateCat or CreateMission, so it is checked exactly like an API request.
+type ImportService interface {
+	Import(r io.Reader, opts models.ImportOptions) (*models.ImportReport, error)
+}
+
+type importService struct {
+	repos     *repository.Repositories
+	rates     *money.Rates
+	currency  string
+	validator *validator.Validate
+}
+
+func NewImportService(repos *repository.Repositories, rates *money.Rates, currency string) ImportService {
+	return &importService{
+		repos:     repos,
+		rates:     rates,
+		currency:  currency,
+		validator: validator.New(),
+	}
+}
+
+var catColumns = []string{"name", "years_experience", "breed", "salary", "currency"}
+
+var missionColumns = []string{
+	"mission", "cat_id", "planned_start_at", "deadline_at",
+	"target_name", "target_country", "target_classification", "target_deadline_at", "target_latitude", "target_longitude",
+}
+
+// Columns of a mission row that describe the mission rather than the target
+var missionFields = []string{"cat_id", "planned_start_at", "deadline_at"}
+
+// importRow is one cat or mission of the file; err is set when the row could not be read
+type importRow struct {
+	line    int
+	cat     *models.CreateCatRequest
+	mission *models.CreateMissionRequest
+	err     error
+}
+
+// Rolls back the import transaction without reporting an error
+var errImportRollback = errors.New("import rolled back")
+
+// Import runs in one transaction with a savepoint per row, so a failed row leaves nothing behind and the
+// remaining rows are still checked. Nothing is committed on a dry run or when an all-or-nothing import has errors.
+func (s *importService) Import(r io.Reader, opts models.ImportOptions) (*models.ImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = models.ImportModeAllOrNothing
+	}
+	if opts.Mode != models.ImportModeAllOrNothing && opts.Mode != models.ImportModeBestEffort {
+		return nil, fmt.Errorf("invalid mode %q, expected %s or %s", opts.Mode, models.ImportModeAllOrNothing, models.ImportModeBestEffort)
+	}
+
+	rows, err := parseImport(r, opts.Type, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ImportReport{
+		Type:   opts.Type,
+		Format: opts.Format,
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Rows:   len(rows),
+		IDs:    []uint{},
+		Errors: []models.ImportRowError{},
+	}
+
+	err = s.repos.Transaction(func(tx *repository.Repositories) error {
+		for _, row := range rows {
+			id, err := s.importRow(tx, row)
+			if err != nil {
+				report.Failed++
+				report.Errors = append(report.Errors, models.ImportRowError{Line: row.line, Error: err.Error()})
+				continue
+			}
+			report.Valid++
+			report.IDs = append(report.IDs, id)
+		}
+
+		if opts.DryRun || (report.Failed > 0 && opts.Mode == models.ImportModeAllOrNothing) {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, fmt.Errorf("failed to import: %w", err)
+	}
+
+	report.Committed = err == nil
+	if !report.Committed {
+		report.IDs = []uint{}
+	}
+	return report, nil
+}
+
+func (s *importService) importRow(tx *repository.Repositories, row importRow) (uint, error) {
+	if row.err != nil {
+		return 0, row.err
+	}
+	if row.cat != nil {
+		if err := s.validator.Struct(row.cat); err != nil {
+			return 0, err
+		}
+	} else if err := s.validator.Struct(row.mission); err != nil {
+		return 0, err
+	}
+
+	var id uint
+	err := tx.Transaction(func(savepoint *repository.Repositories) error {
+		missionService := NewMissionService(savepoint.Missions, savepoint.Targets, savepoint.Cats, savepoint.Availability, savepoint.Skills)
+		if row.cat != nil {
+			catService := NewCatService(savepoint.Cats, savepoint.Payroll, savepoint.Missions, missionService, s.rates, s.currency)
+			cat, err := catService.CreateCat(row.cat)
+			if err != nil {
+				return err
+			}
+			id = cat.ID
+			return nil
+		}
+
+		mission, err := missionService.CreateMission(row.mission)
+		if err != nil {
+			return err
+		}
+		id = mission.ID
+		return nil
+	})
+	return id, err
+}
+
+func parseImport(r io.Reader, importType, format string) ([]importRow, error) {
+	if importType != models.ImportTypeCats && importType != models.ImportTypeMissions {
+		return nil, fmt.Errorf("invalid type %q, expected %s or %s", importType, models.ImportTypeCats, models.ImportTypeMissions)
+	}
+
+	switch format {
+	case models.ImportFormatNDJSON:
+		return parseNDJSON(r, importType)
+	case models.ImportFormatCSV:
+		if importType == models.ImportTypeCats {
+			return parseCatsCSV(r)
+		}
+		return parseMissionsCSV(r)
+	default:
+		return nil, fmt.Errorf("invalid format %q, expected %s or %s", format, models.ImportFormatCSV, models.ImportFormatNDJSON)
+	}
+}
+
+// Each line holds the body of a create request; blank lines are skipped
+func parseNDJSON(r io.Reader, importType string) ([]importRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := importRow{line: line}
+		if importType == models.ImportTypeCats {
+			row.cat = &models.CreateCatRequest{}
+			row.err = json.Unmarshal(data, row.cat)
+		} else {
+			row.mission = &models.CreateMissionRequest{}
+			row.err = json.Unmarshal(data, row.mission)
+		}
+		if row.err != nil {
+			row.err = fmt.Errorf("invalid JSON: %w", row.err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return rows, nil
+}
+
+// csvFile reads a CSV file whose first line names the columns, in any order
+type csvFile struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVFile(r io.Reader, allowed, required []string) (*csvFile, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(allowed, name) {
+			return nil, fmt.Errorf("unknown column %q, expected %s", name, strings.Join(allowed, ", "))
+		}
+		columns[name] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+	return &csvFile{reader: reader, columns: columns}, nil
+}
+
+// next returns the fields of the next record and the line it starts on, or io.EOF
+func (f *csvFile) next() ([]string, int, error) {
+	record, err := f.reader.Read()
+	if err != nil {
+		return nil, 0, err
+	}
+	line, _ := f.reader.FieldPos(0)
+	return record, line, nil
+}
+
+func (f *csvFile) get(record []string, column string) string {
+	i, ok := f.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func parseCatsCSV(r io.Reader) ([]importRow, error) {
+	file, err := newCSVFile(r, catColumns, []string{"name", "years_experience", "breed", "salary"})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for {
+		record, line, err := file.next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		row := importRow{line: line, cat: &models.CreateCatRequest{
+			Name:  file.get(record, "name"),
+			Breed: file.get(record, "breed"),
+		}}
+		row.cat.YearsExperience, err = strconv.Atoi(file.get(record, "years_experience"))
+		if err != nil {
+			row.err = fmt.Errorf("invalid years_experience %q", file.get(record, "years_experience"))
+		} else if currency := file.get(record, "currency"); currency != "" {
+			row.cat.Salary, row.err = money.Parse(file.get(record, "salary"), currency)
+		} else {
+			row.cat.Salary, row.err = money.ParseBare(file.get(record, "salary"))
+		}
+		rows = append(rows, row)
+	}
+}
+
+// A mission spans the consecutive or scattered rows that share its "mission" value, one target per row.
+// Without that column every row is a mission with a single target.
+func parseMissionsCSV(r io.Reader) ([]importRow, error) {
+	file, err := newCSVFile(r, missionColumns, []string{"target_name", "target_country"})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	groups := make(map[string]int)
+	firstRecords := make(map[string][]string)
+	for {
+		record, line, err := file.next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		key := file.get(record, "mission")
+		index, grouped := groups[key]
+		if key == "" || !grouped {
+			row := importRow{line: line, mission: &models.CreateMissionRequest{}}
+			row.err = parseMissionFields(file, record, row.mission)
+			rows = append(rows, row)
+			index = len(rows) - 1
+			if key != "" {
+				groups[key] = index
+				firstRecords[key] = record
+			}
+		}
+
+		row := &rows[index]
+		if grouped {
+			for _, column := range missionFields {
+				if value := file.get(record, column); value != "" && value != file.get(firstRecords[key], column) && row.err == nil {
+					row.err = fmt.Errorf("line %d: %s differs from the first row of mission %q", line, column, key)
+				}
+			}
+		}
+
+		target, err := parseTargetFields(file, record)
+		if err != nil && row.err == nil {
+			row.err = fmt.Errorf("line %d: %w", line, err)
+		}
+		row.mission.Targets = append(row.mission.Targets, target)
+	}
+}
+
+func parseMissionFields(file *csvFile, record []string, mission *models.CreateMissionRequest) error {
+	if value := file.get(record, "cat_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid cat_id %q", value)
+		}
+		catID := uint(id)
+		mission.CatID = &catID
+	}
+
+	var err error
+	if mission.PlannedStartAt, err = parseImportTime(file.get(record, "planned_start_at")); err != nil {
+		return fmt.Errorf("invalid planned_start_at: %w", err)
+	}
+	if mission.DeadlineAt, err = parseImportTime(file.get(record, "deadline_at")); err != nil {
+		return fmt.Errorf("invalid deadline_at: %w", err)
+	}
+	return nil
+}
+
+func parseTargetFields(file *csvFile, record []string) (models.CreateTargetRequest, error) {
+	target := models.CreateTargetRequest{
+		Name:           file.get(record, "target_name"),
+		Country:        file.get(record, "target_country"),
+		Classification: file.get(record, "target_classification"),
+	}
+
+	var err error
+	if target.DeadlineAt, err = parseImportTime(file.get(record, "target_deadline_at")); err != nil {
+		return target, fmt.Errorf("invalid target_deadline_at: %w", err)
+	}
+
+	lat, lng := file.get(record, "target_latitude"), file.get(record, "target_longitude")
+	if (lat == "") != (lng == "") {
+		return target, fmt.Errorf("target_latitude and target_longitude must be given together")
+	}
+	if lat != "" {
+		latitude, err := strconv.ParseFloat(lat, 64)
+		if err != nil {
+			return target, fmt.Errorf("invalid target_latitude %q", lat)
+		}
+		longitude, err := strconv.ParseFloat(lng, 64)
+		if err != nil {
+			return target, fmt.Errorf("invalid target_longitude %q", lng)
+		}
+		target.Latitude, target.Longitude = &latitude, &longitude
+	}
+	return target, nil
+}
+
+// Times are RFC 3339; a bare date means midnight UTC
+func parseImportTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("%q is not an RFC 3339 time or a date", value)
+		}
+	}
+	return &t, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"fmt"
+
+	"spy-cat-agency/internal/geo"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+)
+
+// MissionMap holds the map layers of one mission: target markers and sighting tracks
+type MissionMap struct {
+	Mission  models.Mission
+	Markers  []geo.Feature
+	Tracks   []geo.Feature
+	Metadata map[string]interface{}
+}
+
+type MapService interface {
+	MissionMap(missionID uint) (*MissionMap, error)
+	AgencyMap(state string) ([]MissionMap, error)
+}
+
+type mapService struct {
+	missionRepo repository.MissionRepository
+	targetRepo  repository.TargetRepository
+}
+
+func NewMapService(missionRepo repository.MissionRepository, targetRepo repository.TargetRepository) MapService {
+	return &mapService{
+		missionRepo: missionRepo,
+		targetRepo:  targetRepo,
+	}
+}
+
+func (s *mapService) MissionMap(missionID uint) (*MissionMap, error) {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	maps, err := s.buildMaps([]models.Mission{*mission})
+	if err != nil {
+		return nil, err
+	}
+	return &maps[0], nil
+}
+
+func (s *mapService) AgencyMap(state string) ([]MissionMap, error) {
+	missions, err := s.missionRepo.List(models.MissionFilter{State: state})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missions: %w", err)
+	}
+	return s.buildMaps(missions)
+}
+
+func (s *mapService) buildMaps(missions []models.Mission) ([]MissionMap, error) {
+	missionIDs := make([]uint, len(missions))
+	for i, mission := range missions {
+		missionIDs[i] = mission.ID
+	}
+
+	sightings, err := s.targetRepo.GetSightings(missionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sightings: %w", err)
+	}
+	byTarget := make(map[uint][]models.TargetNote)
+	for _, sighting := range sightings {
+		byTarget[sighting.TargetID] = append(byTarget[sighting.TargetID], sighting)
+	}
+
+	maps := make([]MissionMap, 0, len(missions))
+	for _, mission := range missions {
+		metadata := missionMetadata(&mission)
+		missionMap := MissionMap{Mission: mission, Metadata: metadata, Markers: []geo.Feature{}, Tracks: []geo.Feature{}}
+
+		for _, target := range mission.Targets {
+			marker := targetFeature(&target, nil)
+			marker.ID = fmt.Sprintf("target-%d", target.ID)
+			marker.Properties["target_id"] = target.ID
+			marker.Properties["kind"] = "marker"
+			for key, value := range metadata {
+				marker.Properties[key] = value
+			}
+			missionMap.Markers = append(missionMap.Markers, marker)
+
+			if track, ok := sightingTrack(&target, byTarget[target.ID], metadata); ok {
+				missionMap.Tracks = append(missionMap.Tracks, track)
+			}
+		}
+		maps = append(maps, missionMap)
+	}
+	return maps, nil
+}
+
+// A track needs at least two located sightings to be drawn as a line
+func sightingTrack(target *models.Target, sightings []models.TargetNote, metadata map[string]interface{}) (geo.Feature, bool) {
+	if len(sightings) < 2 {
+		return geo.Feature{}, false
+	}
+
+	points := make([][2]float64, len(sightings))
+	for i, sighting := range sightings {
+		points[i] = [2]float64{*sighting.Latitude, *sighting.Longitude}
+	}
+
+	properties := map[string]interface{}{
+		"kind":       "track",
+		"target_id":  target.ID,
+		"name":       target.Name,
+		"sightings":  len(sightings),
+		"first_seen": sightings[0].SeenAt,
+		"last_seen":  sightings[len(sightings)-1].SeenAt,
+	}
+	for key, value := range metadata {
+		properties[key] = value
+	}
+	return geo.NewFeature(fmt.Sprintf("track-%d", target.ID), geo.LineString(points), properties), true
+}
+
+func missionMetadata(mission *models.Mission) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"mission_id":      mission.ID,
+		"mission_state":   models.MissionState(mission),
+		"assigned_cat_id": mission.CatID,
+		"deadline_at":     mission.DeadlineAt,
+		"started_at":      mission.StartedAt,
+		"ended_at":        mission.EndedAt,
+		"is_overdue":      mission.IsOverdue,
+	}
+	if mission.Cat != nil {
+		metadata["assigned_cat_name"] = mission.Cat.Name
+	}
+	return metadata
+}
+
+// MapFeatures puts markers and tracks of all maps into one collection
+func MapFeatures(maps []MissionMap, properties map[string]interface{}) geo.FeatureCollection {
+	var features []geo.Feature
+	for _, missionMap := range maps {
+		features = append(features, missionMap.Markers...)
+		features = append(features, missionMap.Tracks...)
+	}
+	collection := geo.NewFeatureCollection(features)
+	collection.Properties = properties
+	return collection
+}
+
+// MapFolders gives each mission its own KML folder
+func MapFolders(maps []MissionMap) []geo.KMLFolder {
+	folders := make([]geo.KMLFolder, 0, len(maps))
+	for _, missionMap := range maps {
+		features := append(append([]geo.Feature{}, missionMap.Markers...), missionMap.Tracks...)
+		folders = append(folders, geo.KMLFolder{
+			Name:       fmt.Sprintf("Mission %d", missionMap.Mission.ID),
+			Properties: missionMap.Metadata,
+			Features:   features,
+		})
+	}
+	return folders
+}
@@ -2,14 +2,16 @@ package services
 
 import (
 	"fmt"
+	"spy-cat-agency/internal/country"
 	"spy-cat-agency/internal/models"
 	"spy-cat-agency/internal/repository"
+	"time"
 )
 
 type MissionService interface {
 	CreateMission(req *models.CreateMissionRequest) (*models.Mission, error)
 	GetMission(id uint) (*models.Mission, error)
-	ListMissions() ([]models.Mission, error)
+	ListMissions(filter models.MissionFilter) ([]models.Mission, error)
 	UpdateMission(id uint, req *models.UpdateMissionRequest) (*models.Mission, error)
 	DeleteMission(id uint) error
 	AssignCat(missionID, catID uint) error
@@ -17,37 +19,85 @@ type MissionService interface {
 	AddTarget(missionID uint, req *models.AddTargetRequest) (*models.Target, error)
 	UpdateTarget(missionID, targetID uint, req *models.UpdateTargetRequest) (*models.Target, error)
 	DeleteTarget(missionID, targetID uint) error
-	CompleteTarget(missionID, targetID uint) error
+	CompleteTarget(missionID, targetID uint, req *models.CompleteTargetRequest) error
 	UpdateTargetNotes(missionID, targetID uint, req *models.UpdateTargetNotesRequest) error
+	AssignTargetCat(missionID, targetID uint, req *models.AssignTargetCatRequest) (*models.Target, error)
+	AddMissionCat(missionID, catID uint) (*models.Mission, error)
+	RemoveMissionCat(missionID, catID uint) error
+	ListCatTargets(catID uint) ([]models.Target, error)
+	ListTargets(missionID uint) ([]models.Target, error)
+	AddTargetNote(missionID, targetID uint, req *models.AddTargetNoteRequest) (*models.TargetNote, error)
+	ListTargetNotes(missionID, targetID uint) ([]models.TargetNote, error)
+	ReleaseCat(catID uint, reassignTo *uint) error
 }
 
 type missionService struct {
-	missionRepo repository.MissionRepository
-	targetRepo  repository.TargetRepository
-	catRepo     repository.CatRepository
+	missionRepo      repository.MissionRepository
+	targetRepo       repository.TargetRepository
+	catRepo          repository.CatRepository
+	availabilityRepo repository.AvailabilityRepository
+	skillRepo        repository.SkillRepository
 }
 
-func NewMissionService(missionRepo repository.MissionRepository, targetRepo repository.TargetRepository, catRepo repository.CatRepository) MissionService {
+func NewMissionService(missionRepo repository.MissionRepository, targetRepo repository.TargetRepository, catRepo repository.CatRepository, availabilityRepo repository.AvailabilityRepository, skillRepo repository.SkillRepository) MissionService {
 	return &missionService{
-		missionRepo: missionRepo,
-		targetRepo:  targetRepo,
-		catRepo:     catRepo,
+		missionRepo:      missionRepo,
+		targetRepo:       targetRepo,
+		catRepo:          catRepo,
+		availabilityRepo: availabilityRepo,
+		skillRepo:        skillRepo,
 	}
 }
 
 func (s *missionService) CreateMission(req *models.CreateMissionRequest) (*models.Mission, error) {
+	now := time.Now()
+	if err := validateSchedule(req.PlannedStartAt, req.DeadlineAt, now); err != nil {
+		return nil, err
+	}
+
 	mission := &models.Mission{
-		CatID:       req.CatID,
-		IsCompleted: false,
+		CatID:          req.CatID,
+		IsCompleted:    false,
+		PlannedStartAt: req.PlannedStartAt,
+		DeadlineAt:     req.DeadlineAt,
+	}
+
+	codes := make([]string, len(req.Targets))
+	names := make([]string, len(req.Targets))
+	for i, targetReq := range req.Targets {
+		if err := validateTargetDeadline(targetReq.DeadlineAt, mission, now); err != nil {
+			return nil, err
+		}
+		code, name, err := country.Normalize(targetReq.Country)
+		if err != nil {
+			return nil, err
+		}
+		codes[i], names[i] = code, name
+		if err := validateCoordinates(targetReq.Latitude, targetReq.Longitude); err != nil {
+			return nil, err
+		}
+	}
+
+	requirements, err := s.buildRequirements(req.RequiredSkills)
+	if err != nil {
+		return nil, err
 	}
 
 	if req.CatID != nil {
+		mission.StartedAt = &now
+
 		cat, err := s.catRepo.GetByID(*req.CatID)
 		if err != nil {
 			return nil, fmt.Errorf("cat not found: %w", err)
 		}
-		if !cat.IsAvailable {
-			return nil, fmt.Errorf("cat is not available")
+		if err := checkCatAssignable(cat); err != nil {
+			return nil, err
+		}
+		if err := s.checkCatSchedule(mission, *req.CatID); err != nil {
+			return nil, err
+		}
+		if err := s.checkCatSkills(mission, requirements, *req.CatID); err != nil {
+			return nil, err
 		}
 	}
 
@@ -55,12 +105,24 @@ func (s *missionService) CreateMission(req *models.CreateMissionRequest) (*model
 		return nil, fmt.Errorf("failed to create mission: %w", err)
 	}
 
-	for _, targetReq := range req.Targets {
+	if len(requirements) > 0 {
+		if err := s.skillRepo.SetMissionRequirements(mission.ID, requirements); err != nil {
+			return nil, fmt.Errorf("failed to save required skills: %w", err)
+		}
+		mission.RequiredSkills = requirements
+	}
+
+	for i, targetReq := range req.Targets {
 		target := &models.Target{
-			MissionID:   mission.ID,
-			Name:        targetReq.Name,
-			Country:     targetReq.Country,
-			IsCompleted: false,
+			MissionID:      mission.ID,
+			Name:           targetReq.Name,
+			Country:        codes[i],
+			CountryName:    names[i],
+			Latitude:       targetReq.Latitude,
+			Longitude:      targetReq.Longitude,
+			Classification: classificationOrDefault(targetReq.Classification),
+			DeadlineAt:     targetReq.DeadlineAt,
+			IsCompleted:    false,
 		}
 		if err := s.targetRepo.Create(target); err != nil {
 			return nil, fmt.Errorf("failed to create target: %w", err)
@@ -72,6 +134,9 @@ func (s *missionService) CreateMission(req *models.CreateMissionRequest) (*model
 		if err := s.catRepo.SetAvailability(*req.CatID, false); err != nil {
 			return nil, fmt.Errorf("failed to update cat availability: %w", err)
 		}
+		if err := s.bookCats(mission); err != nil {
+			return nil, err
+		}
 	}
 
 	return mission, nil
@@ -81,8 +146,8 @@ func (s *missionService) GetMission(id uint) (*models.Mission, error) {
 	return s.missionRepo.GetByID(id)
 }
 
-func (s *missionService) ListMissions() ([]models.Mission, error) {
-	return s.missionRepo.GetAll()
+func (s *missionService) ListMissions(filter models.MissionFilter) ([]models.Mission, error) {
+	return s.missionRepo.List(filter)
 }
 
 func (s *missionService) UpdateMission(id uint, req *models.UpdateMissionRequest) (*models.Mission, error) {
@@ -95,32 +160,90 @@ func (s *missionService) UpdateMission(id uint, req *models.UpdateMissionRequest
 		return nil, fmt.Errorf("cannot update completed mission")
 	}
 
-	if req.CatID != nil && (mission.CatID == nil || *mission.CatID != *req.CatID) {
-		if mission.CatID != nil {
-			if err := s.catRepo.SetAvailability(*mission.CatID, true); err != nil {
-				return nil, fmt.Errorf("failed to free up current cat: %w", err)
+	scheduleChanged := req.PlannedStartAt != nil || req.DeadlineAt != nil
+	if scheduleChanged {
+		plannedStartAt, deadlineAt := mission.PlannedStartAt, mission.DeadlineAt
+		if req.PlannedStartAt != nil {
+			plannedStartAt = req.PlannedStartAt
+		}
+		if req.DeadlineAt != nil {
+			deadlineAt = req.DeadlineAt
+		}
+		if req.DeadlineAt != nil && !req.DeadlineAt.After(time.Now()) {
+			return nil, fmt.Errorf("deadline must be in the future")
+		}
+		if plannedStartAt != nil && deadlineAt != nil && !plannedStartAt.Before(*deadlineAt) {
+			return nil, fmt.Errorf("planned start must be before the deadline")
+		}
+
+		mission.PlannedStartAt = plannedStartAt
+		if req.DeadlineAt != nil {
+			mission.DeadlineAt = req.DeadlineAt
+			mission.IsOverdue = false
+		}
+	}
+
+	catChanged := req.CatID != nil && (mission.CatID == nil || *mission.CatID != *req.CatID)
+	if catChanged {
+		if err := s.checkNewLead(mission, *req.CatID); err != nil {
+			return nil, err
+		}
+	} else if scheduleChanged && mission.CatID != nil {
+		if err := s.checkCatSkills(mission, mission.RequiredSkills, *mission.CatID); err != nil {
+			return nil, err
+		}
+	}
+	if scheduleChanged {
+		// Every cat staying on the mission has to be free for the new schedule
+		for _, catID := range missionCatIDs(mission) {
+			if catChanged && catID == *mission.CatID {
+				continue
+			}
+			if err := s.checkCatSchedule(mission, catID); err != nil {
+				return nil, err
 			}
 		}
+	}
 
-		cat, err := s.catRepo.GetByID(*req.CatID)
-		if err != nil {
-			return nil, fmt.Errorf("cat not found: %w", err)
+	if catChanged {
+		if mission.CatID != nil {
+			if err := s.leaveMission(id, *mission.CatID); err != nil {
+				return nil, err
+			}
+			for i := range mission.Targets {
+				if mission.Targets[i].CatID != nil && *mission.Targets[i].CatID == *mission.CatID {
+					mission.Targets[i].CatID = nil
+				}
+			}
 		}
-		if !cat.IsAvailable {
-			return nil, fmt.Errorf("cat is not available")
+		if missionHasMember(mission, *req.CatID) {
+			if err := s.missionRepo.RemoveMember(id, *req.CatID); err != nil {
+				return nil, fmt.Errorf("failed to promote member: %w", err)
+			}
 		}
 
 		mission.CatID = req.CatID
+		mission.Cat = nil
 		if err := s.catRepo.SetAvailability(*req.CatID, false); err != nil {
 			return nil, fmt.Errorf("failed to assign new cat: %w", err)
 		}
+		if mission.StartedAt == nil {
+			now := time.Now()
+			mission.StartedAt = &now
+		}
 	}
 
 	if err := s.missionRepo.Update(mission); err != nil {
 		return nil, fmt.Errorf("failed to update mission: %w", err)
 	}
 
-	return mission, nil
+	if catChanged || scheduleChanged {
+		if err := s.rebook(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.missionRepo.GetByID(id)
 }
 
 func (s *missionService) DeleteMission(id uint) error {
@@ -129,7 +252,7 @@ func (s *missionService) DeleteMission(id uint) error {
 		return fmt.Errorf("mission not found: %w", err)
 	}
 
-	if mission.CatID != nil {
+	if len(missionCatIDs(mission)) > 0 {
 		return fmt.Errorf("cannot delete mission that is assigned to a cat")
 	}
 
@@ -146,18 +269,20 @@ func (s *missionService) AssignCat(missionID, catID uint) error {
 		return fmt.Errorf("cannot assign cat to completed mission")
 	}
 
-	cat, err := s.catRepo.GetByID(catID)
-	if err != nil {
-		return fmt.Errorf("cat not found: %w", err)
+	if err := s.checkNewLead(mission, catID); err != nil {
+		return err
 	}
 
-	if !cat.IsAvailable {
-		return fmt.Errorf("cat is not available")
+	// The previous lead leaves the mission; other members stay on it
+	if mission.CatID != nil && *mission.CatID != catID {
+		if err := s.leaveMission(missionID, *mission.CatID); err != nil {
+			return err
+		}
 	}
 
-	if mission.CatID != nil {
-		if err := s.catRepo.SetAvailability(*mission.CatID, true); err != nil {
-			return fmt.Errorf("failed to free up current cat: %w", err)
+	if missionHasMember(mission, catID) {
+		if err := s.missionRepo.RemoveMember(missionID, catID); err != nil {
+			return fmt.Errorf("failed to promote member: %w", err)
 		}
 	}
 
@@ -169,7 +294,91 @@ func (s *missionService) AssignCat(missionID, catID uint) error {
 		return fmt.Errorf("failed to update cat availability: %w", err)
 	}
 
-	return nil
+	if err := s.missionRepo.MarkStarted(missionID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark mission as started: %w", err)
+	}
+
+	return s.rebook(missionID)
+}
+
+// AddMissionCat puts another cat on the mission; the first cat on a mission becomes its lead
+func (s *missionService) AddMissionCat(missionID, catID uint) (*models.Mission, error) {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("cannot add cat to completed mission")
+	}
+
+	if missionHasCat(mission, catID) {
+		return nil, fmt.Errorf("cat is already on this mission")
+	}
+
+	if mission.CatID == nil {
+		if err := s.AssignCat(missionID, catID); err != nil {
+			return nil, err
+		}
+		return s.missionRepo.GetByID(missionID)
+	}
+
+	cat, err := s.catRepo.GetByID(catID)
+	if err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	if err := checkCatAssignable(cat); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCatSchedule(mission, catID); err != nil {
+		return nil, err
+	}
+
+	if err := s.missionRepo.AddMember(missionID, catID); err != nil {
+		return nil, fmt.Errorf("failed to add cat to mission: %w", err)
+	}
+
+	if err := s.catRepo.SetAvailability(catID, false); err != nil {
+		return nil, fmt.Errorf("failed to update cat availability: %w", err)
+	}
+
+	if err := s.rebook(missionID); err != nil {
+		return nil, err
+	}
+
+	return s.missionRepo.GetByID(missionID)
+}
+
+// RemoveMissionCat takes a member off the mission; the lead can only be replaced through AssignCat
+func (s *missionService) RemoveMissionCat(missionID, catID uint) error {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return fmt.Errorf("mission not found: %w", err)
+	}
+
+	if mission.IsCompleted {
+		return fmt.Errorf("cannot remove cat from completed mission")
+	}
+
+	if mission.CatID != nil && *mission.CatID == catID {
+		return fmt.Errorf("cannot remove the mission's lead cat; assign another lead first")
+	}
+
+	if !missionHasMember(mission, catID) {
+		return fmt.Errorf("cat is not on this mission")
+	}
+
+	if err := s.missionRepo.RemoveMember(missionID, catID); err != nil {
+		return fmt.Errorf("failed to remove cat from mission: %w", err)
+	}
+
+	if err := s.leaveMission(missionID, catID); err != nil {
+		return err
+	}
+
+	return s.rebook(missionID)
 }
 
 func (s *missionService) CompleteMission(missionID uint) error {
@@ -201,8 +410,12 @@ func (s *missionService) CompleteMission(missionID uint) error {
 		return fmt.Errorf("failed to complete mission: %w", err)
 	}
 
-	if mission.CatID != nil {
-		if err := s.catRepo.SetAvailability(*mission.CatID, true); err != nil {
+	if err := s.availabilityRepo.CloseMissionBooking(missionID, time.Now()); err != nil {
+		return fmt.Errorf("failed to close mission booking: %w", err)
+	}
+
+	for _, catID := range missionCatIDs(mission) {
+		if err := s.catRepo.SetAvailability(catID, true); err != nil {
 			return fmt.Errorf("failed to free up cat: %w", err)
 		}
 	}
@@ -229,11 +442,29 @@ func (s *missionService) AddTarget(missionID uint, req *models.AddTargetRequest)
 		return nil, fmt.Errorf("mission already has maximum number of targets (3)")
 	}
 
+	if err := validateTargetDeadline(req.DeadlineAt, mission, time.Now()); err != nil {
+		return nil, err
+	}
+
+	code, name, err := country.Normalize(req.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
 	target := &models.Target{
-		MissionID:   missionID,
-		Name:        req.Name,
-		Country:     req.Country,
-		IsCompleted: false,
+		MissionID:      missionID,
+		Name:           req.Name,
+		Country:        code,
+		CountryName:    name,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		Classification: classificationOrDefault(req.Classification),
+		DeadlineAt:     req.DeadlineAt,
+		IsCompleted:    false,
 	}
 
 	if err := s.targetRepo.Create(target); err != nil {
@@ -262,8 +493,31 @@ func (s *missionService) UpdateTarget(missionID, targetID uint, req *models.Upda
 		return nil, fmt.Errorf("cannot update target in completed mission or completed target")
 	}
 
+	if req.DeadlineAt != nil {
+		if err := validateTargetDeadline(req.DeadlineAt, mission, time.Now()); err != nil {
+			return nil, err
+		}
+		target.DeadlineAt = req.DeadlineAt
+	}
+
+	code, name, err := country.Normalize(req.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
 	target.Name = req.Name
-	target.Country = req.Country
+	target.Country = code
+	target.CountryName = name
+	if req.Latitude != nil {
+		target.Latitude, target.Longitude = req.Latitude, req.Longitude
+	}
+	if req.Classification != "" {
+		target.Classification = req.Classification
+	}
 
 	if err := s.targetRepo.Update(target); err != nil {
 		return nil, fmt.Errorf("failed to update target: %w", err)
@@ -298,7 +552,7 @@ func (s *missionService) DeleteTarget(missionID, targetID uint) error {
 	return s.targetRepo.Delete(targetID)
 }
 
-func (s *missionService) CompleteTarget(missionID, targetID uint) error {
+func (s *missionService) CompleteTarget(missionID, targetID uint, req *models.CompleteTargetRequest) error {
 	target, err := s.targetRepo.GetByID(targetID)
 	if err != nil {
 		return fmt.Errorf("target not found: %w", err)
@@ -321,6 +575,10 @@ func (s *missionService) CompleteTarget(missionID, targetID uint) error {
 		return fmt.Errorf("cannot complete target in completed mission")
 	}
 
+	if err := checkActingCat(mission, target, req.CatID); err != nil {
+		return err
+	}
+
 	return s.targetRepo.CompleteTarget(targetID)
 }
 
@@ -347,6 +605,10 @@ func (s *missionService) UpdateTargetNotes(missionID, targetID uint, req *models
 		return fmt.Errorf("cannot update notes in completed mission")
 	}
 
+	if err := checkActingCat(mission, target, req.CatID); err != nil {
+		return err
+	}
+
 	newNotes := req.Notes
 	if existing := target.Notes; len(existing) > 0 {
 		newNotes = existing + "\n" + req.Notes
@@ -354,3 +616,449 @@ func (s *missionService) UpdateTargetNotes(missionID, targetID uint, req *models
 
 	return s.targetRepo.UpdateNotes(targetID, newNotes)
 }
+
+func (s *missionService) ListTargets(missionID uint) ([]models.Target, error) {
+	if _, err := s.missionRepo.GetByID(missionID); err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+	return s.targetRepo.GetByMissionID(missionID)
+}
+
+// Note entries follow the same rules as notes; a located entry also moves the target's last known location
+func (s *missionService) AddTargetNote(missionID, targetID uint, req *models.AddTargetNoteRequest) (*models.TargetNote, error) {
+	target, err := s.targetRepo.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.MissionID != missionID {
+		return nil, fmt.Errorf("target does not belong to this mission")
+	}
+
+	if target.IsCompleted {
+		return nil, fmt.Errorf("cannot update notes for completed target")
+	}
+
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("cannot update notes in completed mission")
+	}
+
+	if err := checkActingCat(mission, target, req.CatID); err != nil {
+		return nil, err
+	}
+
+	if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+
+	if req.Notes == "" && req.Latitude == nil {
+		return nil, fmt.Errorf("note entry needs notes or a location")
+	}
+
+	now := time.Now()
+	seenAt := now
+	if req.SeenAt != nil {
+		if req.SeenAt.After(now) {
+			return nil, fmt.Errorf("seen_at cannot be in the future")
+		}
+		seenAt = *req.SeenAt
+	}
+
+	note := &models.TargetNote{
+		TargetID:  targetID,
+		MissionID: missionID,
+		CatID:     req.CatID,
+		Notes:     req.Notes,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		SeenAt:    seenAt,
+	}
+
+	if err := s.targetRepo.CreateNote(note); err != nil {
+		return nil, fmt.Errorf("failed to add note: %w", err)
+	}
+
+	if req.Latitude != nil {
+		if err := s.targetRepo.UpdateLastSeen(targetID, *req.Latitude, *req.Longitude, seenAt); err != nil {
+			return nil, fmt.Errorf("failed to update last known location: %w", err)
+		}
+	}
+
+	return note, nil
+}
+
+func (s *missionService) ListTargetNotes(missionID, targetID uint) ([]models.TargetNote, error) {
+	target, err := s.targetRepo.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.MissionID != missionID {
+		return nil, fmt.Errorf("target does not belong to this mission")
+	}
+
+	return s.targetRepo.GetNotes(targetID)
+}
+
+func (s *missionService) AssignTargetCat(missionID, targetID uint, req *models.AssignTargetCatRequest) (*models.Target, error) {
+	target, err := s.targetRepo.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.MissionID != missionID {
+		return nil, fmt.Errorf("target does not belong to this mission")
+	}
+
+	if target.IsCompleted {
+		return nil, fmt.Errorf("cannot reassign completed target")
+	}
+
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("cannot reassign target in completed mission")
+	}
+
+	if req.CatID != nil && !missionHasCat(mission, *req.CatID) {
+		return nil, fmt.Errorf("cat is not on this mission")
+	}
+
+	if err := s.targetRepo.AssignCat(targetID, req.CatID); err != nil {
+		return nil, fmt.Errorf("failed to assign cat to target: %w", err)
+	}
+
+	target.CatID = req.CatID
+	return target, nil
+}
+
+func (s *missionService) ListCatTargets(catID uint) ([]models.Target, error) {
+	if _, err := s.catRepo.GetByID(catID); err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	return s.targetRepo.GetByCatID(catID)
+}
+
+// ReleaseCat hands a cat's active missions over to another cat, or leaves them unassigned
+func (s *missionService) ReleaseCat(catID uint, reassignTo *uint) error {
+	if reassignTo != nil && *reassignTo == catID {
+		return fmt.Errorf("cannot reassign missions to the same cat")
+	}
+
+	missions, err := s.missionRepo.GetActiveByCatID(catID)
+	if err != nil {
+		return fmt.Errorf("failed to load active missions: %w", err)
+	}
+
+	for _, mission := range missions {
+		if err := s.releaseFrom(&mission, catID, reassignTo); err != nil {
+			return fmt.Errorf("failed to release mission %d: %w", mission.ID, err)
+		}
+	}
+
+	if len(missions) > 0 {
+		if err := s.catRepo.SetAvailability(catID, true); err != nil {
+			return fmt.Errorf("failed to free up cat: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseFrom takes the cat off one mission. A lead is replaced by reassignTo or else by the
+// longest-serving member; a member is replaced by reassignTo unless that cat is already on the mission.
+func (s *missionService) releaseFrom(mission *models.Mission, catID uint, reassignTo *uint) error {
+	isLead := mission.CatID != nil && *mission.CatID == catID
+	if isLead && reassignTo != nil {
+		return s.AssignCat(mission.ID, *reassignTo)
+	}
+
+	if isLead {
+		if err := s.missionRepo.UnassignCat(mission.ID); err != nil {
+			return fmt.Errorf("failed to unassign cat: %w", err)
+		}
+		if len(mission.Members) > 0 {
+			next := mission.Members[0].CatID
+			if err := s.missionRepo.RemoveMember(mission.ID, next); err != nil {
+				return fmt.Errorf("failed to promote member: %w", err)
+			}
+			if err := s.missionRepo.AssignCat(mission.ID, next); err != nil {
+				return fmt.Errorf("failed to promote member: %w", err)
+			}
+		}
+	} else if err := s.missionRepo.RemoveMember(mission.ID, catID); err != nil {
+		return fmt.Errorf("failed to remove cat from mission: %w", err)
+	}
+
+	if err := s.leaveMission(mission.ID, catID); err != nil {
+		return err
+	}
+	if err := s.rebook(mission.ID); err != nil {
+		return err
+	}
+
+	if !isLead && reassignTo != nil && !missionHasCat(mission, *reassignTo) {
+		if _, err := s.AddMissionCat(mission.ID, *reassignTo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNewLead checks that a cat can take over as the mission's lead. A member taking over is
+// already booked for the mission, so it does not have to be available.
+func (s *missionService) checkNewLead(mission *models.Mission, catID uint) error {
+	cat, err := s.catRepo.GetByID(catID)
+	if err != nil {
+		return fmt.Errorf("cat not found: %w", err)
+	}
+	if missionHasMember(mission, catID) {
+		if cat.Status != models.CatStatusActive {
+			return fmt.Errorf("cat is %s", cat.Status)
+		}
+	} else if err := checkCatAssignable(cat); err != nil {
+		return err
+	}
+	if err := s.checkCatSchedule(mission, catID); err != nil {
+		return err
+	}
+	return s.checkCatSkills(mission, mission.RequiredSkills, catID)
+}
+
+// leaveMission frees up a cat taken off a mission and unassigns its targets there
+func (s *missionService) leaveMission(missionID, catID uint) error {
+	if err := s.targetRepo.UnassignCat(missionID, catID); err != nil {
+		return fmt.Errorf("failed to clear target assignments: %w", err)
+	}
+	if err := s.catRepo.SetAvailability(catID, true); err != nil {
+		return fmt.Errorf("failed to free up cat: %w", err)
+	}
+	return nil
+}
+
+func (s *missionService) checkCatSchedule(mission *models.Mission, catID uint) error {
+	from, to := missionWindow(mission)
+	entries, err := s.availabilityRepo.FindOverlapping(catID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to check cat availability: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.MissionID != nil && *entry.MissionID == mission.ID {
+			continue
+		}
+		return fmt.Errorf("cat is unavailable during the mission (%s from %s)", entry.Kind, entry.StartsAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// bookCats replaces the mission's bookings with one for every cat on it
+func (s *missionService) bookCats(mission *models.Mission) error {
+	if err := s.availabilityRepo.DeleteByMissionID(mission.ID); err != nil {
+		return fmt.Errorf("failed to clear mission booking: %w", err)
+	}
+
+	from, to := missionWindow(mission)
+	for _, catID := range missionCatIDs(mission) {
+		booking := &models.AvailabilityEntry{
+			CatID:     catID,
+			Kind:      models.AvailabilityMission,
+			StartsAt:  from,
+			EndsAt:    to,
+			MissionID: &mission.ID,
+		}
+		if err := s.availabilityRepo.Create(booking); err != nil {
+			return fmt.Errorf("failed to book cat for mission: %w", err)
+		}
+	}
+	return nil
+}
+
+// rebook reloads the mission after its cats changed and books them
+func (s *missionService) rebook(missionID uint) error {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return fmt.Errorf("mission not found: %w", err)
+	}
+	return s.bookCats(mission)
+}
+
+func (s *missionService) buildRequirements(reqs []models.SkillRequirementRequest) ([]models.MissionSkillRequirement, error) {
+	requirements := make([]models.MissionSkillRequirement, 0, len(reqs))
+	seen := make(map[uint]bool, len(reqs))
+	for _, req := range reqs {
+		if seen[req.SkillID] {
+			return nil, fmt.Errorf("skill %d is required more than once", req.SkillID)
+		}
+		seen[req.SkillID] = true
+
+		skill, err := s.skillRepo.GetByID(req.SkillID)
+		if err != nil {
+			return nil, fmt.Errorf("skill %d not found: %w", req.SkillID, err)
+		}
+		requirements = append(requirements, models.MissionSkillRequirement{
+			SkillID:  req.SkillID,
+			Skill:    skill,
+			MinLevel: req.MinLevel,
+		})
+	}
+	return requirements, nil
+}
+
+func (s *missionService) checkCatSkills(mission *models.Mission, requirements []models.MissionSkillRequirement, catID uint) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	catSkills, err := s.skillRepo.GetCatSkills(catID)
+	if err != nil {
+		return fmt.Errorf("failed to load cat skills: %w", err)
+	}
+
+	if missing := missingSkill(catSkills, requirements, skillsValidUntil(mission)); missing != nil {
+		name := fmt.Sprintf("skill %d", missing.SkillID)
+		if missing.Skill != nil {
+			name = missing.Skill.Name
+		}
+		return fmt.Errorf("cat lacks required skill %s at level %d", name, missing.MinLevel)
+	}
+	return nil
+}
+
+// Certifications must stay valid until the mission deadline
+func skillsValidUntil(mission *models.Mission) time.Time {
+	validUntil := time.Now()
+	if mission.DeadlineAt != nil && mission.DeadlineAt.After(validUntil) {
+		validUntil = *mission.DeadlineAt
+	}
+	return validUntil
+}
+
+func missingSkill(catSkills []models.CatSkill, requirements []models.MissionSkillRequirement, validUntil time.Time) *models.MissionSkillRequirement {
+	levels := make(map[uint]int, len(catSkills))
+	for _, catSkill := range catSkills {
+		if catSkill.ExpiresAt == nil || catSkill.ExpiresAt.After(validUntil) {
+			levels[catSkill.SkillID] = catSkill.Level
+		}
+	}
+
+	for i := range requirements {
+		if levels[requirements[i].SkillID] < requirements[i].MinLevel {
+			return &requirements[i]
+		}
+	}
+	return nil
+}
+
+// Missions without a future deadline keep the cat booked until they are completed
+func missionWindow(mission *models.Mission) (time.Time, *time.Time) {
+	from := time.Now()
+	if mission.PlannedStartAt != nil && mission.PlannedStartAt.After(from) {
+		from = *mission.PlannedStartAt
+	}
+	if mission.DeadlineAt == nil || !mission.DeadlineAt.After(from) {
+		return from, nil
+	}
+	return from, mission.DeadlineAt
+}
+
+func classificationOrDefault(classification string) string {
+	if classification == "" {
+		return models.ClassificationConfidential
+	}
+	return classification
+}
+
+func checkCatAssignable(cat *models.SpyCat) error {
+	if cat.Status != models.CatStatusActive {
+		return fmt.Errorf("cat is %s", cat.Status)
+	}
+	if !cat.IsAvailable {
+		return fmt.Errorf("cat is not available")
+	}
+	return nil
+}
+
+// missionCatIDs lists every cat on the mission, the lead first
+func missionCatIDs(mission *models.Mission) []uint {
+	var ids []uint
+	if mission.CatID != nil {
+		ids = append(ids, *mission.CatID)
+	}
+	for _, member := range mission.Members {
+		ids = append(ids, member.CatID)
+	}
+	return ids
+}
+
+// missionHasCat reports whether the cat leads the mission or is one of its members
+func missionHasCat(mission *models.Mission, catID uint) bool {
+	return (mission.CatID != nil && *mission.CatID == catID) || missionHasMember(mission, catID)
+}
+
+func missionHasMember(mission *models.Mission, catID uint) bool {
+	for _, member := range mission.Members {
+		if member.CatID == catID {
+			return true
+		}
+	}
+	return false
+}
+
+// checkActingCat checks the cat_id of a request working a target: the cat must be on the mission,
+// and once the target is assigned it must be the assigned cat
+func checkActingCat(mission *models.Mission, target *models.Target, catID *uint) error {
+	if catID != nil && !missionHasCat(mission, *catID) {
+		return fmt.Errorf("cat %d is not on this mission", *catID)
+	}
+	// Unassigned targets can be handled by anyone on the mission
+	if target.CatID == nil {
+		return nil
+	}
+	if catID == nil {
+		return fmt.Errorf("target is assigned to cat %d; cat_id is required", *target.CatID)
+	}
+	if *catID != *target.CatID {
+		return fmt.Errorf("target is assigned to another cat")
+	}
+	return nil
+}
+
+func validateSchedule(plannedStartAt, deadlineAt *time.Time, now time.Time) error {
+	if deadlineAt != nil && !deadlineAt.After(now) {
+		return fmt.Errorf("deadline must be in the future")
+	}
+	if plannedStartAt != nil && deadlineAt != nil && !plannedStartAt.Before(*deadlineAt) {
+		return fmt.Errorf("planned start must be before the deadline")
+	}
+	return nil
+}
+
+func validateCoordinates(lat, lng *float64) error {
+	if (lat == nil) != (lng == nil) {
+		return fmt.Errorf("latitude and longitude must be given together")
+	}
+	return nil
+}
+
+func validateTargetDeadline(deadlineAt *time.Time, mission *models.Mission, now time.Time) error {
+	if deadlineAt == nil {
+		return nil
+	}
+	if !deadlineAt.After(now) {
+		return fmt.Errorf("target deadline must be in the future")
+	}
+	if mission.DeadlineAt != nil && deadlineAt.After(*mission.DeadlineAt) {
+		return fmt.Errorf("target deadline cannot be after the mission deadline")
+	}
+	return nil
+}
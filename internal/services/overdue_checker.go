@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"log"
+	"spy-cat-agency/internal/repository"
+	"time"
+)
+
+type OverdueChecker struct {
+	missionRepo repository.MissionRepository
+	interval    time.Duration
+}
+
+func NewOverdueChecker(missionRepo repository.MissionRepository, interval time.Duration) *OverdueChecker {
+	return &OverdueChecker{
+		missionRepo: missionRepo,
+		interval:    interval,
+	}
+}
+
+func (c *OverdueChecker) Start(ctx context.Context) {
+	runPeriodically(ctx, "overdue check", c.interval, c.Check)
+}
+
+func (c *OverdueChecker) Check() {
+	flagged, err := c.missionRepo.MarkOverdue(time.Now())
+	if err != nil {
+		log.Printf("Overdue check failed: %v", err)
+		return
+	}
+
+	if flagged > 0 {
+		log.Printf("Flagged %d mission(s) as overdue", flagged)
+	}
+}
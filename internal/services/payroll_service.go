@@ -0,0 +1,313 @@
+package services
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/country"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"strings"
+	"time"
+)
+
+type PayrollService interface {
+	PreviewPayroll(period string) (*models.PayrollRun, error)
+	RunPayroll(period string) (*models.PayrollRun, error)
+	GetRun(id uint) (*models.PayrollRun, error)
+	ListRuns() ([]models.PayrollRun, error)
+	GetCompensation(catID uint) (*models.Compensation, error)
+	ListHazardRates() ([]models.HazardPayRate, error)
+	SetHazardRate(country string, req *models.SetHazardPayRateRequest) (*models.HazardPayRate, error)
+	DeleteHazardRate(country string) error
+}
+
+type payrollService struct {
+	payrollRepo  repository.PayrollRepository
+	catRepo      repository.CatRepository
+	missionRepo  repository.MissionRepository
+	rates        *money.Rates
+	currency     string
+	missionBonus money.Money
+}
+
+func NewPayrollService(payrollRepo repository.PayrollRepository, catRepo repository.CatRepository, missionRepo repository.MissionRepository, rates *money.Rates, currency string, missionBonus int64) PayrollService {
+	return &payrollService{
+		payrollRepo:  payrollRepo,
+		catRepo:      catRepo,
+		missionRepo:  missionRepo,
+		rates:        rates,
+		currency:     currency,
+		missionBonus: money.New(missionBonus, currency),
+	}
+}
+
+func (s *payrollService) PreviewPayroll(period string) (*models.PayrollRun, error) {
+	start, err := time.Parse("2006-01", period)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period %q, expected YYYY-MM", period)
+	}
+	end := start.AddDate(0, 1, 0)
+
+	cats, err := s.catRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cats: %w", err)
+	}
+
+	missions, err := s.missionRepo.GetCompletedBetween(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed missions: %w", err)
+	}
+
+	hazardRates, err := s.hazardRatesByCountry()
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.PayrollRun{
+		Period:      period,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Total:       money.Zero(s.currency),
+	}
+
+	for _, cat := range cats {
+		entry, err := s.computeEntry(cat, start, end, missions, hazardRates)
+		if err != nil {
+			return nil, fmt.Errorf("cat %d: %w", cat.ID, err)
+		}
+		if entry.Total.Amount == 0 {
+			continue
+		}
+
+		run.Entries = append(run.Entries, *entry)
+	}
+
+	// Entries are paid in each cat's salary currency; the run total is reported in the payroll currency
+	for _, entry := range run.Entries {
+		converted, err := s.rates.Convert(entry.Total, s.currency)
+		if err != nil {
+			return nil, err
+		}
+		run.Total.Amount += converted.Amount
+	}
+
+	return run, nil
+}
+
+func (s *payrollService) RunPayroll(period string) (*models.PayrollRun, error) {
+	if _, err := s.payrollRepo.GetRunByPeriod(period); err == nil {
+		return nil, fmt.Errorf("payroll for %s has already been run", period)
+	}
+
+	run, err := s.PreviewPayroll(period)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.payrollRepo.CreateRun(run); err != nil {
+		return nil, fmt.Errorf("failed to save payroll run: %w", err)
+	}
+
+	return run, nil
+}
+
+func (s *payrollService) GetRun(id uint) (*models.PayrollRun, error) {
+	return s.payrollRepo.GetRunByID(id)
+}
+
+func (s *payrollService) ListRuns() ([]models.PayrollRun, error) {
+	return s.payrollRepo.GetRuns()
+}
+
+func (s *payrollService) GetCompensation(catID uint) (*models.Compensation, error) {
+	cat, err := s.catRepo.GetByID(catID)
+	if err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	history, err := s.salaryHistory(cat)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips, err := s.payrollRepo.GetEntriesByCatID(catID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payslips: %w", err)
+	}
+
+	totalPaid := money.Zero(s.currency)
+	for _, payslip := range payslips {
+		converted, err := s.rates.Convert(payslip.Total, s.currency)
+		if err != nil {
+			return nil, err
+		}
+		totalPaid.Amount += converted.Amount
+	}
+
+	return &models.Compensation{
+		Cat:           *cat,
+		CurrentSalary: history[len(history)-1].Salary,
+		SalaryHistory: history,
+		Payslips:      payslips,
+		TotalPaid:     totalPaid,
+	}, nil
+}
+
+func (s *payrollService) ListHazardRates() ([]models.HazardPayRate, error) {
+	return s.payrollRepo.GetHazardRates()
+}
+
+func (s *payrollService) SetHazardRate(countryInput string, req *models.SetHazardPayRateRequest) (*models.HazardPayRate, error) {
+	code, name, err := country.Normalize(countryInput)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := req.Rate.OrCurrency(s.currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hazard pay rate: %w", err)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("hazard pay rate must be positive")
+	}
+	if _, err := s.rates.Convert(amount, s.currency); err != nil {
+		return nil, fmt.Errorf("invalid hazard pay rate: %w", err)
+	}
+
+	rate := &models.HazardPayRate{
+		Country:     code,
+		CountryName: name,
+		Rate:        amount,
+	}
+
+	if err := s.payrollRepo.UpsertHazardRate(rate); err != nil {
+		return nil, fmt.Errorf("failed to save hazard pay rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// Rates that could not be migrated to a country code are deleted by their stored value
+func (s *payrollService) DeleteHazardRate(countryInput string) error {
+	if code, _, err := country.Normalize(countryInput); err == nil {
+		countryInput = code
+	}
+	return s.payrollRepo.DeleteHazardRate(countryInput)
+}
+
+// Cats created before salary history was recorded fall back to their current salary
+func (s *payrollService) salaryHistory(cat *models.SpyCat) ([]models.SalaryRecord, error) {
+	history, err := s.payrollRepo.GetSalaryHistory(cat.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load salary history: %w", err)
+	}
+
+	if len(history) == 0 {
+		history = []models.SalaryRecord{{
+			CatID:         cat.ID,
+			Salary:        cat.Salary,
+			EffectiveFrom: cat.CreatedAt,
+			Reason:        "current salary",
+		}}
+	}
+
+	return history, nil
+}
+
+func (s *payrollService) hazardRatesByCountry() (map[string]money.Money, error) {
+	rates, err := s.payrollRepo.GetHazardRates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hazard pay rates: %w", err)
+	}
+
+	byCountry := make(map[string]money.Money, len(rates))
+	for _, rate := range rates {
+		byCountry[strings.ToLower(rate.Country)] = rate.Rate
+	}
+	return byCountry, nil
+}
+
+// Salaries are annual; the monthly base is prorated per day using the salary in effect that day
+func (s *payrollService) computeEntry(cat models.SpyCat, start, end time.Time, missions []models.Mission, hazardRates map[string]money.Money) (*models.PayrollEntry, error) {
+	history, err := s.salaryHistory(&cat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pay in the currency of the cat's latest salary, converting earlier records if the cat changed office
+	currency := history[len(history)-1].Salary.Currency
+	salaries := make([]money.Money, len(history))
+	for i, record := range history {
+		if salaries[i], err = s.rates.Convert(record.Salary, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	// Retired and fallen cats are paid up to the day their status changed
+	var stoppedAt *time.Time
+	if cat.Status == models.CatStatusRetired || cat.Status == models.CatStatusKIA {
+		stoppedAt = cat.StatusChangedAt
+	}
+
+	days := int64(end.Sub(start).Hours() / 24)
+	var annualSum int64
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if stoppedAt != nil && !day.Before(*stoppedAt) {
+			break
+		}
+		dayEnd := day.AddDate(0, 0, 1)
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].EffectiveFrom.Before(dayEnd) {
+				annualSum += salaries[i].Amount
+				break
+			}
+		}
+	}
+
+	missionBonus, err := s.rates.Convert(s.missionBonus, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.PayrollEntry{
+		CatID:        cat.ID,
+		BaseSalary:   money.New((annualSum+6*days)/(12*days), currency),
+		MissionBonus: money.Zero(currency),
+		HazardPay:    money.Zero(currency),
+	}
+
+	for _, mission := range missions {
+		if !missionHasCat(&mission, cat.ID) {
+			continue
+		}
+		entry.MissionsCompleted++
+
+		// Hazard pay covers the countries of the cat's own targets and of targets nobody was assigned
+		countries := make(map[string]bool)
+		for _, target := range mission.Targets {
+			if target.CatID == nil || *target.CatID == cat.ID {
+				countries[strings.ToLower(target.Country)] = true
+			}
+		}
+		for country := range countries {
+			if rate, ok := hazardRates[country]; ok {
+				converted, err := s.rates.Convert(rate, currency)
+				if err != nil {
+					return nil, err
+				}
+				entry.HazardPay.Amount += converted.Amount
+			}
+		}
+	}
+	entry.MissionBonus = missionBonus.Multiply(int64(entry.MissionsCompleted))
+
+	entry.Total = entry.BaseSalary
+	if entry.Total, err = entry.Total.Add(entry.MissionBonus); err != nil {
+		return nil, err
+	}
+	if entry.Total, err = entry.Total.Add(entry.HazardPay); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runPeriodically runs job right away and then every interval until ctx is done. The interval comes
+// from the config, which only hands out positive ones; anything else would make time.NewTicker panic,
+// so the job then runs once.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func()) {
+	go func() {
+		job()
+		if interval <= 0 {
+			log.Printf("WARNING: %s interval %s is not positive; it runs only at startup", name, interval)
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				job()
+			}
+		}
+	}()
+}
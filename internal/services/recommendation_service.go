@@ -0,0 +1,387 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"strings"
+	"time"
+)
+
+const (
+	experienceWeight = 0.20
+	breedWeight      = 0.10
+	successWeight    = 0.25
+	countryWeight    = 0.20
+	salaryWeight     = 0.15
+	workloadWeight   = 0.10
+
+	fullExperienceYears = 20
+	neutralScore        = 0.5
+)
+
+type RecommendationService interface {
+	RankCandidates(missionID uint, opts models.CandidateOptions) ([]models.CandidateScore, error)
+	AutoAssign(missionID uint, opts models.CandidateOptions) (*models.CandidateScore, error)
+}
+
+type recommendationService struct {
+	missionRepo      repository.MissionRepository
+	catRepo          repository.CatRepository
+	availabilityRepo repository.AvailabilityRepository
+	skillRepo        repository.SkillRepository
+	missionService   MissionService
+	rates            *money.Rates
+	currency         string
+}
+
+func NewRecommendationService(missionRepo repository.MissionRepository, catRepo repository.CatRepository, availabilityRepo repository.AvailabilityRepository, skillRepo repository.SkillRepository, missionService MissionService, rates *money.Rates, currency string) RecommendationService {
+	return &recommendationService{
+		missionRepo:      missionRepo,
+		catRepo:          catRepo,
+		availabilityRepo: availabilityRepo,
+		skillRepo:        skillRepo,
+		missionService:   missionService,
+		rates:            rates,
+		currency:         currency,
+	}
+}
+
+type catHistory struct {
+	finished  int
+	succeeded int
+	active    int
+	countries map[string]bool
+}
+
+func (h *catHistory) successRate() (float64, bool) {
+	if h == nil || h.finished == 0 {
+		return neutralScore, false
+	}
+	return float64(h.succeeded) / float64(h.finished), true
+}
+
+func (s *recommendationService) RankCandidates(missionID uint, opts models.CandidateOptions) ([]models.CandidateScore, error) {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("mission is already completed")
+	}
+
+	candidates, err := s.availableCats(mission)
+	if err != nil {
+		return nil, err
+	}
+
+	missions, err := s.missionRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mission history: %w", err)
+	}
+
+	histories, breedHistories := buildHistories(missions, mission.ID)
+
+	// Salaries are compared in the budget currency so cats from different offices rank fairly
+	currency := s.currency
+	var budget *money.Money
+	if opts.SalaryBudget != nil {
+		amount, err := opts.SalaryBudget.OrCurrency(s.currency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget: %w", err)
+		}
+		budget = &amount
+		currency = amount.Currency
+	}
+
+	salaries := make(map[uint]money.Money, len(candidates))
+	var minSalary, maxSalary money.Money
+	for i, cat := range candidates {
+		salary, err := s.rates.Convert(cat.Salary, currency)
+		if err != nil {
+			return nil, err
+		}
+		salaries[cat.ID] = salary
+		if i == 0 || salary.Amount < minSalary.Amount {
+			minSalary = salary
+		}
+		if i == 0 || salary.Amount > maxSalary.Amount {
+			maxSalary = salary
+		}
+	}
+
+	now := time.Now()
+	scores := make([]models.CandidateScore, 0, len(candidates))
+	for _, cat := range candidates {
+		bookings, err := s.availabilityRepo.FindOverlapping(cat.ID, now, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load calendar: %w", err)
+		}
+
+		breakdown := []models.ScoreComponent{
+			experienceComponent(cat),
+			breedComponent(cat, breedHistories[cat.Breed]),
+			successComponent(histories[cat.ID]),
+			countryComponent(mission, histories[cat.ID]),
+			salaryComponent(salaries[cat.ID], budget, minSalary, maxSalary),
+			workloadComponent(histories[cat.ID], bookings),
+		}
+
+		score := models.CandidateScore{Cat: cat, Breakdown: breakdown}
+		for _, component := range breakdown {
+			score.Score += component.Contribution
+		}
+		score.Score = round(score.Score)
+		scores = append(scores, score)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Cat.ID < scores[j].Cat.ID
+	})
+
+	if opts.Limit > 0 && len(scores) > opts.Limit {
+		scores = scores[:opts.Limit]
+	}
+
+	return scores, nil
+}
+
+func (s *recommendationService) AutoAssign(missionID uint, opts models.CandidateOptions) (*models.CandidateScore, error) {
+	scores, err := s.RankCandidates(missionID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("no available cats for this mission")
+	}
+
+	best := scores[0]
+	if err := s.missionService.AssignCat(missionID, best.Cat.ID); err != nil {
+		return nil, err
+	}
+
+	return &best, nil
+}
+
+func (s *recommendationService) availableCats(mission *models.Mission) ([]models.SpyCat, error) {
+	cats, err := s.catRepo.GetAvailable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cats: %w", err)
+	}
+
+	from, to := missionWindow(mission)
+	unavailableIDs, err := s.availabilityRepo.GetUnavailableCatIDs(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check calendar: %w", err)
+	}
+
+	unavailable := make(map[uint]bool, len(unavailableIDs))
+	for _, id := range unavailableIDs {
+		unavailable[id] = true
+	}
+
+	validUntil := skillsValidUntil(mission)
+
+	available := make([]models.SpyCat, 0, len(cats))
+	for _, cat := range cats {
+		if unavailable[cat.ID] {
+			continue
+		}
+
+		qualified, err := s.hasRequiredSkills(cat.ID, mission.RequiredSkills, validUntil)
+		if err != nil {
+			return nil, err
+		}
+		if qualified {
+			available = append(available, cat)
+		}
+	}
+	return available, nil
+}
+
+func (s *recommendationService) hasRequiredSkills(catID uint, requirements []models.MissionSkillRequirement, validUntil time.Time) (bool, error) {
+	if len(requirements) == 0 {
+		return true, nil
+	}
+
+	catSkills, err := s.skillRepo.GetCatSkills(catID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load cat skills: %w", err)
+	}
+
+	return missingSkill(catSkills, requirements, validUntil) == nil, nil
+}
+
+func buildHistories(missions []models.Mission, excludeMissionID uint) (map[uint]*catHistory, map[string]*catHistory) {
+	histories := make(map[uint]*catHistory)
+	breedHistories := make(map[string]*catHistory)
+
+	for _, m := range missions {
+		if m.ID == excludeMissionID {
+			continue
+		}
+
+		// The lead and every member share the mission's record; a breed counts each mission once
+		credited := make(map[uint]bool)
+		creditedBreeds := make(map[string]bool)
+		for _, cat := range missionCats(&m) {
+			if credited[cat.id] {
+				continue
+			}
+			credited[cat.id] = true
+
+			h, ok := histories[cat.id]
+			if !ok {
+				h = &catHistory{countries: make(map[string]bool)}
+				histories[cat.id] = h
+			}
+			for _, t := range m.Targets {
+				h.countries[strings.ToLower(t.Country)] = true
+			}
+
+			// A mission counts as a success when it was completed without running overdue
+			switch {
+			case m.IsCompleted:
+				h.finished++
+				if !m.IsOverdue {
+					h.succeeded++
+				}
+			case m.IsOverdue:
+				h.finished++
+				h.active++
+			default:
+				h.active++
+			}
+
+			if cat.cat == nil || creditedBreeds[cat.cat.Breed] || !(m.IsCompleted || m.IsOverdue) {
+				continue
+			}
+			creditedBreeds[cat.cat.Breed] = true
+			breed, ok := breedHistories[cat.cat.Breed]
+			if !ok {
+				breed = &catHistory{countries: make(map[string]bool)}
+				breedHistories[cat.cat.Breed] = breed
+			}
+			breed.finished++
+			if m.IsCompleted && !m.IsOverdue {
+				breed.succeeded++
+			}
+		}
+	}
+
+	return histories, breedHistories
+}
+
+type missionCat struct {
+	id  uint
+	cat *models.SpyCat
+}
+
+// missionCats lists the lead and the members of a mission with the cats that were loaded for them
+func missionCats(m *models.Mission) []missionCat {
+	var cats []missionCat
+	if m.CatID != nil {
+		cats = append(cats, missionCat{id: *m.CatID, cat: m.Cat})
+	}
+	for _, member := range m.Members {
+		cats = append(cats, missionCat{id: member.CatID, cat: member.Cat})
+	}
+	return cats
+}
+
+func experienceComponent(cat models.SpyCat) models.ScoreComponent {
+	value := math.Min(float64(cat.YearsExperience)/fullExperienceYears, 1)
+	return component("experience", value, experienceWeight, fmt.Sprintf("%d years of experience", cat.YearsExperience))
+}
+
+func breedComponent(cat models.SpyCat, history *catHistory) models.ScoreComponent {
+	value, known := history.successRate()
+	detail := fmt.Sprintf("no finished missions for %s cats yet", cat.Breed)
+	if known {
+		detail = fmt.Sprintf("%s cats succeeded in %d of %d missions", cat.Breed, history.succeeded, history.finished)
+	}
+	return component("breed", value, breedWeight, detail)
+}
+
+func successComponent(history *catHistory) models.ScoreComponent {
+	value, known := history.successRate()
+	detail := "no finished missions yet"
+	if known {
+		detail = fmt.Sprintf("succeeded in %d of %d missions", history.succeeded, history.finished)
+	}
+	return component("success_rate", value, successWeight, detail)
+}
+
+func countryComponent(mission *models.Mission, history *catHistory) models.ScoreComponent {
+	countries := make(map[string]bool)
+	for _, t := range mission.Targets {
+		countries[strings.ToLower(t.Country)] = true
+	}
+
+	if len(countries) == 0 {
+		return component("countries", 0, countryWeight, "mission has no targets")
+	}
+
+	known := 0
+	if history != nil {
+		for country := range countries {
+			if history.countries[country] {
+				known++
+			}
+		}
+	}
+
+	value := float64(known) / float64(len(countries))
+	return component("countries", value, countryWeight, fmt.Sprintf("has worked in %d of %d target countries", known, len(countries)))
+}
+
+func salaryComponent(salary money.Money, budget *money.Money, minSalary, maxSalary money.Money) models.ScoreComponent {
+	if budget != nil {
+		if salary.Amount > budget.Amount {
+			return component("salary", 0, salaryWeight, fmt.Sprintf("salary %s exceeds budget %s", salary, *budget))
+		}
+		value := 1.0
+		if budget.IsPositive() {
+			value = 1 - 0.5*float64(salary.Amount)/float64(budget.Amount)
+		}
+		return component("salary", value, salaryWeight, fmt.Sprintf("salary %s within budget %s", salary, *budget))
+	}
+
+	value := 1.0
+	if maxSalary.Amount > minSalary.Amount {
+		value = float64(maxSalary.Amount-salary.Amount) / float64(maxSalary.Amount-minSalary.Amount)
+	}
+	return component("salary", value, salaryWeight, fmt.Sprintf("salary %s among candidates ranging %s-%s", salary, minSalary, maxSalary))
+}
+
+func workloadComponent(history *catHistory, bookings []models.AvailabilityEntry) models.ScoreComponent {
+	load := len(bookings)
+	if history != nil {
+		load += history.active
+	}
+
+	value := 1 / float64(1+load)
+	return component("workload", value, workloadWeight, fmt.Sprintf("%d active missions or upcoming calendar entries", load))
+}
+
+func component(factor string, value, weight float64, detail string) models.ScoreComponent {
+	return models.ScoreComponent{
+		Factor:       factor,
+		Value:        round(value),
+		Weight:       weight,
+		Contribution: round(value * weight),
+		Detail:       detail,
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type RetentionEnforcer struct {
+	retentionService RetentionService
+	interval         time.Duration
+}
+
+func NewRetentionEnforcer(retentionService RetentionService, interval time.Duration) *RetentionEnforcer {
+	return &RetentionEnforcer{
+		retentionService: retentionService,
+		interval:         interval,
+	}
+}
+
+func (e *RetentionEnforcer) Start(ctx context.Context) {
+	runPeriodically(ctx, "retention enforcement", e.interval, e.Apply)
+}
+
+func (e *RetentionEnforcer) Apply() {
+	result, err := e.retentionService.ApplyPolicies()
+	if err != nil {
+		log.Printf("Retention enforcement failed: %v", err)
+		return
+	}
+
+	if result.NotesPurged+result.Pseudonymized > 0 {
+		log.Printf("Purged notes of %d target(s) and pseudonymized %d target(s)", result.NotesPurged, result.Pseudonymized)
+	}
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"spy-cat-agency/internal/country"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"strings"
+	"time"
+)
+
+type RetentionService interface {
+	ListPolicies() ([]models.RetentionPolicy, error)
+	SetPolicy(classification string, req *models.SetRetentionPolicyRequest) (*models.RetentionPolicy, error)
+	DeletePolicy(classification string) error
+	ApplyPolicies() (*models.RetentionResult, error)
+	CreateErasureRequest(req *models.CreateErasureRequest) (*models.ErasureRequest, error)
+	ListErasureRequests() ([]models.ErasureRequest, error)
+	GetErasureRequest(id uint) (*models.ErasureRequest, error)
+	ExecuteErasureRequest(id uint) (*models.ErasureReport, error)
+	GetErasureReport(id uint) (*models.ErasureReport, error)
+}
+
+type retentionService struct {
+	retentionRepo repository.RetentionRepository
+	key           []byte
+}
+
+func NewRetentionService(retentionRepo repository.RetentionRepository, key string) RetentionService {
+	return &retentionService{
+		retentionRepo: retentionRepo,
+		key:           []byte(key),
+	}
+}
+
+func (s *retentionService) ListPolicies() ([]models.RetentionPolicy, error) {
+	return s.retentionRepo.GetPolicies()
+}
+
+func (s *retentionService) SetPolicy(classification string, req *models.SetRetentionPolicyRequest) (*models.RetentionPolicy, error) {
+	if err := validateClassification(classification); err != nil {
+		return nil, err
+	}
+
+	if req.PurgeNotesAfterDays == 0 && req.PseudonymizeAfterDays == 0 {
+		return nil, fmt.Errorf("policy must purge notes or pseudonymize names")
+	}
+
+	policy := &models.RetentionPolicy{
+		Classification:        classification,
+		PurgeNotesAfterDays:   req.PurgeNotesAfterDays,
+		PseudonymizeAfterDays: req.PseudonymizeAfterDays,
+	}
+
+	if err := s.retentionRepo.UpsertPolicy(policy); err != nil {
+		return nil, fmt.Errorf("failed to save retention policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (s *retentionService) DeletePolicy(classification string) error {
+	return s.retentionRepo.DeletePolicy(classification)
+}
+
+// Policy periods are counted from the completion of the target's mission; 0 days means never
+func (s *retentionService) ApplyPolicies() (*models.RetentionResult, error) {
+	policies, err := s.retentionRepo.GetPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load retention policies: %w", err)
+	}
+
+	now := time.Now()
+	result := &models.RetentionResult{}
+
+	for _, policy := range policies {
+		if policy.PurgeNotesAfterDays > 0 {
+			purged, err := s.retentionRepo.PurgeNotes(policy.Classification, now.AddDate(0, 0, -policy.PurgeNotesAfterDays), now)
+			if err != nil {
+				return nil, fmt.Errorf("failed to purge %s notes: %w", policy.Classification, err)
+			}
+			result.NotesPurged += purged
+		}
+
+		if policy.PseudonymizeAfterDays > 0 {
+			targets, err := s.retentionRepo.GetTargetsToPseudonymize(policy.Classification, now.AddDate(0, 0, -policy.PseudonymizeAfterDays))
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s targets: %w", policy.Classification, err)
+			}
+			for _, target := range targets {
+				if err := s.retentionRepo.PseudonymizeTarget(target.ID, s.pseudonym(target.Name), now); err != nil {
+					return nil, fmt.Errorf("failed to pseudonymize target %d: %w", target.ID, err)
+				}
+				result.Pseudonymized++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (s *retentionService) CreateErasureRequest(req *models.CreateErasureRequest) (*models.ErasureRequest, error) {
+	code := ""
+	if strings.TrimSpace(req.Country) != "" {
+		normalized, _, err := country.Normalize(req.Country)
+		if err != nil {
+			return nil, err
+		}
+		code = normalized
+	}
+
+	request := &models.ErasureRequest{
+		SubjectName:  strings.TrimSpace(req.SubjectName),
+		SubjectToken: s.pseudonym(req.SubjectName),
+		Country:      code,
+		Reason:       req.Reason,
+		Status:       models.ErasureStatusPending,
+	}
+
+	if err := s.retentionRepo.CreateErasureRequest(request); err != nil {
+		return nil, fmt.Errorf("failed to create erasure request: %w", err)
+	}
+
+	matches, dossiers, err := s.findMatches(request)
+	if err != nil {
+		return nil, err
+	}
+	request.Matches = matches
+	request.DossierMatches = dossiers
+
+	return request, nil
+}
+
+func (s *retentionService) ListErasureRequests() ([]models.ErasureRequest, error) {
+	return s.retentionRepo.GetErasureRequests()
+}
+
+// Pending requests list the targets and dossiers that would be erased
+func (s *retentionService) GetErasureRequest(id uint) (*models.ErasureRequest, error) {
+	request, err := s.retentionRepo.GetErasureRequest(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status == models.ErasureStatusPending {
+		request.Matches, request.DossierMatches, err = s.findMatches(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return request, nil
+}
+
+func (s *retentionService) ExecuteErasureRequest(id uint) (*models.ErasureReport, error) {
+	request, err := s.retentionRepo.GetErasureRequest(id)
+	if err != nil {
+		return nil, fmt.Errorf("erasure request not found: %w", err)
+	}
+
+	if request.Status != models.ErasureStatusPending {
+		return nil, fmt.Errorf("erasure request is already %s", request.Status)
+	}
+
+	matches, dossiers, err := s.findMatches(request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	records := make([]models.ErasureRecord, 0, len(matches))
+	for _, target := range matches {
+		var fields []string
+		if target.Name != request.SubjectToken {
+			fields = append(fields, "name")
+		}
+		if target.Notes != "" {
+			fields = append(fields, "notes")
+		}
+		if _, _, ok := models.TargetLocation(&target); ok {
+			fields = append(fields, "location")
+		}
+		if target.DossierID != nil {
+			fields = append(fields, "dossier")
+		}
+		records = append(records, models.ErasureRecord{
+			RequestID: request.ID,
+			TargetID:  target.ID,
+			MissionID: target.MissionID,
+			Fields:    strings.Join(fields, ","),
+			ErasedAt:  now,
+		})
+	}
+
+	dossierRecords := make([]models.ErasureDossierRecord, 0, len(dossiers))
+	for _, dossier := range dossiers {
+		dossierRecords = append(dossierRecords, models.ErasureDossierRecord{
+			RequestID: request.ID,
+			DossierID: dossier.ID,
+			Aliases:   len(dossier.Aliases),
+			Photos:    len(dossier.Photos),
+			ErasedAt:  now,
+		})
+	}
+
+	// The request itself must not keep the subject's name once it is carried out
+	request.SubjectName = request.SubjectToken
+	request.Status = models.ErasureStatusCompleted
+	request.CompletedAt = &now
+
+	if err := s.retentionRepo.EraseTargets(request, records, dossierRecords); err != nil {
+		return nil, fmt.Errorf("failed to erase targets: %w", err)
+	}
+
+	request.Records = records
+	request.DossierRecords = dossierRecords
+	return buildErasureReport(request), nil
+}
+
+func (s *retentionService) GetErasureReport(id uint) (*models.ErasureReport, error) {
+	request, err := s.retentionRepo.GetErasureRequest(id)
+	if err != nil {
+		return nil, fmt.Errorf("erasure request not found: %w", err)
+	}
+
+	if request.Status != models.ErasureStatusCompleted {
+		return nil, fmt.Errorf("erasure request is still %s", request.Status)
+	}
+
+	return buildErasureReport(request), nil
+}
+
+// Targets pseudonymized earlier carry the subject's token instead of the name. Dossiers match the
+// name or are linked to a matching target.
+func (s *retentionService) findMatches(request *models.ErasureRequest) ([]models.Target, []models.Dossier, error) {
+	names := []string{strings.ToLower(request.SubjectToken)}
+	if request.Status == models.ErasureStatusPending {
+		names = append(names, normalizeSubjectName(request.SubjectName))
+	}
+
+	targets, err := s.retentionRepo.FindSubjectTargets(names, request.Country)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search targets: %w", err)
+	}
+
+	targetIDs := make([]uint, 0, len(targets))
+	for _, target := range targets {
+		targetIDs = append(targetIDs, target.ID)
+	}
+	dossiers, err := s.retentionRepo.FindSubjectDossiers(names, request.Country, targetIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search dossiers: %w", err)
+	}
+	return targets, dossiers, nil
+}
+
+// Tokens are stable per person, so pseudonymized targets can still be linked across missions
+func (s *retentionService) pseudonym(name string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(normalizeSubjectName(name)))
+	return "subject-" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+func buildErasureReport(request *models.ErasureRequest) *models.ErasureReport {
+	report := &models.ErasureReport{
+		RequestID:      request.ID,
+		SubjectToken:   request.SubjectToken,
+		Status:         request.Status,
+		CompletedAt:    request.CompletedAt,
+		TargetsErased:  len(request.Records),
+		DossiersErased: len(request.DossierRecords),
+		Records:        request.Records,
+		DossierRecords: request.DossierRecords,
+	}
+
+	missions := make(map[uint]bool)
+	for _, record := range request.Records {
+		missions[record.MissionID] = true
+	}
+	report.MissionsTouched = len(missions)
+
+	return report
+}
+
+func normalizeSubjectName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func validateClassification(classification string) error {
+	switch classification {
+	case models.ClassificationPublic, models.ClassificationConfidential, models.ClassificationSecret, models.ClassificationTopSecret:
+		return nil
+	default:
+		return fmt.Errorf("unknown classification %q", classification)
+	}
+}
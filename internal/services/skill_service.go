@@ -0,0 +1,218 @@
+package services
+
+import (
+	"fmt"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"time"
+)
+
+type SkillService interface {
+	CreateSkill(req *models.CreateSkillRequest) (*models.Skill, error)
+	GetSkill(id uint) (*models.Skill, error)
+	ListSkills() ([]models.Skill, error)
+	UpdateSkill(id uint, req *models.UpdateSkillRequest) (*models.Skill, error)
+	DeleteSkill(id uint) error
+	ListCatSkills(catID uint) ([]models.CatSkill, error)
+	AddCatSkill(catID uint, req *models.AddCatSkillRequest) (*models.CatSkill, error)
+	UpdateCatSkill(catID, skillID uint, req *models.UpdateCatSkillRequest) (*models.CatSkill, error)
+	RemoveCatSkill(catID, skillID uint) error
+	ListExpiringCertifications(within time.Duration) ([]models.CatSkill, error)
+	GetMissionSkills(missionID uint) ([]models.MissionSkillRequirement, error)
+	SetMissionSkills(missionID uint, req *models.SetMissionSkillsRequest) ([]models.MissionSkillRequirement, error)
+}
+
+type skillService struct {
+	skillRepo   repository.SkillRepository
+	catRepo     repository.CatRepository
+	missionRepo repository.MissionRepository
+}
+
+func NewSkillService(skillRepo repository.SkillRepository, catRepo repository.CatRepository, missionRepo repository.MissionRepository) SkillService {
+	return &skillService{
+		skillRepo:   skillRepo,
+		catRepo:     catRepo,
+		missionRepo: missionRepo,
+	}
+}
+
+func (s *skillService) CreateSkill(req *models.CreateSkillRequest) (*models.Skill, error) {
+	skill := &models.Skill{
+		Name:        req.Name,
+		Category:    req.Category,
+		Description: req.Description,
+	}
+
+	if err := s.skillRepo.Create(skill); err != nil {
+		return nil, fmt.Errorf("failed to create skill: %w", err)
+	}
+
+	return skill, nil
+}
+
+func (s *skillService) GetSkill(id uint) (*models.Skill, error) {
+	return s.skillRepo.GetByID(id)
+}
+
+func (s *skillService) ListSkills() ([]models.Skill, error) {
+	return s.skillRepo.GetAll()
+}
+
+func (s *skillService) UpdateSkill(id uint, req *models.UpdateSkillRequest) (*models.Skill, error) {
+	skill, err := s.skillRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("skill not found: %w", err)
+	}
+
+	skill.Name = req.Name
+	skill.Category = req.Category
+	skill.Description = req.Description
+
+	if err := s.skillRepo.Update(skill); err != nil {
+		return nil, fmt.Errorf("failed to update skill: %w", err)
+	}
+
+	return skill, nil
+}
+
+func (s *skillService) DeleteSkill(id uint) error {
+	if _, err := s.skillRepo.GetByID(id); err != nil {
+		return fmt.Errorf("skill not found: %w", err)
+	}
+
+	usage, err := s.skillRepo.CountUsage(id)
+	if err != nil {
+		return fmt.Errorf("failed to check skill usage: %w", err)
+	}
+
+	if usage > 0 {
+		return fmt.Errorf("cannot delete skill that is held by cats or required by missions")
+	}
+
+	return s.skillRepo.Delete(id)
+}
+
+func (s *skillService) ListCatSkills(catID uint) ([]models.CatSkill, error) {
+	if _, err := s.catRepo.GetByID(catID); err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	return s.skillRepo.GetCatSkills(catID)
+}
+
+func (s *skillService) AddCatSkill(catID uint, req *models.AddCatSkillRequest) (*models.CatSkill, error) {
+	if _, err := s.catRepo.GetByID(catID); err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	skill, err := s.skillRepo.GetByID(req.SkillID)
+	if err != nil {
+		return nil, fmt.Errorf("skill not found: %w", err)
+	}
+
+	if _, err := s.skillRepo.GetCatSkill(catID, req.SkillID); err == nil {
+		return nil, fmt.Errorf("cat already has skill %s", skill.Name)
+	}
+
+	if err := validateCertification(req.CertifiedAt, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	catSkill := &models.CatSkill{
+		CatID:       catID,
+		SkillID:     req.SkillID,
+		Skill:       skill,
+		Level:       req.Level,
+		CertifiedAt: req.CertifiedAt,
+		ExpiresAt:   req.ExpiresAt,
+	}
+
+	if err := s.skillRepo.AddCatSkill(catSkill); err != nil {
+		return nil, fmt.Errorf("failed to add skill: %w", err)
+	}
+
+	return catSkill, nil
+}
+
+func (s *skillService) UpdateCatSkill(catID, skillID uint, req *models.UpdateCatSkillRequest) (*models.CatSkill, error) {
+	catSkill, err := s.skillRepo.GetCatSkill(catID, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("cat skill not found: %w", err)
+	}
+
+	if err := validateCertification(req.CertifiedAt, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	catSkill.Level = req.Level
+	catSkill.CertifiedAt = req.CertifiedAt
+	catSkill.ExpiresAt = req.ExpiresAt
+
+	if err := s.skillRepo.UpdateCatSkill(catSkill); err != nil {
+		return nil, fmt.Errorf("failed to update cat skill: %w", err)
+	}
+
+	return catSkill, nil
+}
+
+func (s *skillService) RemoveCatSkill(catID, skillID uint) error {
+	if _, err := s.skillRepo.GetCatSkill(catID, skillID); err != nil {
+		return fmt.Errorf("cat skill not found: %w", err)
+	}
+
+	return s.skillRepo.DeleteCatSkill(catID, skillID)
+}
+
+func (s *skillService) ListExpiringCertifications(within time.Duration) ([]models.CatSkill, error) {
+	now := time.Now()
+	return s.skillRepo.GetExpiringCatSkills(now, now.Add(within))
+}
+
+func (s *skillService) GetMissionSkills(missionID uint) ([]models.MissionSkillRequirement, error) {
+	if _, err := s.missionRepo.GetByID(missionID); err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	return s.skillRepo.GetMissionRequirements(missionID)
+}
+
+func (s *skillService) SetMissionSkills(missionID uint, req *models.SetMissionSkillsRequest) ([]models.MissionSkillRequirement, error) {
+	mission, err := s.missionRepo.GetByID(missionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("cannot change required skills of completed mission")
+	}
+
+	requirements := make([]models.MissionSkillRequirement, 0, len(req.RequiredSkills))
+	seen := make(map[uint]bool, len(req.RequiredSkills))
+	for _, r := range req.RequiredSkills {
+		if seen[r.SkillID] {
+			return nil, fmt.Errorf("skill %d is required more than once", r.SkillID)
+		}
+		seen[r.SkillID] = true
+
+		if _, err := s.skillRepo.GetByID(r.SkillID); err != nil {
+			return nil, fmt.Errorf("skill %d not found: %w", r.SkillID, err)
+		}
+		requirements = append(requirements, models.MissionSkillRequirement{
+			SkillID:  r.SkillID,
+			MinLevel: r.MinLevel,
+		})
+	}
+
+	if err := s.skillRepo.SetMissionRequirements(missionID, requirements); err != nil {
+		return nil, fmt.Errorf("failed to save required skills: %w", err)
+	}
+
+	return s.skillRepo.GetMissionRequirements(missionID)
+}
+
+func validateCertification(certifiedAt, expiresAt *time.Time) error {
+	if certifiedAt != nil && expiresAt != nil && !expiresAt.After(*certifiedAt) {
+		return fmt.Errorf("certification must expire after it was issued")
+	}
+	return nil
+}
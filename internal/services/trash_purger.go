@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type TrashPurger struct {
+	trashService TrashService
+	interval     time.Duration
+}
+
+func NewTrashPurger(trashService TrashService, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		trashService: trashService,
+		interval:     interval,
+	}
+}
+
+func (p *TrashPurger) Start(ctx context.Context) {
+	runPeriodically(ctx, "trash purge", p.interval, p.Purge)
+}
+
+func (p *TrashPurger) Purge() {
+	result, err := p.trashService.Purge()
+	if err != nil {
+		log.Printf("Trash purge failed: %v", err)
+		return
+	}
+
+	if result.Cats+result.Missions+result.Targets > 0 {
+		log.Printf("Purged %d cat(s), %d mission(s) and %d target(s) from the trash", result.Cats, result.Missions, result.Targets)
+	}
+}
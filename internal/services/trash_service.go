@@ -0,0 +1,231 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/repository"
+	"time"
+)
+
+type TrashService interface {
+	ListTrash(itemType string) ([]models.TrashItem, error)
+	Restore(itemType string, id uint) (interface{}, error)
+	Purge() (*models.PurgeResult, error)
+}
+
+type trashService struct {
+	catRepo     repository.CatRepository
+	missionRepo repository.MissionRepository
+	targetRepo  repository.TargetRepository
+	retention   time.Duration
+}
+
+func NewTrashService(catRepo repository.CatRepository, missionRepo repository.MissionRepository, targetRepo repository.TargetRepository, retention time.Duration) TrashService {
+	return &trashService{
+		catRepo:     catRepo,
+		missionRepo: missionRepo,
+		targetRepo:  targetRepo,
+		retention:   retention,
+	}
+}
+
+func (s *trashService) ListTrash(itemType string) ([]models.TrashItem, error) {
+	if err := validateTrashType(itemType); err != nil {
+		return nil, err
+	}
+
+	items := []models.TrashItem{}
+
+	if itemType == "" || itemType == models.TrashTypeCat {
+		cats, err := s.catRepo.ListDeleted()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deleted cats: %w", err)
+		}
+		for i := range cats {
+			items = append(items, s.catItem(&cats[i]))
+		}
+	}
+
+	if itemType == "" || itemType == models.TrashTypeMission {
+		missions, err := s.missionRepo.ListDeleted()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deleted missions: %w", err)
+		}
+		for i := range missions {
+			items = append(items, s.missionItem(&missions[i]))
+		}
+	}
+
+	if itemType == "" || itemType == models.TrashTypeTarget {
+		targets, err := s.targetRepo.ListDeleted()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deleted targets: %w", err)
+		}
+		for i := range targets {
+			items = append(items, s.targetItem(&targets[i]))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+
+	return items, nil
+}
+
+func (s *trashService) Restore(itemType string, id uint) (interface{}, error) {
+	switch itemType {
+	case models.TrashTypeCat:
+		return s.restoreCat(id)
+	case models.TrashTypeMission:
+		return s.restoreMission(id)
+	case models.TrashTypeTarget:
+		return s.restoreTarget(id)
+	default:
+		return nil, validateTrashType(itemType)
+	}
+}
+
+func (s *trashService) Purge() (*models.PurgeResult, error) {
+	if s.retention <= 0 {
+		return nil, fmt.Errorf("trash retention is not configured")
+	}
+
+	before := time.Now().Add(-s.retention)
+	result := &models.PurgeResult{}
+
+	var err error
+	if result.Targets, err = s.targetRepo.PurgeDeleted(before); err != nil {
+		return nil, fmt.Errorf("failed to purge targets: %w", err)
+	}
+	if result.Missions, err = s.missionRepo.PurgeDeleted(before); err != nil {
+		return nil, fmt.Errorf("failed to purge missions: %w", err)
+	}
+	// Cats go last so that purged missions no longer reference them
+	if result.Cats, err = s.catRepo.PurgeDeleted(before); err != nil {
+		return nil, fmt.Errorf("failed to purge cats: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *trashService) restoreCat(id uint) (interface{}, error) {
+	if _, err := s.catRepo.GetDeleted(id); err != nil {
+		return nil, fmt.Errorf("deleted cat not found: %w", err)
+	}
+
+	if err := s.catRepo.Restore(id); err != nil {
+		return nil, fmt.Errorf("failed to restore cat: %w", err)
+	}
+
+	cat, err := s.catRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("cat not found: %w", err)
+	}
+
+	return cat, nil
+}
+
+func (s *trashService) restoreMission(id uint) (interface{}, error) {
+	mission, err := s.missionRepo.GetDeleted(id)
+	if err != nil {
+		return nil, fmt.Errorf("deleted mission not found: %w", err)
+	}
+
+	if mission.CatID != nil {
+		if _, err := s.catRepo.GetByID(*mission.CatID); err != nil {
+			return nil, fmt.Errorf("mission's cat %d is deleted; restore it first", *mission.CatID)
+		}
+	}
+
+	if err := s.missionRepo.Restore(id); err != nil {
+		return nil, fmt.Errorf("failed to restore mission: %w", err)
+	}
+
+	mission, err = s.missionRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("mission not found: %w", err)
+	}
+
+	return mission, nil
+}
+
+func (s *trashService) restoreTarget(id uint) (interface{}, error) {
+	target, err := s.targetRepo.GetDeleted(id)
+	if err != nil {
+		return nil, fmt.Errorf("deleted target not found: %w", err)
+	}
+
+	mission, err := s.missionRepo.GetByID(target.MissionID)
+	if err != nil {
+		return nil, fmt.Errorf("mission %d is deleted; restore it first", target.MissionID)
+	}
+
+	if mission.IsCompleted {
+		return nil, fmt.Errorf("cannot restore target into completed mission")
+	}
+
+	count, err := s.targetRepo.CountByMissionID(mission.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count targets: %w", err)
+	}
+
+	if count >= 3 {
+		return nil, fmt.Errorf("mission already has maximum number of targets (3)")
+	}
+
+	if err := s.targetRepo.Restore(id); err != nil {
+		return nil, fmt.Errorf("failed to restore target: %w", err)
+	}
+
+	// The mission may have changed hands while the target was in the trash
+	if target.CatID != nil && !missionHasCat(mission, *target.CatID) {
+		if err := s.targetRepo.AssignCat(id, nil); err != nil {
+			return nil, fmt.Errorf("failed to clear target assignment: %w", err)
+		}
+	}
+
+	target, err = s.targetRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	return target, nil
+}
+
+func (s *trashService) catItem(cat *models.SpyCat) models.TrashItem {
+	return s.item(models.TrashTypeCat, cat.ID, cat.Name, cat.DeletedAt.Time, cat)
+}
+
+func (s *trashService) missionItem(mission *models.Mission) models.TrashItem {
+	return s.item(models.TrashTypeMission, mission.ID, fmt.Sprintf("Mission %d", mission.ID), mission.DeletedAt.Time, mission)
+}
+
+func (s *trashService) targetItem(target *models.Target) models.TrashItem {
+	return s.item(models.TrashTypeTarget, target.ID, target.Name, target.DeletedAt.Time, target)
+}
+
+func (s *trashService) item(itemType string, id uint, label string, deletedAt time.Time, record interface{}) models.TrashItem {
+	item := models.TrashItem{
+		Type:      itemType,
+		ID:        id,
+		Label:     label,
+		DeletedAt: deletedAt,
+		Record:    record,
+	}
+	if s.retention > 0 {
+		purgeAt := deletedAt.Add(s.retention)
+		item.PurgeAt = &purgeAt
+	}
+	return item
+}
+
+func validateTrashType(itemType string) error {
+	switch itemType {
+	case "", models.TrashTypeCat, models.TrashTypeMission, models.TrashTypeTarget:
+		return nil
+	default:
+		return fmt.Errorf("unknown trash type %q", itemType)
+	}
+}
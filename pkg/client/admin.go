@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type AdminService struct {
+	client *Client
+}
+
+// AuditLog returns audit entries, newest first; filter.Limit defaults to 100 on the server
+func (s *AdminService) AuditLog(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	query := url.Values{}
+	setString(query, "actor", filter.Actor)
+	setString(query, "action", filter.Action)
+	setTime(query, "since", filter.Since)
+	setInt(query, "limit", filter.Limit)
+
+	var entries []models.AuditEntry
+	err := s.client.do(ctx, "GET", "/audit-log", query, nil, &entries)
+	return entries, err
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"spy-cat-agency/internal/models"
+)
+
+type AvailabilityService struct {
+	client *Client
+}
+
+func (s *AvailabilityService) ListEntries(ctx context.Context, catID uint) ([]models.AvailabilityEntry, error) {
+	var entries []models.AvailabilityEntry
+	err := s.client.do(ctx, "GET", fmt.Sprintf("/cats/%d/availability", catID), nil, nil, &entries)
+	return entries, err
+}
+
+func (s *AvailabilityService) AddEntry(ctx context.Context, catID uint, req *models.CreateAvailabilityEntryRequest) (*models.AvailabilityEntry, error) {
+	var entry models.AvailabilityEntry
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/cats/%d/availability", catID), nil, req, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *AvailabilityService) DeleteEntry(ctx context.Context, catID, entryID uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/cats/%d/availability/%d", catID, entryID), nil, nil, nil)
+}
+
+// ListAvailableCats returns the cats free between from and to; a nil from means now, a nil to means open-ended
+func (s *AvailabilityService) ListAvailableCats(ctx context.Context, from, to *time.Time) ([]models.SpyCat, error) {
+	query := url.Values{}
+	setTime(query, "from", from)
+	setTime(query, "to", to)
+
+	var cats []models.SpyCat
+	err := s.client.do(ctx, "GET", "/cats/available", query, nil, &cats)
+	return cats, err
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+)
+
+type CatService struct {
+	client *Client
+}
+
+func (s *CatService) Create(ctx context.Context, req *models.CreateCatRequest) (*models.SpyCat, error) {
+	var cat models.SpyCat
+	if err := s.client.do(ctx, "POST", "/cats", nil, req, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// List returns the cats matching filter; set filter.Limit to get a single page
+func (s *CatService) List(ctx context.Context, filter models.CatFilter) ([]models.SpyCat, error) {
+	var cats []models.SpyCat
+	err := s.client.do(ctx, "GET", "/cats", catQuery(filter), nil, &cats)
+	return cats, err
+}
+
+func catQuery(filter models.CatFilter) url.Values {
+	query := url.Values{}
+	setString(query, "skill", filter.Skill)
+	setString(query, "status", filter.Status)
+	setInt(query, "min_level", filter.MinLevel)
+	setPage(query, filter.Limit, filter.Offset)
+	return query
+}
+
+// Iter pages through the cats matching filter, filter.Limit cats per request
+func (s *CatService) Iter(filter models.CatFilter) *Iterator[models.SpyCat] {
+	return newIterator(filter.Limit, filter.Offset, func(ctx context.Context, limit, offset int) ([]models.SpyCat, error) {
+		page := filter
+		page.Limit, page.Offset = limit, offset
+		return s.List(ctx, page)
+	})
+}
+
+func (s *CatService) Get(ctx context.Context, id uint) (*models.SpyCat, error) {
+	var cat models.SpyCat
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/cats/%d", id), nil, nil, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (s *CatService) Update(ctx context.Context, id uint, req *models.UpdateCatRequest) (*models.SpyCat, error) {
+	var cat models.SpyCat
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/cats/%d", id), nil, req, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (s *CatService) ChangeStatus(ctx context.Context, id uint, req *models.ChangeCatStatusRequest) (*models.SpyCat, error) {
+	var cat models.SpyCat
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/cats/%d/status", id), nil, req, &cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// Delete removes a cat; reassignTo, when set, takes over the cat's open mission
+func (s *CatService) Delete(ctx context.Context, id uint, reassignTo *uint) error {
+	query := url.Values{}
+	if reassignTo != nil {
+		query.Set("reassign_to", strconv.FormatUint(uint64(*reassignTo), 10))
+	}
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/cats/%d", id), query, nil, nil)
+}
+
+// SalaryTotals sums all salaries, converted into currency when it is not empty
+func (s *CatService) SalaryTotals(ctx context.Context, currency string) (*models.SalaryTotals, error) {
+	query := url.Values{}
+	setString(query, "currency", currency)
+
+	var totals models.SalaryTotals
+	if err := s.client.do(ctx, "GET", "/cats/salaries/total", query, nil, &totals); err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
@@ -0,0 +1,212 @@
+// Package client is a Go client for the Spy Cat Agency API (/api/v1).
+//
+//	c := client.New("http://localhost:8080", client.WithToken(os.Getenv("SPYCAT_TOKEN")))
+//	cat, err := c.Cats.Get(ctx, 1)
+//	if errors.Is(err, client.ErrNotFound) { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetries = 3
+	defaultBackoff = 200 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+
+	Admin           *AdminService
+	Availability    *AvailabilityService
+	Cats            *CatService
+	Countries       *CountryService
+	Dossiers        *DossierService
+	Expenses        *ExpenseService
+	Exports         *ExportService
+	Geo             *GeoService
+	Import          *ImportService
+	Maps            *MapService
+	Missions        *MissionService
+	Payroll         *PayrollService
+	Recommendations *RecommendationService
+	Retention       *RetentionService
+	Skills          *SkillService
+	Targets         *TargetService
+	Trash           *TrashService
+}
+
+type Option func(*Client)
+
+// WithToken sends an admin token as a bearer token with every request
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetries sets how often a request is retried after a 5xx response or a network error,
+// waiting backoff before the first retry and twice as long before each next one
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New creates a client for the server at baseURL, e.g. "https://agency.example.com".
+// A base URL that already ends in /api/v1 is used as is.
+func New(baseURL string, opts ...Option) *Client {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if !strings.HasSuffix(baseURL, "/api/v1") {
+		baseURL += "/api/v1"
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Admin = &AdminService{c}
+	c.Availability = &AvailabilityService{c}
+	c.Cats = &CatService{c}
+	c.Countries = &CountryService{c}
+	c.Dossiers = &DossierService{c}
+	c.Expenses = &ExpenseService{c}
+	c.Exports = &ExportService{c}
+	c.Geo = &GeoService{c}
+	c.Import = &ImportService{c}
+	c.Maps = &MapService{c}
+	c.Missions = &MissionService{c}
+	c.Payroll = &PayrollService{c}
+	c.Recommendations = &RecommendationService{c}
+	c.Retention = &RetentionService{c}
+	c.Skills = &SkillService{c}
+	c.Targets = &TargetService{c}
+	c.Trash = &TrashService{c}
+	return c
+}
+
+// rawBody is sent as is instead of being encoded as JSON
+type rawBody struct {
+	contentType string
+	data        []byte
+}
+
+// do sends body as JSON and decodes the response into out, which may be nil, a *[]byte for raw bodies
+// or a *io.ReadCloser for streamed ones.
+// POST requests are never retried because they are not idempotent.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var payload []byte
+	contentType := "application/json"
+	if raw, ok := body.(rawBody); ok {
+		payload, contentType = raw.data, raw.contentType
+	} else if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	retries := c.retries
+	if method == http.MethodPost {
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, target, payload, contentType)
+		if attempt >= retries || (err == nil && resp.StatusCode < 500) {
+			if err != nil {
+				return err
+			}
+			// A *io.ReadCloser receives the open body, which the caller closes
+			if body, ok := out.(*io.ReadCloser); ok && resp.StatusCode < 300 {
+				*body = resp.Body
+				return nil
+			}
+			defer resp.Body.Close()
+			return decodeResponse(resp, method, path, out)
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(c.retryDelay(attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, target string, payload []byte, contentType string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.httpClient.Do(req)
+}
+
+// Exponential backoff with up to 50% jitter so clients that failed together do not retry together
+func (c *Client) retryDelay(attempt int) time.Duration {
+	if c.backoff <= 0 {
+		return 0
+	}
+	delay := c.backoff << attempt
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func decodeResponse(resp *http.Response, method, path string, out interface{}) error {
+	if resp.StatusCode >= 300 {
+		return newAPIError(resp, method, path)
+	}
+	if raw, ok := out.(*[]byte); ok {
+		data, err := io.ReadAll(resp.Body)
+		*raw = data
+		return err
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,750 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spy-cat-agency/internal/config"
+	"spy-cat-agency/internal/database"
+	"spy-cat-agency/internal/middleware"
+	"spy-cat-agency/internal/models"
+	"spy-cat-agency/internal/money"
+	"spy-cat-agency/internal/repository"
+	"spy-cat-agency/internal/routes"
+	"spy-cat-agency/internal/services"
+	"spy-cat-agency/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// breedTransport answers the breed lookup of cat creation, so the tests do not need TheCatAPI
+type breedTransport struct {
+	next http.RoundTripper
+}
+
+func (t breedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != "api.thecatapi.com" {
+		return t.next.RoundTrip(req)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`[{"name":"Persian"},{"name":"Siamese"}]`)),
+		Request:    req,
+	}, nil
+}
+
+func TestMain(m *testing.M) {
+	http.DefaultTransport = breedTransport{next: http.DefaultTransport}
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// server is the API as cmd serve sets it up, on a migrated in-memory SQLite database
+type server struct {
+	*client.Client
+	db       *gorm.DB
+	url      string
+	requests atomic.Int64
+}
+
+func newServer(t *testing.T) *server {
+	db, err := database.Initialize("sqlite://:memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if _, err := database.MigrateUp(db, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	repos := repository.NewRepositories(db)
+	rates := money.NewRates("USD")
+	rates.Set("EUR", "0.5")
+	cfg := &config.Config{
+		PayrollCurrency:         "USD",
+		MissionBonus:            50000,
+		ExpenseWarningThreshold: 80,
+		TrashRetentionDays:      30,
+		PseudonymizationKey:     "test-key",
+	}
+	adminService := services.NewAdminService(repos.Admins)
+	router := gin.New()
+	routes.SetupRoutes(router, routes.NewHandlers(repos, cfg, rates), middleware.AuthMiddleware(adminService, false), middleware.AuditMiddleware(adminService))
+
+	s := &server{db: db}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	s.url = ts.URL
+	s.Client = client.New(ts.URL, client.WithRetries(0, 0))
+	return s
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func equal[T comparable](t *testing.T, what string, got, want T) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func (s *server) createCat(t *testing.T, name string) *models.SpyCat {
+	t.Helper()
+	cat, err := s.Cats.Create(context.Background(), &models.CreateCatRequest{
+		Name: name, YearsExperience: 3, Breed: "Persian", Salary: money.New(100000, "USD"),
+	})
+	must(t, err)
+	return cat
+}
+
+func (s *server) createMission(t *testing.T, catID *uint, targets ...string) *models.Mission {
+	t.Helper()
+	req := &models.CreateMissionRequest{CatID: catID}
+	for _, name := range targets {
+		req.Targets = append(req.Targets, models.CreateTargetRequest{Name: name, Country: "UA", Latitude: ptr(50.45), Longitude: ptr(30.52)})
+	}
+	mission, err := s.Missions.Create(context.Background(), req)
+	must(t, err)
+	return mission
+}
+
+func TestCats(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	felix := s.createCat(t, "Felix")
+	equal(t, "status", tom.Status, models.CatStatusActive)
+
+	got, err := s.Cats.Get(ctx, tom.ID)
+	must(t, err)
+	equal(t, "name", got.Name, "Tom")
+
+	updated, err := s.Cats.Update(ctx, tom.ID, &models.UpdateCatRequest{Salary: money.New(120000, "USD"), Reason: "raise"})
+	must(t, err)
+	equal(t, "salary", updated.Salary.Amount, int64(120000))
+
+	suspended, err := s.Cats.ChangeStatus(ctx, felix.ID, &models.ChangeCatStatusRequest{Status: models.CatStatusSuspended, Reason: "review"})
+	must(t, err)
+	equal(t, "status", suspended.Status, models.CatStatusSuspended)
+
+	active, err := s.Cats.List(ctx, models.CatFilter{Status: models.CatStatusActive})
+	must(t, err)
+	equal(t, "active cats", len(active), 1)
+
+	totals, err := s.Cats.SalaryTotals(ctx, "EUR")
+	must(t, err)
+	equal(t, "currency", totals.Total.Currency, "EUR")
+
+	must(t, s.Cats.Delete(ctx, felix.ID, nil))
+	_, err = s.Cats.Get(ctx, felix.ID)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("get deleted cat: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestMissions(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	felix := s.createCat(t, "Felix")
+	mission := s.createMission(t, nil, "John Doe")
+
+	got, err := s.Missions.Get(ctx, mission.ID)
+	must(t, err)
+	equal(t, "targets", len(got.Targets), 1)
+
+	deadline := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	updated, err := s.Missions.Update(ctx, mission.ID, &models.UpdateMissionRequest{DeadlineAt: &deadline})
+	must(t, err)
+	if updated.DeadlineAt == nil || !updated.DeadlineAt.Equal(deadline) {
+		t.Errorf("deadline: got %v, want %v", updated.DeadlineAt, deadline)
+	}
+
+	must(t, s.Missions.AssignCat(ctx, mission.ID, tom.ID))
+	withMember, err := s.Missions.AddCat(ctx, mission.ID, felix.ID)
+	must(t, err)
+	equal(t, "members", len(withMember.Members), 1)
+	must(t, s.Missions.RemoveCat(ctx, mission.ID, felix.ID))
+
+	targetID := got.Targets[0].ID
+	must(t, s.Targets.Complete(ctx, mission.ID, targetID, nil))
+	must(t, s.Missions.Complete(ctx, mission.ID))
+
+	completed, err := s.Missions.List(ctx, models.MissionFilter{State: "completed"})
+	must(t, err)
+	equal(t, "completed missions", len(completed), 1)
+
+	other := s.createMission(t, nil, "Jane Roe")
+	must(t, s.Missions.Delete(ctx, other.ID))
+	_, err = s.Missions.Get(ctx, other.ID)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("get deleted mission: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestTargets(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	mission := s.createMission(t, &tom.ID, "John Doe")
+
+	added, err := s.Targets.Add(ctx, mission.ID, &models.AddTargetRequest{Name: "Jane Roe", Country: "PL"})
+	must(t, err)
+	targets, err := s.Targets.List(ctx, mission.ID)
+	must(t, err)
+	equal(t, "targets", len(targets), 2)
+
+	renamed, err := s.Targets.Update(ctx, mission.ID, added.ID, &models.UpdateTargetRequest{Name: "Jane Smith", Country: "PL"})
+	must(t, err)
+	equal(t, "name", renamed.Name, "Jane Smith")
+	must(t, s.Targets.Delete(ctx, mission.ID, added.ID))
+
+	targetID := targets[0].ID
+	assigned, err := s.Targets.AssignCat(ctx, mission.ID, targetID, &models.AssignTargetCatRequest{CatID: &tom.ID})
+	must(t, err)
+	if assigned.CatID == nil || *assigned.CatID != tom.ID {
+		t.Errorf("target cat: got %v, want %d", assigned.CatID, tom.ID)
+	}
+	byCat, err := s.Targets.ListByCat(ctx, tom.ID)
+	must(t, err)
+	equal(t, "targets of cat", len(byCat), 1)
+
+	must(t, s.Targets.UpdateNotes(ctx, mission.ID, targetID, &models.UpdateTargetNotesRequest{CatID: &tom.ID, Notes: "seen at the market"}))
+	_, err = s.Targets.AddNote(ctx, mission.ID, targetID, &models.AddTargetNoteRequest{CatID: &tom.ID, Notes: "moved north", Latitude: ptr(50.5), Longitude: ptr(30.5)})
+	must(t, err)
+	notes, err := s.Targets.ListNotes(ctx, mission.ID, targetID)
+	must(t, err)
+	if len(notes) == 0 {
+		t.Error("notes: got none")
+	}
+
+	must(t, s.Targets.Complete(ctx, mission.ID, targetID, &models.CompleteTargetRequest{CatID: &tom.ID}))
+}
+
+func TestAvailability(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	s.createCat(t, "Felix")
+
+	from := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	to := from.Add(7 * 24 * time.Hour)
+	entry, err := s.Availability.AddEntry(ctx, tom.ID, &models.CreateAvailabilityEntryRequest{Kind: "leave", StartsAt: from, EndsAt: &to})
+	must(t, err)
+	entries, err := s.Availability.ListEntries(ctx, tom.ID)
+	must(t, err)
+	equal(t, "entries", len(entries), 1)
+
+	free, err := s.Availability.ListAvailableCats(ctx, &from, &to)
+	must(t, err)
+	equal(t, "available cats", len(free), 1)
+
+	must(t, s.Availability.DeleteEntry(ctx, tom.ID, entry.ID))
+	free, err = s.Availability.ListAvailableCats(ctx, &from, &to)
+	must(t, err)
+	equal(t, "available cats", len(free), 2)
+}
+
+func TestSkillsAndRecommendations(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	s.createCat(t, "Felix")
+	mission := s.createMission(t, nil, "John Doe")
+
+	skill, err := s.Skills.Create(ctx, &models.CreateSkillRequest{Name: "Lockpicking"})
+	must(t, err)
+	_, err = s.Skills.Update(ctx, skill.ID, &models.UpdateSkillRequest{Name: "Lockpicking", Category: "entry"})
+	must(t, err)
+	got, err := s.Skills.Get(ctx, skill.ID)
+	must(t, err)
+	equal(t, "category", got.Category, "entry")
+	skills, err := s.Skills.List(ctx)
+	must(t, err)
+	equal(t, "skills", len(skills), 1)
+
+	expires := time.Now().Add(10 * 24 * time.Hour)
+	_, err = s.Skills.AddToCat(ctx, tom.ID, &models.AddCatSkillRequest{SkillID: skill.ID, Level: 2, ExpiresAt: &expires})
+	must(t, err)
+	_, err = s.Skills.UpdateForCat(ctx, tom.ID, skill.ID, &models.UpdateCatSkillRequest{Level: 4, ExpiresAt: &expires})
+	must(t, err)
+	catSkills, err := s.Skills.ListForCat(ctx, tom.ID)
+	must(t, err)
+	equal(t, "cat skills", len(catSkills), 1)
+	expiring, err := s.Skills.ListExpiring(ctx, 30)
+	must(t, err)
+	equal(t, "expiring", len(expiring), 1)
+
+	_, err = s.Skills.SetForMission(ctx, mission.ID, &models.SetMissionSkillsRequest{RequiredSkills: []models.SkillRequirementRequest{{SkillID: skill.ID, MinLevel: 3}}})
+	must(t, err)
+	requirements, err := s.Skills.ListForMission(ctx, mission.ID)
+	must(t, err)
+	equal(t, "requirements", len(requirements), 1)
+
+	candidates, err := s.Recommendations.Candidates(ctx, mission.ID, models.CandidateOptions{SalaryBudget: ptr(money.New(200000, "USD"))})
+	must(t, err)
+	if len(candidates) == 0 || candidates[0].Cat.ID != tom.ID {
+		t.Fatalf("candidates: got %v, want Tom first", candidates)
+	}
+	best, err := s.Recommendations.AutoAssign(ctx, mission.ID, models.CandidateOptions{})
+	must(t, err)
+	equal(t, "assigned", best.Cat.ID, tom.ID)
+
+	// The skill is still required by the mission
+	if err := s.Skills.Delete(ctx, skill.ID); !errors.Is(err, client.ErrBadRequest) {
+		t.Errorf("delete required skill: got %v, want ErrBadRequest", err)
+	}
+	must(t, s.Skills.RemoveFromCat(ctx, tom.ID, skill.ID))
+}
+
+func TestPayrollAndExpenses(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	mission := s.createMission(t, &tom.ID, "John Doe")
+
+	rate, err := s.Payroll.SetHazardRate(ctx, "UA", &models.SetHazardPayRateRequest{Rate: money.New(5000, "USD")})
+	must(t, err)
+	equal(t, "country", rate.Country, "UA")
+	rates, err := s.Payroll.ListHazardRates(ctx)
+	must(t, err)
+	equal(t, "hazard rates", len(rates), 1)
+	must(t, s.Payroll.DeleteHazardRate(ctx, "UA"))
+
+	period := time.Now().UTC().Format("2006-01")
+	preview, err := s.Payroll.Preview(ctx, period)
+	must(t, err)
+	equal(t, "period", preview.Period, period)
+	run, err := s.Payroll.Run(ctx, &models.CreatePayrollRunRequest{Period: period})
+	must(t, err)
+	fetched, err := s.Payroll.GetRun(ctx, run.ID)
+	must(t, err)
+	equal(t, "total", fetched.Total, run.Total)
+	runs, err := s.Payroll.ListRuns(ctx)
+	must(t, err)
+	equal(t, "runs", len(runs), 1)
+	_, err = s.Payroll.Compensation(ctx, tom.ID)
+	must(t, err)
+
+	_, err = s.Expenses.SetBudget(ctx, mission.ID, &models.SetMissionBudgetRequest{Budget: money.New(100000, "USD")})
+	must(t, err)
+	travel, err := s.Expenses.Create(ctx, mission.ID, &models.CreateExpenseRequest{CatID: tom.ID, Category: "travel", Amount: money.New(20000, "USD")})
+	must(t, err)
+	equal(t, "status", travel.Status, models.ExpenseStatusPending)
+	lodging, err := s.Expenses.Create(ctx, mission.ID, &models.CreateExpenseRequest{CatID: tom.ID, Category: "lodging", Amount: money.New(30000, "USD")})
+	must(t, err)
+	gear, err := s.Expenses.Create(ctx, mission.ID, &models.CreateExpenseRequest{CatID: tom.ID, Category: "equipment", Amount: money.New(1000, "USD")})
+	must(t, err)
+
+	approved, err := s.Expenses.Approve(ctx, mission.ID, travel.ID, &models.ReviewExpenseRequest{Note: "ok"})
+	must(t, err)
+	equal(t, "status", approved.Status, models.ExpenseStatusApproved)
+	rejected, err := s.Expenses.Reject(ctx, mission.ID, lodging.ID, nil)
+	must(t, err)
+	equal(t, "status", rejected.Status, models.ExpenseStatusRejected)
+	must(t, s.Expenses.Delete(ctx, mission.ID, gear.ID))
+
+	expenses, err := s.Expenses.List(ctx, mission.ID)
+	must(t, err)
+	equal(t, "expenses", len(expenses), 2)
+	summary, err := s.Expenses.Summary(ctx, mission.ID)
+	must(t, err)
+	equal(t, "approved", summary.Approved.Amount, int64(20000))
+}
+
+func TestDossiersAndCountries(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	mission := s.createMission(t, nil, "John Doe")
+	targetID := mission.Targets[0].ID
+
+	dossier, err := s.Dossiers.Create(ctx, &models.CreateDossierRequest{Name: "John Doe", Aliases: []string{"Johnny"}, Countries: []string{"UA"}})
+	must(t, err)
+	_, err = s.Dossiers.Update(ctx, dossier.ID, &models.UpdateDossierRequest{Name: "John Doe", Summary: "courier", Aliases: []string{"Johnny"}, Countries: []string{"UA"}})
+	must(t, err)
+	got, err := s.Dossiers.Get(ctx, dossier.ID)
+	must(t, err)
+	equal(t, "summary", got.Summary, "courier")
+	dossiers, err := s.Dossiers.List(ctx)
+	must(t, err)
+	equal(t, "dossiers", len(dossiers), 1)
+
+	photo, err := s.Dossiers.AddPhoto(ctx, dossier.ID, &models.AddDossierPhotoRequest{URL: "https://example.com/john.jpg"})
+	must(t, err)
+	must(t, s.Dossiers.DeletePhoto(ctx, dossier.ID, photo.ID))
+
+	matches, err := s.Dossiers.Suggest(ctx, "Jon Doe", "UA", 0)
+	must(t, err)
+	if len(matches) == 0 {
+		t.Error("suggestions: got none")
+	}
+	matches, err = s.Dossiers.SuggestForTarget(ctx, mission.ID, targetID)
+	must(t, err)
+	if len(matches) == 0 {
+		t.Error("target suggestions: got none")
+	}
+	linked, err := s.Dossiers.LinkTarget(ctx, mission.ID, targetID, &models.LinkTargetDossierRequest{DossierID: &dossier.ID})
+	must(t, err)
+	if linked.DossierID == nil || *linked.DossierID != dossier.ID {
+		t.Errorf("linked dossier: got %v, want %d", linked.DossierID, dossier.ID)
+	}
+	view, err := s.Dossiers.View(ctx, dossier.ID)
+	must(t, err)
+	equal(t, "missions", view.Missions, 1)
+
+	_, err = s.Dossiers.LinkTarget(ctx, mission.ID, targetID, &models.LinkTargetDossierRequest{})
+	must(t, err)
+	must(t, s.Dossiers.Delete(ctx, dossier.ID))
+
+	countries, err := s.Countries.List(ctx, "ukr")
+	must(t, err)
+	if len(countries) == 0 || countries[0].Code != "UA" {
+		t.Errorf("countries: got %v, want UA", countries)
+	}
+	unmapped, err := s.Countries.ListUnmapped(ctx)
+	must(t, err)
+	if unmapped == nil {
+		t.Error("unmapped: got null, want an empty list")
+	}
+}
+
+func TestGeoAndMaps(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	mission := s.createMission(t, nil, "John Doe")
+
+	nearby, err := s.Geo.Nearby(ctx, 50.45, 30.52, 10)
+	must(t, err)
+	equal(t, "nearby", len(nearby), 1)
+	within, err := s.Geo.Within(ctx, models.BoundingBox{MinLat: 50, MinLng: 30, MaxLat: 51, MaxLng: 31})
+	must(t, err)
+	equal(t, "within", len(within), 1)
+
+	agency, err := s.Maps.AgencyGeoJSON(ctx, "")
+	must(t, err)
+	if len(agency.Features) == 0 {
+		t.Error("agency map: got no features")
+	}
+	kml, err := s.Maps.AgencyKML(ctx, "")
+	must(t, err)
+	if !strings.Contains(string(kml), "<kml") {
+		t.Errorf("agency KML: got %q", kml)
+	}
+	missionMap, err := s.Maps.MissionGeoJSON(ctx, mission.ID)
+	must(t, err)
+	if len(missionMap.Features) == 0 {
+		t.Error("mission map: got no features")
+	}
+	kml, err = s.Maps.MissionKML(ctx, mission.ID)
+	must(t, err)
+	if !strings.Contains(string(kml), "John Doe") {
+		t.Errorf("mission KML: got %q", kml)
+	}
+}
+
+func TestImportAndExports(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	csv := "name,years_experience,breed,salary,currency\nTom,3,Persian,1000.00,USD\nFelix,4,Siamese,1200.00,USD\n"
+	report, err := s.Import.Import(ctx, strings.NewReader(csv), models.ImportOptions{Type: models.ImportTypeCats, Format: models.ImportFormatCSV})
+	must(t, err)
+	equal(t, "valid rows", report.Valid, 2)
+
+	// The second row has an unknown breed, so nothing is imported and the report says why
+	csv = "name,years_experience,breed,salary,currency\nGarfield,3,Persian,1000.00,USD\nOdie,3,Beagle,1000.00,USD\n"
+	report, err = s.Import.Import(ctx, strings.NewReader(csv), models.ImportOptions{Type: models.ImportTypeCats, Format: models.ImportFormatCSV})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("failed import: got %v, want a 422 APIError", err)
+	}
+	if report == nil || report.Failed != 1 {
+		t.Fatalf("failed import report: got %+v, want one failed row", report)
+	}
+
+	s.createMission(t, nil, "John Doe")
+	for name, export := range map[string]func() (io.ReadCloser, error){
+		"cats":     func() (io.ReadCloser, error) { return s.Exports.Cats(ctx, "csv", models.CatFilter{}) },
+		"missions": func() (io.ReadCloser, error) { return s.Exports.Missions(ctx, "ndjson", models.MissionFilter{}) },
+		"targets":  func() (io.ReadCloser, error) { return s.Exports.Targets(ctx, "csv", models.TargetFilter{}) },
+	} {
+		body, err := export()
+		must(t, err)
+		data, err := io.ReadAll(body)
+		body.Close()
+		must(t, err)
+		if len(data) == 0 {
+			t.Errorf("%s export: got an empty body", name)
+		}
+	}
+}
+
+func TestTrashRetentionAndAudit(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	tom := s.createCat(t, "Tom")
+	must(t, s.Cats.Delete(ctx, tom.ID, nil))
+	items, err := s.Trash.List(ctx, "cat")
+	must(t, err)
+	equal(t, "trash", len(items), 1)
+	_, err = s.Trash.Restore(ctx, "cat", tom.ID)
+	must(t, err)
+	_, err = s.Cats.Get(ctx, tom.ID)
+	must(t, err)
+
+	mission := s.createMission(t, nil, "John Doe")
+	must(t, s.Missions.Delete(ctx, mission.ID))
+	// Purging only takes what has been in the trash longer than the retention period
+	purged, err := s.Trash.Purge(ctx)
+	must(t, err)
+	equal(t, "purged missions within retention", purged.Missions, int64(0))
+	must(t, s.db.Unscoped().Model(&models.Mission{}).Where("id = ?", mission.ID).
+		Update("deleted_at", time.Now().AddDate(0, 0, -31)).Error)
+	purged, err = s.Trash.Purge(ctx)
+	must(t, err)
+	equal(t, "purged missions", purged.Missions, int64(1))
+
+	policy, err := s.Retention.SetPolicy(ctx, "secret", &models.SetRetentionPolicyRequest{PurgeNotesAfterDays: 30})
+	must(t, err)
+	equal(t, "classification", policy.Classification, "secret")
+	policies, err := s.Retention.ListPolicies(ctx)
+	must(t, err)
+	if len(policies) == 0 {
+		t.Error("policies: got none")
+	}
+	must(t, s.Retention.DeletePolicy(ctx, "secret"))
+	_, err = s.Retention.Apply(ctx)
+	must(t, err)
+
+	s.createMission(t, nil, "Jane Roe")
+	request, err := s.Retention.CreateErasureRequest(ctx, &models.CreateErasureRequest{SubjectName: "Jane Roe", Country: "UA"})
+	must(t, err)
+	requests, err := s.Retention.ListErasureRequests(ctx)
+	must(t, err)
+	equal(t, "erasure requests", len(requests), 1)
+	_, err = s.Retention.GetErasureRequest(ctx, request.ID)
+	must(t, err)
+	report, err := s.Retention.ExecuteErasureRequest(ctx, request.ID)
+	must(t, err)
+	equal(t, "targets erased", report.TargetsErased, 1)
+	stored, err := s.Retention.GetErasureReport(ctx, request.ID)
+	must(t, err)
+	equal(t, "stored report", stored.TargetsErased, 1)
+
+	entries, err := s.Admin.AuditLog(ctx, models.AuditFilter{Action: "POST /api/v1/erasure-requests/:id/execute"})
+	must(t, err)
+	equal(t, "audit entries", len(entries), 1)
+}
+
+func TestErrors(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	_, err := s.Cats.Get(ctx, 999)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("missing cat: got %v, want ErrNotFound", err)
+	}
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("missing cat: got %T, want *APIError", err)
+	}
+	equal(t, "status", apiErr.StatusCode, http.StatusNotFound)
+	equal(t, "method", apiErr.Method, "GET")
+	equal(t, "path", apiErr.Path, "/cats/999")
+	if apiErr.Message == "" || strings.Contains(apiErr.Message, "{") {
+		t.Errorf("message: got %q, want the error field of the body", apiErr.Message)
+	}
+
+	_, err = s.Cats.Create(ctx, &models.CreateCatRequest{Name: "Tom", YearsExperience: 3, Breed: "Unicorn", Salary: money.New(100000, "USD")})
+	if !errors.Is(err, client.ErrBadRequest) {
+		t.Errorf("unknown breed: got %v, want ErrBadRequest", err)
+	}
+	if errors.Is(err, client.ErrNotFound) || errors.Is(err, client.ErrServer) {
+		t.Errorf("unknown breed: %v matches another error kind", err)
+	}
+
+	// Authentication is off in newServer, so a 401 comes from a server that requires it
+	unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":"missing token"}`)
+			return
+		}
+		io.WriteString(w, `[]`)
+	}))
+	defer unauthorized.Close()
+
+	_, err = client.New(unauthorized.URL).Skills.List(ctx)
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("without token: got %v, want ErrUnauthorized", err)
+	}
+	if errors.As(err, &apiErr) {
+		equal(t, "message", apiErr.Message, "missing token")
+	}
+	_, err = client.New(unauthorized.URL, client.WithToken("secret")).Skills.List(ctx)
+	must(t, err)
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+
+	// failing answers 500 to the first failures requests and 200 to the rest
+	failing := func(failures int64) (*httptest.Server, *atomic.Int64) {
+		var calls atomic.Int64
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= failures {
+				w.WriteHeader(http.StatusInternalServerError)
+				io.WriteString(w, `{"error":"database is down"}`)
+				return
+			}
+			io.WriteString(w, `{"id":1,"name":"Lockpicking"}`)
+		}))
+		t.Cleanup(ts.Close)
+		return ts, &calls
+	}
+
+	t.Run("recovers", func(t *testing.T) {
+		ts, calls := failing(2)
+		skill, err := client.New(ts.URL, client.WithRetries(3, time.Millisecond)).Skills.Get(ctx, 1)
+		must(t, err)
+		equal(t, "name", skill.Name, "Lockpicking")
+		equal(t, "requests", calls.Load(), int64(3))
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		ts, calls := failing(10)
+		_, err := client.New(ts.URL, client.WithRetries(2, time.Millisecond)).Skills.Get(ctx, 1)
+		if !errors.Is(err, client.ErrServer) {
+			t.Errorf("got %v, want ErrServer", err)
+		}
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) {
+			equal(t, "message", apiErr.Message, "database is down")
+		}
+		equal(t, "requests", calls.Load(), int64(3))
+	})
+
+	t.Run("never retries POST", func(t *testing.T) {
+		ts, calls := failing(1)
+		_, err := client.New(ts.URL, client.WithRetries(3, time.Millisecond)).Skills.Create(ctx, &models.CreateSkillRequest{Name: "Lockpicking"})
+		if !errors.Is(err, client.ErrServer) {
+			t.Errorf("got %v, want ErrServer", err)
+		}
+		equal(t, "requests", calls.Load(), int64(1))
+	})
+
+	t.Run("does not retry 4xx", func(t *testing.T) {
+		s := newServer(t)
+		s.Client = client.New(s.url, client.WithRetries(3, time.Millisecond))
+		before := s.requests.Load()
+		_, err := s.Cats.Get(ctx, 999)
+		if !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("got %v, want ErrNotFound", err)
+		}
+		equal(t, "requests", s.requests.Load()-before, int64(1))
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ts, _ := failing(10)
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := client.New(ts.URL, client.WithRetries(10, time.Second)).Skills.Get(ctx, 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestIterators(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"Tom", "Felix", "Garfield", "Salem", "Luna"} {
+		s.createCat(t, name)
+	}
+
+	before := s.requests.Load()
+	cats, err := s.Cats.Iter(models.CatFilter{Limit: 2}).All(ctx)
+	must(t, err)
+	equal(t, "cats", len(cats), 5)
+	// Pages of 2, 2 and 1; the short page is the last one
+	equal(t, "requests", s.requests.Load()-before, int64(3))
+	seen := make(map[uint]bool)
+	for _, cat := range cats {
+		if seen[cat.ID] {
+			t.Errorf("cat %d returned twice", cat.ID)
+		}
+		seen[cat.ID] = true
+	}
+
+	// An offset skips the first cats; a full last page takes one more request to find the end
+	before = s.requests.Load()
+	cats, err = s.Cats.Iter(models.CatFilter{Limit: 2, Offset: 1}).All(ctx)
+	must(t, err)
+	equal(t, "cats from offset 1", len(cats), 4)
+	equal(t, "requests", s.requests.Load()-before, int64(3))
+
+	it := s.Cats.Iter(models.CatFilter{Limit: 2, Status: models.CatStatusRetired})
+	if it.Next(ctx) {
+		t.Error("retired cats: got one, want none")
+	}
+	must(t, it.Err())
+
+	for i := 0; i < 3; i++ {
+		s.createMission(t, nil, "John Doe")
+	}
+	missions, err := s.Missions.Iter(models.MissionFilter{Limit: 2}).All(ctx)
+	must(t, err)
+	equal(t, "missions", len(missions), 3)
+
+	// An error ends the iteration and is kept
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":"invalid status"}`)
+	}))
+	defer broken.Close()
+	it = client.New(broken.URL).Cats.Iter(models.CatFilter{Limit: 2})
+	if it.Next(ctx) {
+		t.Error("broken server: got a cat")
+	}
+	if !errors.Is(it.Err(), client.ErrBadRequest) {
+		t.Errorf("broken server: got %v, want ErrBadRequest", it.Err())
+	}
+}
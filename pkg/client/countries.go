@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"spy-cat-agency/internal/country"
+	"spy-cat-agency/internal/models"
+)
+
+type CountryService struct {
+	client *Client
+}
+
+// List returns the ISO 3166 countries whose code or name matches q, or all of them when q is empty
+func (s *CountryService) List(ctx context.Context, q string) ([]country.Country, error) {
+	query := url.Values{}
+	setString(query, "q", q)
+
+	var countries []country.Country
+	err := s.client.do(ctx, "GET", "/countries", query, nil, &countries)
+	return countries, err
+}
+
+// ListUnmapped returns stored country values that could not be matched to an ISO code
+func (s *CountryService) ListUnmapped(ctx context.Context) ([]models.UnmappedCountry, error) {
+	var unmapped []models.UnmappedCountry
+	err := s.client.do(ctx, "GET", "/countries/unmapped", nil, nil, &unmapped)
+	return unmapped, err
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type DossierService struct {
+	client *Client
+}
+
+func (s *DossierService) Create(ctx context.Context, req *models.CreateDossierRequest) (*models.Dossier, error) {
+	var dossier models.Dossier
+	if err := s.client.do(ctx, "POST", "/dossiers", nil, req, &dossier); err != nil {
+		return nil, err
+	}
+	return &dossier, nil
+}
+
+func (s *DossierService) List(ctx context.Context) ([]models.Dossier, error) {
+	var dossiers []models.Dossier
+	err := s.client.do(ctx, "GET", "/dossiers", nil, nil, &dossiers)
+	return dossiers, err
+}
+
+func (s *DossierService) Get(ctx context.Context, id uint) (*models.Dossier, error) {
+	var dossier models.Dossier
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/dossiers/%d", id), nil, nil, &dossier); err != nil {
+		return nil, err
+	}
+	return &dossier, nil
+}
+
+func (s *DossierService) Update(ctx context.Context, id uint, req *models.UpdateDossierRequest) (*models.Dossier, error) {
+	var dossier models.Dossier
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/dossiers/%d", id), nil, req, &dossier); err != nil {
+		return nil, err
+	}
+	return &dossier, nil
+}
+
+func (s *DossierService) Delete(ctx context.Context, id uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/dossiers/%d", id), nil, nil, nil)
+}
+
+// View returns the dossier with a summary of the missions and targets linked to it
+func (s *DossierService) View(ctx context.Context, id uint) (*models.DossierView, error) {
+	var view models.DossierView
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/dossiers/%d/view", id), nil, nil, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (s *DossierService) AddPhoto(ctx context.Context, id uint, req *models.AddDossierPhotoRequest) (*models.DossierPhoto, error) {
+	var photo models.DossierPhoto
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/dossiers/%d/photos", id), nil, req, &photo); err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+func (s *DossierService) DeletePhoto(ctx context.Context, id, photoID uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/dossiers/%d/photos/%d", id, photoID), nil, nil, nil)
+}
+
+// Suggest finds dossiers similar to a name and country; limit 0 uses the server default
+func (s *DossierService) Suggest(ctx context.Context, name, country string, limit int) ([]models.DossierMatch, error) {
+	query := url.Values{}
+	setString(query, "name", name)
+	setString(query, "country", country)
+	setInt(query, "limit", limit)
+
+	var matches []models.DossierMatch
+	err := s.client.do(ctx, "GET", "/dossiers/suggest", query, nil, &matches)
+	return matches, err
+}
+
+func (s *DossierService) SuggestForTarget(ctx context.Context, missionID, targetID uint) ([]models.DossierMatch, error) {
+	var matches []models.DossierMatch
+	err := s.client.do(ctx, "GET", targetPath(missionID, targetID, "/dossier-suggestions"), nil, nil, &matches)
+	return matches, err
+}
+
+func (s *DossierService) LinkTarget(ctx context.Context, missionID, targetID uint, req *models.LinkTargetDossierRequest) (*models.Target, error) {
+	var target models.Target
+	if err := s.client.do(ctx, "PUT", targetPath(missionID, targetID, "/dossier"), nil, req, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"spy-cat-agency/internal/models"
+)
+
+type ExpenseService struct {
+	client *Client
+}
+
+func (s *ExpenseService) SetBudget(ctx context.Context, missionID uint, req *models.SetMissionBudgetRequest) (*models.Mission, error) {
+	var mission models.Mission
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/missions/%d/budget", missionID), nil, req, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+func (s *ExpenseService) List(ctx context.Context, missionID uint) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := s.client.do(ctx, "GET", expensesPath(missionID, ""), nil, nil, &expenses)
+	return expenses, err
+}
+
+func (s *ExpenseService) Create(ctx context.Context, missionID uint, req *models.CreateExpenseRequest) (*models.Expense, error) {
+	var expense models.Expense
+	if err := s.client.do(ctx, "POST", expensesPath(missionID, ""), nil, req, &expense); err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (s *ExpenseService) Summary(ctx context.Context, missionID uint) (*models.ExpenseSummary, error) {
+	var summary models.ExpenseSummary
+	if err := s.client.do(ctx, "GET", expensesPath(missionID, "/summary"), nil, nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Approve and Reject take an optional review comment; req may be nil
+func (s *ExpenseService) Approve(ctx context.Context, missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error) {
+	return s.review(ctx, missionID, expenseID, "approve", req)
+}
+
+func (s *ExpenseService) Reject(ctx context.Context, missionID, expenseID uint, req *models.ReviewExpenseRequest) (*models.Expense, error) {
+	return s.review(ctx, missionID, expenseID, "reject", req)
+}
+
+func (s *ExpenseService) Delete(ctx context.Context, missionID, expenseID uint) error {
+	return s.client.do(ctx, "DELETE", expensesPath(missionID, fmt.Sprintf("/%d", expenseID)), nil, nil, nil)
+}
+
+func (s *ExpenseService) review(ctx context.Context, missionID, expenseID uint, action string, req *models.ReviewExpenseRequest) (*models.Expense, error) {
+	var body interface{}
+	if req != nil {
+		body = req
+	}
+	var expense models.Expense
+	if err := s.client.do(ctx, "PUT", expensesPath(missionID, fmt.Sprintf("/%d/%s", expenseID, action)), nil, body, &expense); err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func expensesPath(missionID uint, suffix string) string {
+	return fmt.Sprintf("/missions/%d/expenses%s", missionID, suffix)
+}
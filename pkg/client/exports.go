@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+)
+
+// ExportService downloads tables as csv, ndjson or xlsx. The body is streamed: copy it where it belongs
+// and close it. The default client gives up after 30 seconds, so large exports need WithHTTPClient.
+type ExportService struct {
+	client *Client
+}
+
+func (s *ExportService) Cats(ctx context.Context, format string, filter models.CatFilter) (io.ReadCloser, error) {
+	return s.export(ctx, "/exports/cats", format, catQuery(filter))
+}
+
+func (s *ExportService) Missions(ctx context.Context, format string, filter models.MissionFilter) (io.ReadCloser, error) {
+	return s.export(ctx, "/exports/missions", format, missionQuery(filter))
+}
+
+func (s *ExportService) Targets(ctx context.Context, format string, filter models.TargetFilter) (io.ReadCloser, error) {
+	query := url.Values{}
+	if filter.MissionID != 0 {
+		query.Set("mission_id", strconv.FormatUint(uint64(filter.MissionID), 10))
+	}
+	if filter.CatID != 0 {
+		query.Set("cat_id", strconv.FormatUint(uint64(filter.CatID), 10))
+	}
+	return s.export(ctx, "/exports/targets", format, query)
+}
+
+func (s *ExportService) export(ctx context.Context, path, format string, query url.Values) (io.ReadCloser, error) {
+	setString(query, "format", format)
+
+	var body io.ReadCloser
+	if err := s.client.do(ctx, "GET", path, query, nil, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
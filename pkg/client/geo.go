@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+)
+
+type GeoService struct {
+	client *Client
+}
+
+// Nearby returns targets within radiusKm of a point, nearest first
+func (s *GeoService) Nearby(ctx context.Context, lat, lng, radiusKm float64) ([]models.TargetDistance, error) {
+	query := url.Values{}
+	setFloat(query, "lat", lat)
+	setFloat(query, "lng", lng)
+	setFloat(query, "radius_km", radiusKm)
+
+	var targets []models.TargetDistance
+	err := s.client.do(ctx, "GET", "/targets/nearby", query, nil, &targets)
+	return targets, err
+}
+
+func (s *GeoService) Within(ctx context.Context, box models.BoundingBox) ([]models.Target, error) {
+	query := url.Values{}
+	setFloat(query, "min_lat", box.MinLat)
+	setFloat(query, "min_lng", box.MinLng)
+	setFloat(query, "max_lat", box.MaxLat)
+	setFloat(query, "max_lng", box.MaxLng)
+
+	var targets []models.Target
+	err := s.client.do(ctx, "GET", "/targets/within", query, nil, &targets)
+	return targets, err
+}
+
+func setFloat(query url.Values, key string, value float64) {
+	query.Set(key, strconv.FormatFloat(value, 'f', -1, 64))
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type ImportService struct {
+	client *Client
+}
+
+// Import uploads a CSV or NDJSON file of cats or missions; opts.Type and opts.Format are required.
+// When an all-or-nothing import fails, the report with the row errors is returned together with the *APIError.
+func (s *ImportService) Import(ctx context.Context, r io.Reader, opts models.ImportOptions) (*models.ImportReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	setString(query, "type", opts.Type)
+	setString(query, "format", opts.Format)
+	setString(query, "mode", opts.Mode)
+	if opts.DryRun {
+		query.Set("dry_run", "true")
+	}
+
+	contentType := "application/x-ndjson"
+	if opts.Format == models.ImportFormatCSV {
+		contentType = "text/csv"
+	}
+
+	var report models.ImportReport
+	err = s.client.do(ctx, "POST", "/import", query, rawBody{contentType: contentType, data: data}, &report)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+		if json.Unmarshal(apiErr.body, &report) == nil {
+			apiErr.Message = fmt.Sprintf("%d of %d rows failed, nothing was imported", report.Failed, report.Rows)
+			return &report, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
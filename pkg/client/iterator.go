@@ -0,0 +1,71 @@
+package client
+
+import "context"
+
+const defaultPageSize = 100
+
+// Iterator walks a paginated list one item at a time, fetching the next page when the current one is used up:
+//
+//	it := c.Cats.Iter(models.CatFilter{Status: "active"})
+//	for it.Next(ctx) {
+//		cat := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	fetch    func(ctx context.Context, limit, offset int) ([]T, error)
+	pageSize int
+	offset   int
+	page     []T
+	index    int
+	done     bool
+	err      error
+}
+
+func newIterator[T any](pageSize, offset int, fetch func(ctx context.Context, limit, offset int) ([]T, error)) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return &Iterator[T]{fetch: fetch, pageSize: pageSize, offset: offset, index: -1}
+}
+
+// Next advances to the next item and reports whether there is one
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index+1 < len(it.page) {
+		it.index++
+		return true
+	}
+	// A short page is the last one
+	if it.done {
+		return false
+	}
+
+	page, err := it.fetch(ctx, it.pageSize, it.offset)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page, it.index = page, 0
+	it.offset += len(page)
+	it.done = len(page) < it.pageSize
+	return len(page) > 0
+}
+
+func (it *Iterator[T]) Value() T {
+	return it.page[it.index]
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All collects the remaining items
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/geo"
+)
+
+type MapService struct {
+	client *Client
+}
+
+// AgencyGeoJSON returns the markers and tracks of all missions, or only those in state when it is set
+func (s *MapService) AgencyGeoJSON(ctx context.Context, state string) (*geo.FeatureCollection, error) {
+	query := url.Values{}
+	setString(query, "state", state)
+
+	var collection geo.FeatureCollection
+	if err := s.client.do(ctx, "GET", "/map.geojson", query, nil, &collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// AgencyKML returns the KML document as written by the server
+func (s *MapService) AgencyKML(ctx context.Context, state string) ([]byte, error) {
+	query := url.Values{}
+	setString(query, "state", state)
+
+	var kml []byte
+	err := s.client.do(ctx, "GET", "/map.kml", query, nil, &kml)
+	return kml, err
+}
+
+func (s *MapService) MissionGeoJSON(ctx context.Context, missionID uint) (*geo.FeatureCollection, error) {
+	var collection geo.FeatureCollection
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/missions/%d/map.geojson", missionID), nil, nil, &collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (s *MapService) MissionKML(ctx context.Context, missionID uint) ([]byte, error) {
+	var kml []byte
+	err := s.client.do(ctx, "GET", fmt.Sprintf("/missions/%d/map.kml", missionID), nil, nil, &kml)
+	return kml, err
+}
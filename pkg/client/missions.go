@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"spy-cat-agency/internal/models"
+)
+
+type MissionService struct {
+	client *Client
+}
+
+func (s *MissionService) Create(ctx context.Context, req *models.CreateMissionRequest) (*models.Mission, error) {
+	var mission models.Mission
+	if err := s.client.do(ctx, "POST", "/missions", nil, req, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// List returns the missions matching filter; set filter.Limit to get a single page
+func (s *MissionService) List(ctx context.Context, filter models.MissionFilter) ([]models.Mission, error) {
+	var missions []models.Mission
+	err := s.client.do(ctx, "GET", "/missions", missionQuery(filter), nil, &missions)
+	return missions, err
+}
+
+func missionQuery(filter models.MissionFilter) url.Values {
+	query := url.Values{}
+	if filter.Overdue != nil {
+		query.Set("overdue", strconv.FormatBool(*filter.Overdue))
+	}
+	setTime(query, "due_before", filter.DueBefore)
+	setString(query, "state", filter.State)
+	setPage(query, filter.Limit, filter.Offset)
+	return query
+}
+
+// Iter pages through the missions matching filter, filter.Limit missions per request
+func (s *MissionService) Iter(filter models.MissionFilter) *Iterator[models.Mission] {
+	return newIterator(filter.Limit, filter.Offset, func(ctx context.Context, limit, offset int) ([]models.Mission, error) {
+		page := filter
+		page.Limit, page.Offset = limit, offset
+		return s.List(ctx, page)
+	})
+}
+
+func (s *MissionService) Get(ctx context.Context, id uint) (*models.Mission, error) {
+	var mission models.Mission
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/missions/%d", id), nil, nil, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+func (s *MissionService) Update(ctx context.Context, id uint, req *models.UpdateMissionRequest) (*models.Mission, error) {
+	var mission models.Mission
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/missions/%d", id), nil, req, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+func (s *MissionService) Delete(ctx context.Context, id uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/missions/%d", id), nil, nil, nil)
+}
+
+func (s *MissionService) AssignCat(ctx context.Context, id, catID uint) error {
+	body := map[string]uint{"cat_id": catID}
+	return s.client.do(ctx, "PUT", fmt.Sprintf("/missions/%d/assign", id), nil, body, nil)
+}
+
+// AddCat puts another cat on the mission; the first cat on a mission becomes its lead
+func (s *MissionService) AddCat(ctx context.Context, id, catID uint) (*models.Mission, error) {
+	body := map[string]uint{"cat_id": catID}
+	var mission models.Mission
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/missions/%d/cats", id), nil, body, &mission); err != nil {
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// RemoveCat takes a cat other than the lead off the mission
+func (s *MissionService) RemoveCat(ctx context.Context, id, catID uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/missions/%d/cats/%d", id, catID), nil, nil, nil)
+}
+
+func (s *MissionService) Complete(ctx context.Context, id uint) error {
+	return s.client.do(ctx, "PUT", fmt.Sprintf("/missions/%d/complete", id), nil, nil, nil)
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type PayrollService struct {
+	client *Client
+}
+
+// Preview calculates the payroll run for period (YYYY-MM) without saving it
+func (s *PayrollService) Preview(ctx context.Context, period string) (*models.PayrollRun, error) {
+	query := url.Values{}
+	setString(query, "period", period)
+
+	var run models.PayrollRun
+	if err := s.client.do(ctx, "GET", "/payroll/preview", query, nil, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (s *PayrollService) Run(ctx context.Context, req *models.CreatePayrollRunRequest) (*models.PayrollRun, error) {
+	var run models.PayrollRun
+	if err := s.client.do(ctx, "POST", "/payroll/runs", nil, req, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (s *PayrollService) ListRuns(ctx context.Context) ([]models.PayrollRun, error) {
+	var runs []models.PayrollRun
+	err := s.client.do(ctx, "GET", "/payroll/runs", nil, nil, &runs)
+	return runs, err
+}
+
+func (s *PayrollService) GetRun(ctx context.Context, id uint) (*models.PayrollRun, error) {
+	var run models.PayrollRun
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/payroll/runs/%d", id), nil, nil, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (s *PayrollService) Compensation(ctx context.Context, catID uint) (*models.Compensation, error) {
+	var compensation models.Compensation
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/cats/%d/compensation", catID), nil, nil, &compensation); err != nil {
+		return nil, err
+	}
+	return &compensation, nil
+}
+
+func (s *PayrollService) ListHazardRates(ctx context.Context) ([]models.HazardPayRate, error) {
+	var rates []models.HazardPayRate
+	err := s.client.do(ctx, "GET", "/payroll/hazard-rates", nil, nil, &rates)
+	return rates, err
+}
+
+func (s *PayrollService) SetHazardRate(ctx context.Context, country string, req *models.SetHazardPayRateRequest) (*models.HazardPayRate, error) {
+	var rate models.HazardPayRate
+	if err := s.client.do(ctx, "PUT", "/payroll/hazard-rates/"+url.PathEscape(country), nil, req, &rate); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (s *PayrollService) DeleteHazardRate(ctx context.Context, country string) error {
+	return s.client.do(ctx, "DELETE", "/payroll/hazard-rates/"+url.PathEscape(country), nil, nil, nil)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func setString(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func setInt(query url.Values, key string, value int) {
+	if value != 0 {
+		query.Set(key, strconv.Itoa(value))
+	}
+}
+
+// setPage adds limit and offset; without a limit the API returns everything and ignores the offset
+func setPage(query url.Values, limit, offset int) {
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+		setInt(query, "offset", offset)
+	}
+}
+
+func setTime(query url.Values, key string, value *time.Time) {
+	if value != nil {
+		query.Set(key, value.Format(time.RFC3339))
+	}
+}
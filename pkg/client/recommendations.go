@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type RecommendationService struct {
+	client *Client
+}
+
+// Candidates ranks the cats that could take a mission, best first
+func (s *RecommendationService) Candidates(ctx context.Context, missionID uint, opts models.CandidateOptions) ([]models.CandidateScore, error) {
+	var candidates []models.CandidateScore
+	err := s.client.do(ctx, "GET", fmt.Sprintf("/missions/%d/candidates", missionID), candidateQuery(opts), nil, &candidates)
+	return candidates, err
+}
+
+// AutoAssign assigns the best candidate to the mission and returns it
+func (s *RecommendationService) AutoAssign(ctx context.Context, missionID uint, opts models.CandidateOptions) (*models.CandidateScore, error) {
+	var candidate models.CandidateScore
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/missions/%d/auto-assign", missionID), candidateQuery(opts), nil, &candidate); err != nil {
+		return nil, err
+	}
+	return &candidate, nil
+}
+
+func candidateQuery(opts models.CandidateOptions) url.Values {
+	query := url.Values{}
+	setInt(query, "limit", opts.Limit)
+	if opts.SalaryBudget != nil {
+		query.Set("budget", opts.SalaryBudget.Decimal())
+		setString(query, "currency", opts.SalaryBudget.Currency)
+	}
+	return query
+}
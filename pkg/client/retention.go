@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type RetentionService struct {
+	client *Client
+}
+
+func (s *RetentionService) ListPolicies(ctx context.Context) ([]models.RetentionPolicy, error) {
+	var policies []models.RetentionPolicy
+	err := s.client.do(ctx, "GET", "/retention/policies", nil, nil, &policies)
+	return policies, err
+}
+
+func (s *RetentionService) SetPolicy(ctx context.Context, classification string, req *models.SetRetentionPolicyRequest) (*models.RetentionPolicy, error) {
+	var policy models.RetentionPolicy
+	if err := s.client.do(ctx, "PUT", "/retention/policies/"+url.PathEscape(classification), nil, req, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (s *RetentionService) DeletePolicy(ctx context.Context, classification string) error {
+	return s.client.do(ctx, "DELETE", "/retention/policies/"+url.PathEscape(classification), nil, nil, nil)
+}
+
+// Apply purges or pseudonymizes target notes that are past their retention period
+func (s *RetentionService) Apply(ctx context.Context) (*models.RetentionResult, error) {
+	var result models.RetentionResult
+	if err := s.client.do(ctx, "POST", "/retention/apply", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *RetentionService) CreateErasureRequest(ctx context.Context, req *models.CreateErasureRequest) (*models.ErasureRequest, error) {
+	var request models.ErasureRequest
+	if err := s.client.do(ctx, "POST", "/erasure-requests", nil, req, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (s *RetentionService) ListErasureRequests(ctx context.Context) ([]models.ErasureRequest, error) {
+	var requests []models.ErasureRequest
+	err := s.client.do(ctx, "GET", "/erasure-requests", nil, nil, &requests)
+	return requests, err
+}
+
+func (s *RetentionService) GetErasureRequest(ctx context.Context, id uint) (*models.ErasureRequest, error) {
+	var request models.ErasureRequest
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/erasure-requests/%d", id), nil, nil, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (s *RetentionService) ExecuteErasureRequest(ctx context.Context, id uint) (*models.ErasureReport, error) {
+	var report models.ErasureReport
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/erasure-requests/%d/execute", id), nil, nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (s *RetentionService) GetErasureReport(ctx context.Context, id uint) (*models.ErasureReport, error) {
+	var report models.ErasureReport
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/erasure-requests/%d/report", id), nil, nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type SkillService struct {
+	client *Client
+}
+
+func (s *SkillService) Create(ctx context.Context, req *models.CreateSkillRequest) (*models.Skill, error) {
+	var skill models.Skill
+	if err := s.client.do(ctx, "POST", "/skills", nil, req, &skill); err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+func (s *SkillService) List(ctx context.Context) ([]models.Skill, error) {
+	var skills []models.Skill
+	err := s.client.do(ctx, "GET", "/skills", nil, nil, &skills)
+	return skills, err
+}
+
+func (s *SkillService) Get(ctx context.Context, id uint) (*models.Skill, error) {
+	var skill models.Skill
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/skills/%d", id), nil, nil, &skill); err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+func (s *SkillService) Update(ctx context.Context, id uint, req *models.UpdateSkillRequest) (*models.Skill, error) {
+	var skill models.Skill
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/skills/%d", id), nil, req, &skill); err != nil {
+		return nil, err
+	}
+	return &skill, nil
+}
+
+func (s *SkillService) Delete(ctx context.Context, id uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/skills/%d", id), nil, nil, nil)
+}
+
+// ListExpiring returns certifications expiring within the given number of days; 0 uses the server default of 30
+func (s *SkillService) ListExpiring(ctx context.Context, days int) ([]models.CatSkill, error) {
+	query := url.Values{}
+	setInt(query, "days", days)
+
+	var catSkills []models.CatSkill
+	err := s.client.do(ctx, "GET", "/skills/expiring", query, nil, &catSkills)
+	return catSkills, err
+}
+
+func (s *SkillService) ListForCat(ctx context.Context, catID uint) ([]models.CatSkill, error) {
+	var catSkills []models.CatSkill
+	err := s.client.do(ctx, "GET", fmt.Sprintf("/cats/%d/skills", catID), nil, nil, &catSkills)
+	return catSkills, err
+}
+
+func (s *SkillService) AddToCat(ctx context.Context, catID uint, req *models.AddCatSkillRequest) (*models.CatSkill, error) {
+	var catSkill models.CatSkill
+	if err := s.client.do(ctx, "POST", fmt.Sprintf("/cats/%d/skills", catID), nil, req, &catSkill); err != nil {
+		return nil, err
+	}
+	return &catSkill, nil
+}
+
+func (s *SkillService) UpdateForCat(ctx context.Context, catID, skillID uint, req *models.UpdateCatSkillRequest) (*models.CatSkill, error) {
+	var catSkill models.CatSkill
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/cats/%d/skills/%d", catID, skillID), nil, req, &catSkill); err != nil {
+		return nil, err
+	}
+	return &catSkill, nil
+}
+
+func (s *SkillService) RemoveFromCat(ctx context.Context, catID, skillID uint) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/cats/%d/skills/%d", catID, skillID), nil, nil, nil)
+}
+
+func (s *SkillService) ListForMission(ctx context.Context, missionID uint) ([]models.MissionSkillRequirement, error) {
+	var requirements []models.MissionSkillRequirement
+	err := s.client.do(ctx, "GET", fmt.Sprintf("/missions/%d/skills", missionID), nil, nil, &requirements)
+	return requirements, err
+}
+
+func (s *SkillService) SetForMission(ctx context.Context, missionID uint, req *models.SetMissionSkillsRequest) ([]models.MissionSkillRequirement, error) {
+	var requirements []models.MissionSkillRequirement
+	err := s.client.do(ctx, "PUT", fmt.Sprintf("/missions/%d/skills", missionID), nil, req, &requirements)
+	return requirements, err
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"spy-cat-agency/internal/models"
+)
+
+type TargetService struct {
+	client *Client
+}
+
+func (s *TargetService) List(ctx context.Context, missionID uint) ([]models.Target, error) {
+	var targets []models.Target
+	err := s.client.do(ctx, "GET", targetsPath(missionID), nil, nil, &targets)
+	return targets, err
+}
+
+// ListByCat returns the targets assigned to a cat across missions
+func (s *TargetService) ListByCat(ctx context.Context, catID uint) ([]models.Target, error) {
+	var targets []models.Target
+	err := s.client.do(ctx, "GET", fmt.Sprintf("/cats/%d/targets", catID), nil, nil, &targets)
+	return targets, err
+}
+
+func (s *TargetService) Add(ctx context.Context, missionID uint, req *models.AddTargetRequest) (*models.Target, error) {
+	var target models.Target
+	if err := s.client.do(ctx, "POST", targetsPath(missionID), nil, req, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (s *TargetService) Update(ctx context.Context, missionID, targetID uint, req *models.UpdateTargetRequest) (*models.Target, error) {
+	var target models.Target
+	if err := s.client.do(ctx, "PUT", targetPath(missionID, targetID, ""), nil, req, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (s *TargetService) Delete(ctx context.Context, missionID, targetID uint) error {
+	return s.client.do(ctx, "DELETE", targetPath(missionID, targetID, ""), nil, nil, nil)
+}
+
+// Complete marks a target as completed; req may be nil when the mission has a single cat
+func (s *TargetService) Complete(ctx context.Context, missionID, targetID uint, req *models.CompleteTargetRequest) error {
+	var body interface{}
+	if req != nil {
+		body = req
+	}
+	return s.client.do(ctx, "PUT", targetPath(missionID, targetID, "/complete"), nil, body, nil)
+}
+
+func (s *TargetService) UpdateNotes(ctx context.Context, missionID, targetID uint, req *models.UpdateTargetNotesRequest) error {
+	return s.client.do(ctx, "PUT", targetPath(missionID, targetID, "/notes"), nil, req, nil)
+}
+
+func (s *TargetService) ListNotes(ctx context.Context, missionID, targetID uint) ([]models.TargetNote, error) {
+	var notes []models.TargetNote
+	err := s.client.do(ctx, "GET", targetPath(missionID, targetID, "/notes"), nil, nil, &notes)
+	return notes, err
+}
+
+func (s *TargetService) AddNote(ctx context.Context, missionID, targetID uint, req *models.AddTargetNoteRequest) (*models.TargetNote, error) {
+	var note models.TargetNote
+	if err := s.client.do(ctx, "POST", targetPath(missionID, targetID, "/notes"), nil, req, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (s *TargetService) AssignCat(ctx context.Context, missionID, targetID uint, req *models.AssignTargetCatRequest) (*models.Target, error) {
+	var target models.Target
+	if err := s.client.do(ctx, "PUT", targetPath(missionID, targetID, "/assign"), nil, req, &target); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func targetsPath(missionID uint) string {
+	return fmt.Sprintf("/missions/%d/targets", missionID)
+}
+
+func targetPath(missionID, targetID uint, suffix string) string {
+	return fmt.Sprintf("/missions/%d/targets/%d%s", missionID, targetID, suffix)
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"spy-cat-agency/internal/models"
+)
+
+type TrashService struct {
+	client *Client
+}
+
+// List returns deleted records of every kind, or only of itemType: "cat", "mission" or "target"
+func (s *TrashService) List(ctx context.Context, itemType string) ([]models.TrashItem, error) {
+	query := url.Values{}
+	setString(query, "type", itemType)
+
+	var items []models.TrashItem
+	err := s.client.do(ctx, "GET", "/trash", query, nil, &items)
+	return items, err
+}
+
+// Restore undeletes a record; the restored record is returned as JSON because its type depends on itemType
+func (s *TrashService) Restore(ctx context.Context, itemType string, id uint) (json.RawMessage, error) {
+	var record json.RawMessage
+	err := s.client.do(ctx, "POST", fmt.Sprintf("/trash/%s/%d/restore", url.PathEscape(itemType), id), nil, nil, &record)
+	return record, err
+}
+
+func (s *TrashService) Purge(ctx context.Context) (*models.PurgeResult, error) {
+	var result models.PurgeResult
+	if err := s.client.do(ctx, "POST", "/trash/purge", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}